@@ -1,24 +1,46 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-co-op/gocron"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/calendar"
 	"github.com/soerenschneider/aether/internal/config"
+	"github.com/soerenschneider/aether/internal/datasource/airquality"
 	"github.com/soerenschneider/aether/internal/datasource/alertmanager"
 	"github.com/soerenschneider/aether/internal/datasource/astral"
+	"github.com/soerenschneider/aether/internal/datasource/backups"
 	"github.com/soerenschneider/aether/internal/datasource/cached"
 	"github.com/soerenschneider/aether/internal/datasource/caldav"
 	"github.com/soerenschneider/aether/internal/datasource/carddav"
+	"github.com/soerenschneider/aether/internal/datasource/certificates"
+	"github.com/soerenschneider/aether/internal/datasource/countdowns"
+	"github.com/soerenschneider/aether/internal/datasource/departures"
+	"github.com/soerenschneider/aether/internal/datasource/feeds"
+	"github.com/soerenschneider/aether/internal/datasource/forge"
+	"github.com/soerenschneider/aether/internal/datasource/fx"
+	"github.com/soerenschneider/aether/internal/datasource/holidays"
+	"github.com/soerenschneider/aether/internal/datasource/homeassistant"
+	"github.com/soerenschneider/aether/internal/datasource/imap"
 	"github.com/soerenschneider/aether/internal/datasource/logs"
+	"github.com/soerenschneider/aether/internal/datasource/mqtt"
+	"github.com/soerenschneider/aether/internal/datasource/notes"
+	"github.com/soerenschneider/aether/internal/datasource/probe"
 	"github.com/soerenschneider/aether/internal/datasource/static"
+	"github.com/soerenschneider/aether/internal/datasource/stocks"
+	"github.com/soerenschneider/aether/internal/datasource/system"
 	"github.com/soerenschneider/aether/internal/datasource/taskwarrior"
+	"github.com/soerenschneider/aether/internal/datasource/updates"
+	"github.com/soerenschneider/aether/internal/datasource/waste"
 	"github.com/soerenschneider/aether/internal/datasource/weather"
 	"github.com/soerenschneider/aether/internal/serve"
 	"github.com/soerenschneider/aether/internal/templates"
@@ -47,20 +69,45 @@ func (d *deps) HasEmailSupport() bool {
 	return d.email != nil
 }
 
-func wrapDatasource(ds Datasource, conf config.DatasourceConfig) (Datasource, error) {
-	if conf.IsCached() {
+// excludedFromSummary drops the summary of the wrapped datasource.
+type excludedFromSummary struct {
+	Datasource
+}
+
+func (e *excludedFromSummary) GetData(ctx context.Context) (*internal.Data, error) {
+	data, err := e.Datasource.GetData(ctx)
+	if data == nil {
+		return data, err
+	}
+
+	// copy the data, the wrapped datasource may hand out cached data
+	withoutSummary := *data
+	withoutSummary.Summary = nil
+	return &withoutSummary, err
+}
+
+func wrapDatasource(ds Datasource, conf config.DatasourceConfigContainer) (Datasource, error) {
+	if conf.Config.IsCached() {
 		var opts []cached.Opts
-		if conf.GetCacheExpiry() > 0 {
-			opts = append(opts, cached.WithRefreshInterval(conf.GetCacheExpiry()))
+		if conf.Config.GetCacheExpiry() > 0 {
+			opts = append(opts, cached.WithRefreshInterval(conf.Config.GetCacheExpiry()))
 		}
 
-		return cached.New(ds, opts...)
+		var err error
+		ds, err = cached.New(ds, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.ExcludeFromSummary {
+		ds = &excludedFromSummary{Datasource: ds}
 	}
 
 	return ds, nil
 }
 
-func buildDatasources(conf config.Config, _ *sync.WaitGroup) ([]Datasource, error) {
+func buildDatasources(ctx context.Context, conf config.Config, wg *sync.WaitGroup) ([]Datasource, error) {
 	var datasources []Datasource
 	var errs error
 
@@ -70,20 +117,54 @@ func buildDatasources(conf config.Config, _ *sync.WaitGroup) ([]Datasource, erro
 		var ds Datasource
 
 		switch dsConfig.Config.Type() {
+		case config.AirQuality:
+			ds, err = buildAirQuality(dsConfig.Config.(*config.AirQualityConfig))
 		case config.Alertmanager:
 			ds, err = buildAlertmanager(dsConfig.Config.(*config.AlertmanagerConfig))
 		case config.Astral:
 			ds, err = buildAstral(dsConfig.Config.(*config.AstralConfig))
+		case config.Backups:
+			ds, err = buildBackups(dsConfig.Config.(*config.BackupsConfig))
 		case config.CalDav:
 			ds, err = buildCalDav(dsConfig.Config.(*config.CalDavConfig))
 		case config.CardDav:
 			ds, err = buildCardDav(dsConfig.Config.(*config.CardDavConfig))
+		case config.Certificates:
+			ds, err = buildCertificates(dsConfig.Config.(*config.CertificatesConfig))
+		case config.Countdowns:
+			ds, err = buildCountdowns(dsConfig.Config.(*config.CountdownsConfig))
+		case config.Departures:
+			ds, err = buildDepartures(dsConfig.Config.(*config.DeparturesConfig))
+		case config.Feeds:
+			ds, err = buildFeeds(dsConfig.Config.(*config.FeedsConfig))
+		case config.Forge:
+			ds, err = buildForge(dsConfig.Config.(*config.ForgeConfig))
+		case config.Fx:
+			ds, err = buildFx(dsConfig.Config.(*config.FxConfig))
+		case config.Holidays:
+			ds, err = buildHolidays(dsConfig.Config.(*config.HolidaysConfig))
+		case config.HomeAssistant:
+			ds, err = buildHomeAssistant(dsConfig.Config.(*config.HomeAssistantConfig))
+		case config.Imap:
+			ds, err = buildImap(dsConfig.Config.(*config.ImapConfig))
 		case config.Logs:
 			ds, err = buildLogs(dsConfig.Config.(*config.LogsConfig))
-		//case config.Stocks:
-		//	ds, err = buildStocks(dsConfig.Config.(*config.StocksConfig))
+		case config.Mqtt:
+			ds, err = buildMqtt(ctx, dsConfig.Config.(*config.MqttConfig), wg)
+		case config.Notes:
+			ds, err = buildNotes(dsConfig.Config.(*config.NotesConfig))
+		case config.Probe:
+			ds, err = buildProbe(dsConfig.Config.(*config.ProbeConfig))
+		case config.Stocks:
+			ds, err = buildStocks(dsConfig.Config.(*config.StocksConfig))
+		case config.System:
+			ds, err = buildSystem(dsConfig.Config.(*config.SystemConfig))
 		case config.Taskwarrior:
 			ds, err = buildTaskwarrior(dsConfig.Config.(*config.TaskwarriorConfig))
+		case config.Updates:
+			ds, err = buildUpdates(dsConfig.Config.(*config.UpdatesConfig))
+		case config.Waste:
+			ds, err = buildWaste(dsConfig.Config.(*config.WasteConfig))
 		case config.Weather:
 			ds, err = buildWeather(dsConfig.Config.(*config.WeatherConfig))
 		default:
@@ -93,7 +174,7 @@ func buildDatasources(conf config.Config, _ *sync.WaitGroup) ([]Datasource, erro
 		if err != nil {
 			errs = multierr.Append(errs, err)
 		} else {
-			wrapped, err := wrapDatasource(ds, dsConfig.Config)
+			wrapped, err := wrapDatasource(ds, dsConfig)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			} else {
@@ -113,16 +194,46 @@ func buildAstral(conf *config.AstralConfig) (*astral.Astral, error) {
 		return nil, err
 	}
 
-	tz, err := time.LoadLocation("Europe/Berlin")
+	tz, err := time.LoadLocation(conf.Timezone)
 	if err != nil {
 		return nil, err
 	}
-	impl, err := astral.New(astral.Lat(conf.Latitude), astral.Lon(conf.Longitude), templateData, tz)
-	if err != nil {
-		return nil, err
+
+	opts := []astral.Opt{
+		astral.WithLocation(tz),
 	}
 
-	return impl, nil
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, astral.WithTemplateFile(conf.TemplateFile))
+	}
+
+	var observers []astral.Observer
+	if len(conf.Locations) > 0 {
+		for _, location := range conf.Locations {
+			observer := astral.Observer{
+				Name:      location.Name,
+				Latitude:  astral.Lat(location.Latitude),
+				Longitude: astral.Lon(location.Longitude),
+				Elevation: location.Elevation,
+			}
+			if len(location.Timezone) > 0 {
+				observer.Location, err = time.LoadLocation(location.Timezone)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timezone for location %q: %w", location.Name, err)
+				}
+			}
+			observers = append(observers, observer)
+		}
+	} else {
+		observers = append(observers, astral.Observer{
+			Name:      "Home",
+			Latitude:  astral.Lat(conf.Latitude),
+			Longitude: astral.Lon(conf.Longitude),
+			Elevation: conf.Elevation,
+		})
+	}
+
+	return astral.New(observers, templateData, opts...)
 }
 
 func buildWeather(conf *config.WeatherConfig) (*weather.WeatherDatasource, error) {
@@ -166,20 +277,200 @@ func buildWeather(conf *config.WeatherConfig) (*weather.WeatherDatasource, error
 	return weatherProvider, nil
 }
 
-func buildAlertmanager(conf *config.AlertmanagerConfig) (*alertmanager.AlertmanagerDatasource, error) {
-	var opts []alertmanager.Opt
-	if len(conf.BasePath) > 0 {
-		opts = append(opts, alertmanager.WithBasePath(conf.BasePath))
+func buildUpdates(conf *config.UpdatesConfig) (*updates.UpdatesDatasource, error) {
+	var opts []updates.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, updates.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.PackageManager) > 0 {
+		opts = append(opts, updates.WithPackageManager(conf.PackageManager))
+	}
+
+	if len(conf.ContainerRuntime) > 0 {
+		opts = append(opts, updates.WithContainers(conf.ContainerRuntime, conf.Images, httpClient))
+	}
+
+	if len(conf.InsecureRegistries) > 0 {
+		opts = append(opts, updates.WithInsecureRegistries(conf.InsecureRegistries))
+	}
+
+	if conf.Limit > 0 {
+		opts = append(opts, updates.WithLimit(conf.Limit))
+	}
+
+	if conf.Timeout > 0 {
+		opts = append(opts, updates.WithTimeout(conf.Timeout))
+	}
+
+	templateData := templates.TemplateData{}
+	var err error
+	templateData.DefaultTemplate, err = templates.GetTemplate("updates/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return updates.New(templateData, opts...)
+}
+
+func buildWaste(conf *config.WasteConfig) (*waste.WasteDatasource, error) {
+	var source waste.Source
+	var err error
+	if len(conf.Ics) > 0 {
+		source, err = waste.NewIcsSource(conf.Ics, httpClient)
+	} else {
+		source, err = waste.NewListSource(conf.File)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []waste.Opt{
+		waste.WithEveningHour(conf.EveningHour),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, waste.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.Bins) > 0 {
+		var bins []waste.Bin
+		for _, bin := range conf.Bins {
+			bins = append(bins, waste.Bin{
+				Name:     bin.Name,
+				Emoji:    bin.Emoji,
+				CssClass: bin.Color,
+				Match:    bin.Match,
+			})
+		}
+		opts = append(opts, waste.WithBins(bins))
+	}
+
+	if conf.Horizon > 0 {
+		opts = append(opts, waste.WithHorizon(conf.Horizon))
+	}
+
+	if conf.Count > 0 {
+		opts = append(opts, waste.WithCount(conf.Count))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("waste/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return waste.New(source, templateData, opts...)
+}
+
+func buildAirQuality(conf *config.AirQualityConfig) (*airquality.AirQualityDatasource, error) {
+	clientOpts := []airquality.OpenMeteoOpt{
+		airquality.WithHttpClient(httpClient),
+	}
+
+	if len(conf.Endpoint) > 0 {
+		clientOpts = append(clientOpts, airquality.WithEndpoint(conf.Endpoint))
 	}
 
-	if len(conf.Scheme) > 0 {
-		opts = append(opts, alertmanager.WithScheme(conf.Scheme))
+	if conf.ForecastDays > 0 {
+		clientOpts = append(clientOpts, airquality.WithForecastDays(conf.ForecastDays))
 	}
 
+	client, err := airquality.NewOpenMeteoClient(airquality.Lat(conf.Latitude), airquality.Lon(conf.Longitude), conf.NiceName, clientOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []airquality.Opt{
+		airquality.WithThresholds(airquality.Thresholds{
+			Aqi:     conf.Thresholds.Aqi,
+			Pm25:    conf.Thresholds.Pm25,
+			Pm10:    conf.Thresholds.Pm10,
+			Ozone:   conf.Thresholds.Ozone,
+			UvIndex: conf.Thresholds.UvIndex,
+			Pollen:  conf.Thresholds.Pollen,
+		}),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, airquality.WithTemplateFile(conf.TemplateFile))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("airquality/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return airquality.New(client, templateData, opts...)
+}
+
+func buildAlertmanager(conf *config.AlertmanagerConfig) (*alertmanager.AlertmanagerDatasource, error) {
+	var opts []alertmanager.Opt
 	if len(conf.TemplateFile) > 0 {
 		opts = append(opts, alertmanager.WithTemplateFile(conf.TemplateFile))
 	}
 
+	if len(conf.Labels) > 0 {
+		opts = append(opts, alertmanager.WithLabels(conf.Labels))
+	}
+
+	if len(conf.SilenceMatchers) > 0 {
+		opts = append(opts, alertmanager.WithSilenceMatchers(conf.SilenceMatchers))
+	}
+
+	if len(conf.Filters) > 0 {
+		opts = append(opts, alertmanager.WithFilters(conf.Filters))
+	}
+
+	if len(conf.Receiver) > 0 {
+		opts = append(opts, alertmanager.WithReceiver(conf.Receiver))
+	}
+
+	if conf.Limit > 0 {
+		opts = append(opts, alertmanager.WithLimit(conf.Limit))
+	}
+
+	var endpoints []alertmanager.Endpoint
+	if len(conf.Host) > 0 {
+		endpoints = append(endpoints, alertmanager.Endpoint{
+			Host:     conf.Host,
+			BasePath: conf.BasePath,
+			Scheme:   conf.Scheme,
+		})
+	}
+
+	for _, endpointConf := range conf.Endpoints {
+		endpoint := alertmanager.Endpoint{
+			Name:        endpointConf.Name,
+			Host:        endpointConf.Host,
+			BasePath:    endpointConf.BasePath,
+			Scheme:      endpointConf.Scheme,
+			Username:    endpointConf.Username,
+			Password:    endpointConf.Password,
+			BearerToken: endpointConf.BearerToken,
+			TlsCaFile:   endpointConf.TlsCaFile,
+		}
+
+		if endpointConf.PasswordFile != "" {
+			password, err := os.ReadFile(endpointConf.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read password from file %q: %w", endpointConf.PasswordFile, err)
+			}
+			endpoint.Password = strings.TrimSpace(string(password))
+		}
+
+		if endpointConf.BearerTokenFile != "" {
+			token, err := os.ReadFile(endpointConf.BearerTokenFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read bearer token from file %q: %w", endpointConf.BearerTokenFile, err)
+			}
+			endpoint.BearerToken = strings.TrimSpace(string(token))
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
 	var err error
 	templateData := templates.TemplateData{}
 	templateData.DefaultTemplate, err = templates.GetTemplate("alertmanager/default.html")
@@ -194,7 +485,251 @@ func buildAlertmanager(conf *config.AlertmanagerConfig) (*alertmanager.Alertmana
 		}
 	}
 
-	return alertmanager.New(conf.Host, templateData, opts...)
+	return alertmanager.New(endpoints, templateData, opts...)
+}
+
+func buildMqtt(ctx context.Context, conf *config.MqttConfig, wg *sync.WaitGroup) (*mqtt.MqttDatasource, error) {
+	var opts []mqtt.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, mqtt.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.NiceName) > 0 {
+		opts = append(opts, mqtt.WithName(conf.NiceName))
+	}
+
+	if len(conf.ClientId) > 0 {
+		opts = append(opts, mqtt.WithClientId(conf.ClientId))
+	}
+
+	if len(conf.Username) > 0 {
+		password := conf.Password
+		if len(conf.PasswordFile) > 0 {
+			content, err := os.ReadFile(conf.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read password from file %q: %w", conf.PasswordFile, err)
+			}
+			password = strings.TrimSpace(string(content))
+		}
+		opts = append(opts, mqtt.WithCredentials(conf.Username, password))
+	}
+
+	if len(conf.TlsCaFile) > 0 {
+		opts = append(opts, mqtt.WithTlsCaFile(conf.TlsCaFile))
+	}
+
+	if conf.StaleAfter > 0 {
+		opts = append(opts, mqtt.WithStaleAfter(conf.StaleAfter))
+	}
+
+	var topics []mqtt.Topic
+	for _, topic := range conf.Topics {
+		topics = append(topics, mqtt.Topic{
+			Name:       topic.Name,
+			Topic:      topic.Topic,
+			Path:       topic.Path,
+			Unit:       topic.Unit,
+			StaleAfter: topic.StaleAfter,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("mqtt/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := mqtt.New(conf.Broker, topics, templateData, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	ds.Start(ctx, wg)
+	return ds, nil
+}
+
+func buildNotes(conf *config.NotesConfig) (*notes.NotesDatasource, error) {
+	var opts []notes.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, notes.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.NiceName) > 0 {
+		opts = append(opts, notes.WithName(conf.NiceName))
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("notes/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return notes.New(conf.Files, templateData, opts...)
+}
+
+func buildProbe(conf *config.ProbeConfig) (*probe.ProbeDatasource, error) {
+	var opts []probe.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, probe.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Timeout > 0 {
+		opts = append(opts, probe.WithTimeout(conf.Timeout))
+	}
+
+	if conf.CertWarningDays > 0 {
+		opts = append(opts, probe.WithCertWarningDays(conf.CertWarningDays))
+	}
+
+	if conf.SlowThreshold > 0 {
+		opts = append(opts, probe.WithSlowThreshold(conf.SlowThreshold))
+	}
+
+	var targets []probe.Target
+	for _, target := range conf.Targets {
+		targets = append(targets, probe.Target{
+			Name:           target.Name,
+			Type:           target.Type,
+			Address:        target.Address,
+			ExpectedStatus: target.ExpectedStatus,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("probe/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return probe.New(targets, templateData, opts...)
+}
+
+func buildSystem(conf *config.SystemConfig) (*system.SystemDatasource, error) {
+	var opts []system.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, system.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.ProcDir) > 0 {
+		opts = append(opts, system.WithProcDir(conf.ProcDir))
+	}
+
+	if len(conf.Mounts) > 0 {
+		opts = append(opts, system.WithMounts(conf.Mounts))
+	}
+
+	if len(conf.RebootMarkers) > 0 {
+		opts = append(opts, system.WithRebootMarkers(conf.RebootMarkers))
+	}
+
+	if conf.SystemdUnits {
+		opts = append(opts, system.WithUnitsProvider(system.NewSystemctlProvider(conf.SystemdUser)))
+	}
+
+	opts = append(opts, system.WithThresholds(system.Thresholds{
+		Disk:   conf.DiskThreshold,
+		Memory: conf.MemoryThreshold,
+		Load:   conf.LoadThreshold,
+	}))
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("system/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return system.New(templateData, opts...)
+}
+
+func buildImap(conf *config.ImapConfig) (*imap.ImapDatasource, error) {
+	opts := []imap.Opt{imap.WithLatest(conf.Latest)}
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, imap.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Timeout > 0 {
+		opts = append(opts, imap.WithTimeout(conf.Timeout))
+	}
+
+	if len(conf.TlsCaFile) > 0 {
+		opts = append(opts, imap.WithTlsCaFile(conf.TlsCaFile))
+	}
+
+	var accounts []imap.Account
+	for _, account := range conf.Accounts {
+		password := account.Password
+		if len(account.PasswordFile) > 0 {
+			content, err := os.ReadFile(account.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read password from file %q: %w", account.PasswordFile, err)
+			}
+			password = strings.TrimSpace(string(content))
+		}
+
+		accounts = append(accounts, imap.Account{
+			Name:     account.Name,
+			Address:  account.Host,
+			Username: account.Username,
+			Password: password,
+			Security: account.Security,
+			Folders:  account.Folders,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("imap/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return imap.New(accounts, templateData, opts...)
+}
+
+func buildStocks(conf *config.StocksConfig) (*stocks.StocksDatasource, error) {
+	provider, err := stocks.NewYahooProvider(conf.Endpoint, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []stocks.Opt{
+		stocks.WithMoverThreshold(conf.MoverThreshold),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, stocks.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.Holdings) > 0 {
+		var positions []stocks.Position
+		for _, holding := range conf.Holdings {
+			positions = append(positions, stocks.Position{
+				Symbol: holding.Symbol,
+				Shares: holding.Shares,
+			})
+		}
+		opts = append(opts, stocks.WithHoldings(positions))
+	}
+
+	if len(conf.Currency) > 0 {
+		opts = append(opts, stocks.WithCurrency(conf.Currency))
+	}
+
+	if len(conf.Ranges) > 0 {
+		opts = append(opts, stocks.WithRanges(conf.Ranges))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("stocks/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return stocks.New(provider, conf.Symbols, templateData, opts...)
 }
 
 func buildTaskwarrior(conf *config.TaskwarriorConfig) (*taskwarrior.Datasource, error) {
@@ -213,6 +748,14 @@ func buildTaskwarrior(conf *config.TaskwarriorConfig) (*taskwarrior.Datasource,
 
 	}
 
+	if len(conf.HolidaysCountry) > 0 {
+		cal, err := calendar.New(conf.HolidaysCountry, conf.HolidaysRegion)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, taskwarrior.WithCalendar(cal))
+	}
+
 	client, err := taskwarrior.NewTaskwarriorClient(conf.TaskRcFile)
 	if err != nil {
 		return nil, err
@@ -296,25 +839,487 @@ func buildCalDav(conf *config.CalDavConfig) (*caldav.CaldavDatasource, error) {
 	return caldav.New(client, templateData, caldavOpts...)
 }
 
-func buildLogs(conf *config.LogsConfig) (*logs.VictorialogsClient, error) {
-	opts := []logs.Opt{
+func buildBackups(conf *config.BackupsConfig) (*backups.BackupsDatasource, error) {
+	var opts []backups.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, backups.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Timeout > 0 {
+		opts = append(opts, backups.WithTimeout(conf.Timeout))
+	}
+
+	var jobs []backups.Job
+	for _, job := range conf.Jobs {
+		jobs = append(jobs, backups.Job{
+			Name:            job.Name,
+			Type:            job.Type,
+			Location:        job.Location,
+			PasswordFile:    job.PasswordFile,
+			MaxAge:          job.MaxAge,
+			TimestampMetric: job.TimestampMetric,
+			SizeMetric:      job.SizeMetric,
+			Labels:          job.Labels,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("backups/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return backups.New(jobs, templateData, opts...)
+}
+
+func buildCertificates(conf *config.CertificatesConfig) (*certificates.CertificatesDatasource, error) {
+	var opts []certificates.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, certificates.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Timeout > 0 {
+		opts = append(opts, certificates.WithTimeout(conf.Timeout))
+	}
+
+	if conf.ThresholdDays > 0 {
+		opts = append(opts, certificates.WithThresholdDays(conf.ThresholdDays))
+	}
+
+	var sources []certificates.Source
+	for _, source := range conf.Sources {
+		sources = append(sources, certificates.Source{
+			Name:       source.Name,
+			Type:       source.Type,
+			Location:   source.Location,
+			ServerName: source.ServerName,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("certificates/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates.New(sources, templateData, opts...)
+}
+
+func buildCountdowns(conf *config.CountdownsConfig) (*countdowns.CountdownsDatasource, error) {
+	opts := []countdowns.Opt{countdowns.WithDefaultLeadDays(conf.LeadDays)}
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, countdowns.WithTemplateFile(conf.TemplateFile))
+	}
+
+	var items []countdowns.Item
+	for _, item := range conf.Items {
+		date, err := time.ParseInLocation("2006-01-02", item.Date, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date for countdown %q: %w", item.Name, err)
+		}
+
+		everyMonths, err := countdowns.ParseRecurrence(item.Recurrence)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recurrence for countdown %q: %w", item.Name, err)
+		}
+
+		items = append(items, countdowns.Item{
+			Name:        item.Name,
+			Date:        date,
+			EveryMonths: everyMonths,
+			LeadDays:    item.LeadDays,
+			Category:    item.Category,
+			Emoji:       item.Emoji,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("countdowns/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return countdowns.New(items, templateData, opts...)
+}
+
+func buildDepartures(conf *config.DeparturesConfig) (*departures.DeparturesDatasource, error) {
+	var provider departures.Provider
+	var err error
+	switch conf.Provider {
+	case config.DeparturesProviderEfa:
+		provider, err = departures.NewEfaProvider(conf.Endpoint, httpClient)
+	default:
+		provider, err = departures.NewHafasProvider(conf.Endpoint, httpClient)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []departures.Opt{
+		departures.WithLeaveWindow(conf.LeaveWindow),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, departures.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Duration > 0 {
+		opts = append(opts, departures.WithDuration(conf.Duration))
+	}
+
+	if conf.Limit > 0 {
+		opts = append(opts, departures.WithLimit(conf.Limit))
+	}
+
+	var stops []departures.Stop
+	for _, stop := range conf.Stops {
+		stops = append(stops, departures.Stop{
+			Id:          stop.Id,
+			Name:        stop.Name,
+			Lines:       stop.Lines,
+			WalkingTime: stop.WalkingTime,
+		})
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("departures/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return departures.New(provider, stops, templateData, opts...)
+}
+
+func buildFeeds(conf *config.FeedsConfig) (*feeds.FeedsDatasource, error) {
+	opts := []feeds.Opt{
+		feeds.WithHttpClient(httpClient),
+		feeds.WithSummaryItems(conf.SummaryItems),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, feeds.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.Window > 0 {
+		opts = append(opts, feeds.WithWindow(conf.Window))
+	}
+
+	if conf.NewFor > 0 {
+		opts = append(opts, feeds.WithNewFor(conf.NewFor))
+	}
+
+	if conf.Limit > 0 {
+		opts = append(opts, feeds.WithLimit(conf.Limit))
+	}
+
+	if len(conf.StateFile) > 0 {
+		opts = append(opts, feeds.WithStateFile(conf.StateFile))
+	}
+
+	var feedList []feeds.Feed
+	for _, feed := range conf.Feeds {
+		feedList = append(feedList, feeds.Feed{
+			Name: feed.Name,
+			Url:  feed.Url,
+		})
+	}
+
+	var err error
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("feeds/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return feeds.New(feedList, templateData, opts...)
+}
+
+func buildFx(conf *config.FxConfig) (*fx.FxDatasource, error) {
+	var provider fx.RatesProvider
+	var err error
+	switch conf.Provider {
+	case config.FxProviderJson:
+		provider, err = fx.NewJsonProvider(conf.Endpoint, httpClient)
+	default:
+		provider, err = fx.NewEcbProvider(conf.Endpoint, httpClient)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []fx.Opt{
+		fx.WithBase(conf.Base),
+		fx.WithThresholds(conf.Threshold, conf.CryptoThreshold),
+	}
+
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, fx.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.StateFile) > 0 {
+		opts = append(opts, fx.WithStateFile(conf.StateFile))
+	}
+
+	if len(conf.Crypto) > 0 {
+		cryptoProvider, err := fx.NewCoingeckoProvider(conf.CryptoEndpoint, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, fx.WithCrypto(cryptoProvider, conf.Crypto))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("fx/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return fx.New(provider, conf.Currencies, templateData, opts...)
+}
+
+func buildForge(conf *config.ForgeConfig) (*forge.ForgeDatasource, error) {
+	token := conf.Token
+	if len(conf.TokenFile) > 0 {
+		content, err := os.ReadFile(conf.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read token from file %q: %w", conf.TokenFile, err)
+		}
+		token = strings.TrimSpace(string(content))
+	}
+
+	var provider forge.Provider
+	var err error
+	switch conf.Flavor {
+	case "github":
+		endpoint := conf.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = forge.DefaultGithubEndpoint
+		}
+		provider, err = forge.NewGithubProvider(endpoint, token, httpClient)
+	case "gitea", "forgejo":
+		provider, err = forge.NewGiteaProvider(conf.Endpoint, token, httpClient)
+	default:
+		return nil, fmt.Errorf("unknown forge flavor %q", conf.Flavor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []forge.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, forge.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.NiceName) > 0 {
+		opts = append(opts, forge.WithName(conf.NiceName))
+	}
+
+	if len(conf.Repos) > 0 {
+		opts = append(opts, forge.WithRepos(conf.Repos))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("forge/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return forge.New(provider, templateData, opts...)
+}
+
+func buildHolidays(conf *config.HolidaysConfig) (*holidays.HolidaysDatasource, error) {
+	cal, err := calendar.New(conf.Country, conf.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []holidays.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, holidays.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if conf.HorizonDays > 0 {
+		opts = append(opts, holidays.WithHorizonDays(conf.HorizonDays))
+	}
+
+	if len(conf.VacationsIcs) > 0 {
+		source, err := holidays.NewIcsVacations(conf.VacationsIcs, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, holidays.WithVacationSource(source))
+	}
+
+	if len(conf.Vacations) > 0 {
+		var vacations []holidays.Vacation
+		for _, vacation := range conf.Vacations {
+			start, err := time.ParseInLocation("2006-01-02", vacation.Start, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid start for vacation %q: %w", vacation.Name, err)
+			}
+			end, err := time.ParseInLocation("2006-01-02", vacation.End, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end for vacation %q: %w", vacation.Name, err)
+			}
+			vacations = append(vacations, holidays.Vacation{
+				Name:  vacation.Name,
+				Start: start,
+				End:   end,
+			})
+		}
+		opts = append(opts, holidays.WithVacations(vacations))
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("holidays/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return holidays.New(cal, templateData, opts...)
+}
+
+func buildHomeAssistant(conf *config.HomeAssistantConfig) (*homeassistant.HomeAssistantDatasource, error) {
+	token := conf.Token
+	if len(conf.TokenFile) > 0 {
+		content, err := os.ReadFile(conf.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read token from file %q: %w", conf.TokenFile, err)
+		}
+		token = strings.TrimSpace(string(content))
+	}
+
+	client, err := homeassistant.NewClient(conf.Endpoint, token, homeassistant.WithHttpClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []homeassistant.Opt
+	if len(conf.TemplateFile) > 0 {
+		opts = append(opts, homeassistant.WithTemplateFile(conf.TemplateFile))
+	}
+
+	if len(conf.Conditions) > 0 {
+		var conditions []homeassistant.Condition
+		for _, condition := range conf.Conditions {
+			conditions = append(conditions, homeassistant.Condition{
+				Expression: condition.Expression,
+				Message:    condition.Message,
+			})
+		}
+		opts = append(opts, homeassistant.WithConditions(conditions))
+	}
+
+	var entities []homeassistant.Entity
+	for _, entity := range conf.Entities {
+		entities = append(entities, homeassistant.Entity{
+			Id:       entity.Id,
+			Name:     entity.Name,
+			Warning:  entity.Warning,
+			Critical: entity.Critical,
+			Below:    entity.Below,
+		})
+	}
+
+	templateData := templates.TemplateData{}
+	templateData.DefaultTemplate, err = templates.GetTemplate("homeassistant/default.html")
+	if err != nil {
+		return nil, err
+	}
+
+	return homeassistant.New(client, entities, templateData, opts...)
+}
+
+func buildLogsBackend(conf *config.LogsConfig) (logs.LogsBackend, error) {
+	opts := []logs.BackendOpt{
 		logs.WithHttpClient(httpClient),
 	}
 
+	if len(conf.Username) > 0 {
+		password := conf.Password
+		if len(conf.PasswordFile) > 0 {
+			content, err := os.ReadFile(conf.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("could not read password from file %q: %w", conf.PasswordFile, err)
+			}
+			password = strings.TrimSpace(string(content))
+		}
+		opts = append(opts, logs.WithBasicAuth(conf.Username, password))
+	}
+
+	switch conf.Backend {
+	case config.LogsBackendLoki:
+		return logs.NewLokiClient(conf.Endpoint, conf.TenantId, opts...)
+	case config.LogsBackendOpensearch, config.LogsBackendElasticsearch:
+		return logs.NewOpensearchClient(conf.Endpoint, conf.Index, conf.TimestampField, conf.MessageField, opts...)
+	default:
+		return logs.NewVictorialogsClient(conf.Endpoint, opts...)
+	}
+}
+
+func buildLogs(conf *config.LogsConfig) (*logs.LogsDatasource, error) {
+	backend, err := buildLogsBackend(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []logs.Opt
 	if len(conf.TemplateFile) > 0 {
 		opts = append(opts, logs.WithTemplateFile(conf.TemplateFile))
 	}
 
-	if conf.Query != "" {
-		opts = append(opts, logs.WithQuery(conf.Query))
+	var queries []logs.NamedQuery
+	for _, query := range conf.Queries {
+		queries = append(queries, logs.NamedQuery{
+			Name:        query.Name,
+			Query:       query.Query,
+			Limit:       query.Limit,
+			Lookback:    query.Lookback,
+			Fields:      query.Fields,
+			SourceField: query.SourceField,
+			Group:       query.Group,
+		})
+	}
+
+	if len(queries) == 0 {
+		// the default query is only valid for VictoriaLogs
+		if conf.Query == "" && conf.Backend != config.LogsBackendVictorialogs {
+			return nil, fmt.Errorf("no query configured for logs backend %q", conf.Backend)
+		}
+
+		query := logs.NamedQuery{
+			Name:     "errors",
+			Query:    conf.Query,
+			Limit:    conf.Limit,
+			Lookback: conf.Lookback,
+		}
+		if query.Query == "" {
+			query.Query = logs.DefaultQuery
+		}
+		queries = append(queries, query)
 	}
 
-	if conf.Limit != 0 {
-		opts = append(opts, logs.WithLimit(conf.Limit))
+	opts = append(opts, logs.WithQueries(queries))
+
+	if len(conf.Stats) > 0 {
+		var statsQueries []logs.StatsQuery
+		for _, query := range conf.Stats {
+			statsQueries = append(statsQueries, logs.StatsQuery{
+				Name:     query.Name,
+				Query:    query.Query,
+				GroupBy:  query.GroupBy,
+				Window:   query.Window,
+				Step:     query.Step,
+				Baseline: query.Baseline,
+			})
+		}
+		opts = append(opts, logs.WithStatsQueries(statsQueries))
 	}
 
 	templateData := templates.TemplateData{}
-	var err error
 	templateData.DefaultTemplate, err = templates.GetTemplate("logs/default.html")
 	if err != nil {
 		return nil, err
@@ -323,7 +1328,7 @@ func buildLogs(conf *config.LogsConfig) (*logs.VictorialogsClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return logs.New(conf.Endpoint, templateData, opts...)
+	return logs.New(backend, templateData, opts...)
 }
 
 func init() {
@@ -69,9 +69,11 @@ func main() {
 	conf, err := getConfig()
 	dieOnError(err, "no config")
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	deps := deps{}
 	wg := &sync.WaitGroup{}
-	deps.datasources, err = buildDatasources(*conf, wg)
+	deps.datasources, err = buildDatasources(ctx, *conf, wg)
 	dieOnError(err, "could not build datasources")
 
 	if conf.Email != nil {
@@ -79,8 +81,6 @@ func main() {
 		dieOnError(err, "could not build email")
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-
 	aetherTemplateData, err := templates.GetTemplate("main/main.html")
 	dieOnError(err, "could not build template")
 
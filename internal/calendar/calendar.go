@@ -0,0 +1,117 @@
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Holiday struct {
+	Name string
+	// Date is the day of the holiday at midnight UTC.
+	Date time.Time
+}
+
+// Calendar knows about the public holidays of a country and optionally one of its regions. All functions only
+// consider the civil date of the times passed in, regardless of their location.
+type Calendar struct {
+	country string
+	region  string
+	rules   []rule
+}
+
+func New(countryCode, region string) (*Calendar, error) {
+	countryCode = strings.ToUpper(countryCode)
+	c, found := countries[countryCode]
+	if !found {
+		return nil, fmt.Errorf("unsupported country %q, supported are %s", countryCode, strings.Join(Countries(), ", "))
+	}
+
+	// accept ISO 3166-2 codes such as DE-BY as well
+	region = strings.TrimPrefix(strings.ToUpper(region), countryCode+"-")
+	if region != "" && !slices.Contains(c.regions, region) {
+		return nil, fmt.Errorf("unsupported region %q for country %q", region, countryCode)
+	}
+
+	return &Calendar{
+		country: countryCode,
+		region:  region,
+		rules:   c.rules,
+	}, nil
+}
+
+// Countries returns the codes of all supported countries.
+func Countries() []string {
+	ret := make([]string, 0, len(countries))
+	for code := range countries {
+		ret = append(ret, code)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// Holidays returns the holidays of the given year, ordered by date.
+func (c *Calendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for _, r := range c.rules {
+		if r.appliesTo(year, c.region) {
+			holidays = append(holidays, Holiday{Name: r.name, Date: r.date(year)})
+		}
+	}
+
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+	return holidays
+}
+
+// Between returns the holidays from and including start until and including end.
+func (c *Calendar) Between(start, end time.Time) []Holiday {
+	from := civilDate(start)
+	to := civilDate(end)
+
+	// observed holidays may move to the previous year, e.g. New Year's Day on a Saturday
+	var holidays []Holiday
+	for year := from.Year(); year <= to.Year()+1; year++ {
+		for _, holiday := range c.Holidays(year) {
+			if !holiday.Date.Before(from) && !holiday.Date.After(to) {
+				holidays = append(holidays, holiday)
+			}
+		}
+	}
+	return holidays
+}
+
+// IsHoliday returns the holiday at the given day, if any.
+func (c *Calendar) IsHoliday(day time.Time) (Holiday, bool) {
+	holidays := c.Between(day, day)
+	if len(holidays) == 0 {
+		return Holiday{}, false
+	}
+	return holidays[0], true
+}
+
+// IsWorkingDay returns whether the day is neither on a weekend nor a public holiday.
+func (c *Calendar) IsWorkingDay(day time.Time) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		return false
+	}
+	_, isHoliday := c.IsHoliday(day)
+	return !isHoliday
+}
+
+// AddWorkingDays returns the day that is the given number of working days after day.
+func (c *Calendar) AddWorkingDays(day time.Time, days int) time.Time {
+	for days > 0 {
+		day = day.AddDate(0, 0, 1)
+		if c.IsWorkingDay(day) {
+			days--
+		}
+	}
+	return day
+}
+
+func civilDate(t time.Time) time.Time {
+	return date(t.Year(), t.Month(), t.Day())
+}
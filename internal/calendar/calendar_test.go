@@ -0,0 +1,133 @@
+package calendar
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want time.Time
+	}{
+		{year: 2000, want: date(2000, time.April, 23)},
+		{year: 2019, want: date(2019, time.April, 21)},
+		{year: 2024, want: date(2024, time.March, 31)},
+		{year: 2025, want: date(2025, time.April, 20)},
+		{year: 2038, want: date(2038, time.April, 25)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.String(), func(t *testing.T) {
+			if got := easter(tt.year); !got.Equal(tt.want) {
+				t.Errorf("easter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func names(holidays []Holiday) []string {
+	var ret []string
+	for _, holiday := range holidays {
+		ret = append(ret, holiday.Date.Format("01-02")+" "+holiday.Name)
+	}
+	return ret
+}
+
+func TestHolidays(t *testing.T) {
+	tests := []struct {
+		country string
+		region  string
+		year    int
+		want    []string
+	}{
+		{
+			country: "DE",
+			region:  "BY",
+			year:    2025,
+			want: []string{
+				"01-01 Neujahr", "01-06 Heilige Drei Könige", "04-18 Karfreitag", "04-21 Ostermontag",
+				"05-01 Tag der Arbeit", "05-29 Christi Himmelfahrt", "06-09 Pfingstmontag", "06-19 Fronleichnam",
+				"10-03 Tag der Deutschen Einheit", "11-01 Allerheiligen", "12-25 1. Weihnachtstag", "12-26 2. Weihnachtstag",
+			},
+		},
+		{
+			country: "de",
+			region:  "de-sn",
+			year:    2025,
+			want: []string{
+				"01-01 Neujahr", "04-18 Karfreitag", "04-21 Ostermontag", "05-01 Tag der Arbeit",
+				"05-29 Christi Himmelfahrt", "06-09 Pfingstmontag", "10-03 Tag der Deutschen Einheit",
+				"10-31 Reformationstag", "11-19 Buß- und Bettag", "12-25 1. Weihnachtstag", "12-26 2. Weihnachtstag",
+			},
+		},
+		{
+			country: "DE",
+			year:    2017,
+			want: []string{
+				"01-01 Neujahr", "04-14 Karfreitag", "04-17 Ostermontag", "05-01 Tag der Arbeit",
+				"05-25 Christi Himmelfahrt", "06-05 Pfingstmontag", "10-03 Tag der Deutschen Einheit",
+				"12-25 1. Weihnachtstag", "12-26 2. Weihnachtstag",
+			},
+		},
+		{
+			country: "US",
+			year:    2021,
+			want: []string{
+				"01-01 New Year's Day", "01-18 Martin Luther King Jr. Day", "02-15 Washington's Birthday",
+				"05-31 Memorial Day", "06-18 Juneteenth", "07-05 Independence Day", "09-06 Labor Day",
+				"10-11 Columbus Day", "11-11 Veterans Day", "11-25 Thanksgiving Day", "12-24 Christmas Day",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.country+tt.region, func(t *testing.T) {
+			cal, err := New(tt.country, tt.region)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := names(cal.Holidays(tt.year)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Holidays() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("XX", ""); err == nil {
+		t.Error("expected error for unknown country")
+	}
+	if _, err := New("DE", "XX"); err == nil {
+		t.Error("expected error for unknown region")
+	}
+	if _, err := New("US", "CA"); err == nil {
+		t.Error("expected error for country without regions")
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	cal, _ := New("DE", "NW")
+	local := time.FixedZone("CET", 3600)
+
+	// Thursday before Easter, Good Friday and Easter Monday 2025 are holidays
+	thursday := time.Date(2025, time.April, 17, 18, 0, 0, 0, local)
+	if !cal.IsWorkingDay(thursday) {
+		t.Error("expected Thursday to be a working day")
+	}
+	if cal.IsWorkingDay(thursday.AddDate(0, 0, 1)) {
+		t.Error("expected Good Friday not to be a working day")
+	}
+	if holiday, found := cal.IsHoliday(thursday.AddDate(0, 0, 4)); !found || holiday.Name != "Ostermontag" {
+		t.Errorf("expected Easter Monday, got %v", holiday)
+	}
+
+	if got, want := cal.AddWorkingDays(thursday, 2), time.Date(2025, time.April, 23, 18, 0, 0, 0, local); !got.Equal(want) {
+		t.Errorf("AddWorkingDays() = %v, want %v", got, want)
+	}
+
+	// New Year's Day 2022 is observed on Friday, 31st of December 2021
+	us, _ := New("US", "")
+	if holiday, found := us.IsHoliday(date(2021, time.December, 31)); !found || holiday.Name != "New Year's Day" {
+		t.Errorf("expected observed New Year's Day, got %v", holiday)
+	}
+}
@@ -0,0 +1,186 @@
+package calendar
+
+import (
+	"time"
+)
+
+// rule describes a single public holiday.
+type rule struct {
+	name string
+	date func(year int) time.Time
+	// regions the holiday is observed in, all regions if empty
+	regions []string
+	// from and until restrict the years the holiday exists, 0 means unbounded
+	from  int
+	until int
+}
+
+func (r rule) appliesTo(year int, region string) bool {
+	if r.from > 0 && year < r.from {
+		return false
+	}
+	if r.until > 0 && year > r.until {
+		return false
+	}
+	if len(r.regions) == 0 {
+		return true
+	}
+	for _, candidate := range r.regions {
+		if candidate == region {
+			return true
+		}
+	}
+	return false
+}
+
+type country struct {
+	regions []string
+	rules   []rule
+}
+
+var countries = map[string]country{
+	"AT": {
+		rules: []rule{
+			{name: "Neujahr", date: fixed(time.January, 1)},
+			{name: "Heilige Drei Könige", date: fixed(time.January, 6)},
+			{name: "Ostermontag", date: easterOffset(1)},
+			{name: "Staatsfeiertag", date: fixed(time.May, 1)},
+			{name: "Christi Himmelfahrt", date: easterOffset(39)},
+			{name: "Pfingstmontag", date: easterOffset(50)},
+			{name: "Fronleichnam", date: easterOffset(60)},
+			{name: "Mariä Himmelfahrt", date: fixed(time.August, 15)},
+			{name: "Nationalfeiertag", date: fixed(time.October, 26)},
+			{name: "Allerheiligen", date: fixed(time.November, 1)},
+			{name: "Mariä Empfängnis", date: fixed(time.December, 8)},
+			{name: "Christtag", date: fixed(time.December, 25)},
+			{name: "Stefanitag", date: fixed(time.December, 26)},
+		},
+	},
+	"DE": {
+		regions: []string{"BW", "BY", "BE", "BB", "HB", "HH", "HE", "MV", "NI", "NW", "RP", "SL", "SN", "ST", "SH", "TH"},
+		rules: []rule{
+			{name: "Neujahr", date: fixed(time.January, 1)},
+			{name: "Heilige Drei Könige", date: fixed(time.January, 6), regions: []string{"BW", "BY", "ST"}},
+			{name: "Internationaler Frauentag", date: fixed(time.March, 8), regions: []string{"BE"}, from: 2019},
+			{name: "Internationaler Frauentag", date: fixed(time.March, 8), regions: []string{"MV"}, from: 2023},
+			{name: "Karfreitag", date: easterOffset(-2)},
+			{name: "Ostersonntag", date: easterOffset(0), regions: []string{"BB"}},
+			{name: "Ostermontag", date: easterOffset(1)},
+			{name: "Tag der Arbeit", date: fixed(time.May, 1)},
+			{name: "Christi Himmelfahrt", date: easterOffset(39)},
+			{name: "Pfingstsonntag", date: easterOffset(49), regions: []string{"BB"}},
+			{name: "Pfingstmontag", date: easterOffset(50)},
+			{name: "Fronleichnam", date: easterOffset(60), regions: []string{"BW", "BY", "HE", "NW", "RP", "SL"}},
+			{name: "Mariä Himmelfahrt", date: fixed(time.August, 15), regions: []string{"SL"}},
+			{name: "Weltkindertag", date: fixed(time.September, 20), regions: []string{"TH"}, from: 2019},
+			{name: "Tag der Deutschen Einheit", date: fixed(time.October, 3)},
+			{name: "Reformationstag", date: fixed(time.October, 31), regions: []string{"BB", "MV", "SN", "ST", "TH"}},
+			{name: "Reformationstag", date: fixed(time.October, 31), regions: []string{"HB", "HH", "NI", "SH"}, from: 2018},
+			{name: "Reformationstag", date: fixed(time.October, 31), regions: []string{"BW", "BY", "BE", "HB", "HH", "HE", "NI", "NW", "RP", "SL", "SH"}, from: 2017, until: 2017},
+			{name: "Allerheiligen", date: fixed(time.November, 1), regions: []string{"BW", "BY", "NW", "RP", "SL"}},
+			{name: "Buß- und Bettag", date: dayOfPrayerAndRepentance, regions: []string{"SN"}},
+			{name: "1. Weihnachtstag", date: fixed(time.December, 25)},
+			{name: "2. Weihnachtstag", date: fixed(time.December, 26)},
+		},
+	},
+	"FR": {
+		rules: []rule{
+			{name: "Jour de l'an", date: fixed(time.January, 1)},
+			{name: "Lundi de Pâques", date: easterOffset(1)},
+			{name: "Fête du Travail", date: fixed(time.May, 1)},
+			{name: "Victoire 1945", date: fixed(time.May, 8)},
+			{name: "Ascension", date: easterOffset(39)},
+			{name: "Lundi de Pentecôte", date: easterOffset(50)},
+			{name: "Fête nationale", date: fixed(time.July, 14)},
+			{name: "Assomption", date: fixed(time.August, 15)},
+			{name: "Toussaint", date: fixed(time.November, 1)},
+			{name: "Armistice 1918", date: fixed(time.November, 11)},
+			{name: "Noël", date: fixed(time.December, 25)},
+		},
+	},
+	"US": {
+		rules: []rule{
+			{name: "New Year's Day", date: observed(fixed(time.January, 1))},
+			{name: "Martin Luther King Jr. Day", date: nthWeekday(time.January, time.Monday, 3)},
+			{name: "Washington's Birthday", date: nthWeekday(time.February, time.Monday, 3)},
+			{name: "Memorial Day", date: nthWeekday(time.May, time.Monday, -1)},
+			{name: "Juneteenth", date: observed(fixed(time.June, 19)), from: 2021},
+			{name: "Independence Day", date: observed(fixed(time.July, 4))},
+			{name: "Labor Day", date: nthWeekday(time.September, time.Monday, 1)},
+			{name: "Columbus Day", date: nthWeekday(time.October, time.Monday, 2)},
+			{name: "Veterans Day", date: observed(fixed(time.November, 11))},
+			{name: "Thanksgiving Day", date: nthWeekday(time.November, time.Thursday, 4)},
+			{name: "Christmas Day", date: observed(fixed(time.December, 25))},
+		},
+	},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func fixed(month time.Month, day int) func(int) time.Time {
+	return func(year int) time.Time {
+		return date(year, month, day)
+	}
+}
+
+// easter returns Easter Sunday of the Gregorian calendar using the anonymous Gregorian algorithm.
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
+
+func easterOffset(days int) func(int) time.Time {
+	return func(year int) time.Time {
+		return easter(year).AddDate(0, 0, days)
+	}
+}
+
+// nthWeekday returns the n-th weekday of the month, the last one for n = -1.
+func nthWeekday(month time.Month, weekday time.Weekday, n int) func(int) time.Time {
+	return func(year int) time.Time {
+		if n < 0 {
+			last := date(year, month+1, 0)
+			return last.AddDate(0, 0, -((int(last.Weekday()) - int(weekday) + 7) % 7))
+		}
+
+		first := date(year, month, 1)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		return first.AddDate(0, 0, offset+(n-1)*7)
+	}
+}
+
+// observed moves holidays on a Saturday to the preceding Friday and on a Sunday to the following Monday.
+func observed(f func(int) time.Time) func(int) time.Time {
+	return func(year int) time.Time {
+		day := f(year)
+		switch day.Weekday() {
+		case time.Saturday:
+			return day.AddDate(0, 0, -1)
+		case time.Sunday:
+			return day.AddDate(0, 0, 1)
+		default:
+			return day
+		}
+	}
+}
+
+// dayOfPrayerAndRepentance is the last Wednesday before the 23rd of November.
+func dayOfPrayerAndRepentance(year int) time.Time {
+	day := date(year, time.November, 22)
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(time.Wednesday) + 7) % 7))
+}
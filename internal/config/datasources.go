@@ -9,23 +9,43 @@ import (
 )
 
 const (
-	Alertmanager = "alertmanager"
-	Astral       = "astral"
-	CalDav       = "caldav"
-	CardDav      = "carddav"
-	Logs         = "logs"
-	Taskwarrior  = "taskwarrior"
-	Stocks       = "stocks"
-	Weather      = "weather"
+	AirQuality    = "airquality"
+	Alertmanager  = "alertmanager"
+	Astral        = "astral"
+	Backups       = "backups"
+	CalDav        = "caldav"
+	CardDav       = "carddav"
+	Certificates  = "certificates"
+	Countdowns    = "countdowns"
+	Departures    = "departures"
+	Feeds         = "feeds"
+	Forge         = "forge"
+	Fx            = "fx"
+	Holidays      = "holidays"
+	HomeAssistant = "homeassistant"
+	Imap          = "imap"
+	Logs          = "logs"
+	Mqtt          = "mqtt"
+	Notes         = "notes"
+	Probe         = "probe"
+	Taskwarrior   = "taskwarrior"
+	Stocks        = "stocks"
+	System        = "system"
+	Updates       = "updates"
+	Waste         = "waste"
+	Weather       = "weather"
 )
 
 type DatasourceConfigContainer struct {
 	Config DatasourceConfig
+	// ExcludeFromSummary is read for all datasources, regardless of their type.
+	ExcludeFromSummary bool
 }
 
 func (ds *DatasourceConfigContainer) UnmarshalYAML(node *yaml.Node) error {
 	type inner struct {
-		Type string `yaml:"type"`
+		Type               string `yaml:"type"`
+		ExcludeFromSummary bool   `yaml:"exclude_from_summary"`
 	}
 
 	hookType := &inner{}
@@ -35,20 +55,54 @@ func (ds *DatasourceConfigContainer) UnmarshalYAML(node *yaml.Node) error {
 
 	var conf DatasourceConfig
 	switch hookType.Type {
+	case AirQuality:
+		conf = &AirQualityConfig{}
 	case Alertmanager:
 		conf = &AlertmanagerConfig{}
 	case Astral:
 		conf = &AstralConfig{}
+	case Backups:
+		conf = &BackupsConfig{}
 	case CalDav:
 		conf = &CalDavConfig{}
 	case CardDav:
 		conf = &CardDavConfig{}
+	case Certificates:
+		conf = &CertificatesConfig{}
+	case Countdowns:
+		conf = &CountdownsConfig{}
+	case Departures:
+		conf = &DeparturesConfig{}
+	case Feeds:
+		conf = &FeedsConfig{}
+	case Forge:
+		conf = &ForgeConfig{}
+	case Fx:
+		conf = &FxConfig{}
+	case Holidays:
+		conf = &HolidaysConfig{}
+	case HomeAssistant:
+		conf = &HomeAssistantConfig{}
+	case Imap:
+		conf = &ImapConfig{}
 	case Logs:
 		conf = &LogsConfig{}
+	case Mqtt:
+		conf = &MqttConfig{}
+	case Notes:
+		conf = &NotesConfig{}
+	case Probe:
+		conf = &ProbeConfig{}
 	case Stocks:
 		conf = &StocksConfig{}
+	case System:
+		conf = &SystemConfig{}
 	case Taskwarrior:
 		conf = &TaskwarriorConfig{}
+	case Updates:
+		conf = &UpdatesConfig{}
+	case Waste:
+		conf = &WasteConfig{}
 	case Weather:
 		conf = &WeatherConfig{}
 
@@ -60,6 +114,7 @@ func (ds *DatasourceConfigContainer) UnmarshalYAML(node *yaml.Node) error {
 		return err
 	}
 	ds.Config = conf
+	ds.ExcludeFromSummary = hookType.ExcludeFromSummary
 
 	if err := Validate(ds.Config); err != nil {
 		return err
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type AirQualityConfig struct {
+	Latitude     float64 `yaml:"latitude" validate:"latitude"`
+	Longitude    float64 `yaml:"longitude" validate:"longitude"`
+	Endpoint     string  `yaml:"endpoint" validate:"omitempty,url"`
+	NiceName     string  `yaml:"nice_name"`
+	ForecastDays int     `yaml:"forecast_days" validate:"omitempty,gte=1,lte=7"`
+
+	Thresholds AirQualityThresholds `yaml:"thresholds"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type AirQualityThresholds struct {
+	Aqi     float64 `yaml:"aqi" validate:"omitempty,gt=0"`
+	Pm25    float64 `yaml:"pm2_5" validate:"omitempty,gt=0"`
+	Pm10    float64 `yaml:"pm10" validate:"omitempty,gt=0"`
+	Ozone   float64 `yaml:"ozone" validate:"omitempty,gt=0"`
+	UvIndex float64 `yaml:"uv_index" validate:"omitempty,gt=0"`
+	Pollen  float64 `yaml:"pollen" validate:"omitempty,gt=0"`
+}
+
+func (ds *AirQualityConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp AirQualityConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = AirQualityConfig(*conf)
+	return nil
+}
+
+func (ds *AirQualityConfig) Type() string {
+	return AirQuality
+}
+
+func (ds *AirQualityConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *AirQualityConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
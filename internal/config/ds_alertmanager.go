@@ -7,9 +7,18 @@ import (
 )
 
 type AlertmanagerConfig struct {
-	Host     string `yaml:"host"`
+	// Host, BasePath and Scheme configure a single Alertmanager instance. Use Endpoints to query multiple instances.
+	Host     string `yaml:"host" validate:"required_without=Endpoints"`
 	BasePath string `yaml:"base_path"`
-	Scheme   string `yaml:"scheme" validate:"oneof=http https"`
+	Scheme   string `yaml:"scheme" validate:"omitempty,oneof=http https"`
+
+	Endpoints []AlertmanagerEndpoint `yaml:"endpoints" validate:"dive"`
+
+	Filters         []string `yaml:"filters"`
+	Receiver        string   `yaml:"receiver"`
+	Limit           int      `yaml:"limit" validate:"omitempty,gte=1"`
+	Labels          []string `yaml:"labels"`
+	SilenceMatchers []string `yaml:"silence_matchers"`
 
 	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
 	Cached             bool          `yaml:"cached"`
@@ -44,3 +53,19 @@ func (ds *AlertmanagerConfig) IsCached() bool {
 func (ds *AlertmanagerConfig) GetCacheExpiry() time.Duration {
 	return ds.CacheExpiry
 }
+
+// AlertmanagerEndpoint describes a single Alertmanager instance. Endpoints sharing the same name are treated as
+// peers of a HA cluster.
+type AlertmanagerEndpoint struct {
+	Name     string `yaml:"name"`
+	Host     string `yaml:"host" validate:"required"`
+	BasePath string `yaml:"base_path"`
+	Scheme   string `yaml:"scheme" validate:"omitempty,oneof=http https"`
+
+	Username        string `yaml:"username" validate:"required_with=Password PasswordFile"`
+	Password        string `yaml:"password" validate:"excluded_with=PasswordFile"`
+	PasswordFile    string `yaml:"password_file" validate:"omitempty,filepath"`
+	BearerToken     string `yaml:"bearer_token" validate:"excluded_with=BearerTokenFile"`
+	BearerTokenFile string `yaml:"bearer_token_file" validate:"omitempty,filepath"`
+	TlsCaFile       string `yaml:"tls_ca_file" validate:"omitempty,filepath"`
+}
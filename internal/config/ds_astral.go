@@ -7,8 +7,11 @@ import (
 )
 
 type AstralConfig struct {
-	Latitude  float64 `yaml:"latitude" validate:"latitude"`
-	Longitude float64 `yaml:"longitude" validate:"longitude"`
+	Latitude  float64          `yaml:"latitude" validate:"latitude"`
+	Longitude float64          `yaml:"longitude" validate:"longitude"`
+	Elevation float64          `yaml:"elevation"`
+	Locations []AstralLocation `yaml:"locations" validate:"dive"`
+	Timezone  string           `yaml:"timezone" validate:"omitempty,timezone"`
 
 	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
 	Cached             bool          `yaml:"cached"`
@@ -16,10 +19,20 @@ type AstralConfig struct {
 	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
 }
 
+type AstralLocation struct {
+	Name      string  `yaml:"name" validate:"required"`
+	Latitude  float64 `yaml:"latitude" validate:"latitude"`
+	Longitude float64 `yaml:"longitude" validate:"longitude"`
+	Elevation float64 `yaml:"elevation"`
+	// Timezone overrides the timezone of the datasource for this location
+	Timezone string `yaml:"timezone" validate:"omitempty,timezone"`
+}
+
 func (ds *AstralConfig) UnmarshalYAML(node *yaml.Node) error {
 	type tmp AstralConfig
 
 	conf := &tmp{
+		Timezone:    "Europe/Berlin",
 		Cached:      true,
 		CacheExpiry: 15 * time.Minute,
 	}
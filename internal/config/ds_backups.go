@@ -0,0 +1,56 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type BackupsConfig struct {
+	Jobs    []BackupJob   `yaml:"jobs" validate:"required,dive"`
+	Timeout time.Duration `yaml:"timeout" validate:"omitempty,gte=1s,lte=5m"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type BackupJob struct {
+	Name         string        `yaml:"name"`
+	Type         string        `yaml:"type" validate:"required,oneof=restic borg file textfile"`
+	Location     string        `yaml:"location" validate:"required"`
+	PasswordFile string        `yaml:"password_file" validate:"omitempty,filepath"`
+	MaxAge       time.Duration `yaml:"max_age" validate:"omitempty,gte=1m"`
+
+	TimestampMetric string            `yaml:"timestamp_metric" validate:"excluded_unless=Type textfile"`
+	SizeMetric      string            `yaml:"size_metric" validate:"excluded_unless=Type textfile"`
+	Labels          map[string]string `yaml:"labels" validate:"excluded_unless=Type textfile"`
+}
+
+func (ds *BackupsConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp BackupsConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 15 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = BackupsConfig(*conf)
+	return nil
+}
+
+func (ds *BackupsConfig) Type() string {
+	return Backups
+}
+
+func (ds *BackupsConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *BackupsConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type CertificatesConfig struct {
+	Sources       []CertificateSource `yaml:"sources" validate:"required,dive"`
+	Timeout       time.Duration       `yaml:"timeout" validate:"omitempty,gte=100ms,lte=1m"`
+	ThresholdDays int                 `yaml:"threshold_days" validate:"omitempty,gte=1"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type CertificateSource struct {
+	Name       string `yaml:"name"`
+	Type       string `yaml:"type" validate:"required,oneof=file secret endpoint"`
+	Location   string `yaml:"location" validate:"required"`
+	ServerName string `yaml:"server_name" validate:"excluded_unless=Type endpoint"`
+}
+
+func (ds *CertificatesConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp CertificatesConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = CertificatesConfig(*conf)
+	return nil
+}
+
+func (ds *CertificatesConfig) Type() string {
+	return Certificates
+}
+
+func (ds *CertificatesConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *CertificatesConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type CountdownsConfig struct {
+	Items    []CountdownItem `yaml:"items" validate:"required,dive"`
+	LeadDays int             `yaml:"lead_days" validate:"gte=0"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type CountdownItem struct {
+	Name string `yaml:"name" validate:"required"`
+	Date string `yaml:"date" validate:"required,datetime=2006-01-02"`
+	// Recurrence is either empty, monthly, quarterly, yearly or every N months / years.
+	Recurrence string `yaml:"recurrence"`
+	LeadDays   int    `yaml:"lead_days" validate:"gte=0"`
+	Category   string `yaml:"category"`
+	Emoji      string `yaml:"emoji"`
+}
+
+func (ds *CountdownsConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp CountdownsConfig
+
+	conf := &tmp{
+		LeadDays:    14,
+		Cached:      true,
+		CacheExpiry: 1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = CountdownsConfig(*conf)
+	return nil
+}
+
+func (ds *CountdownsConfig) Type() string {
+	return Countdowns
+}
+
+func (ds *CountdownsConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *CountdownsConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DeparturesProviderHafas = "hafas"
+	DeparturesProviderEfa   = "efa"
+)
+
+type DeparturesConfig struct {
+	Provider    string           `yaml:"provider" validate:"oneof=hafas efa"`
+	Endpoint    string           `yaml:"endpoint" validate:"required,url"`
+	Stops       []DeparturesStop `yaml:"stops" validate:"required,dive"`
+	Duration    time.Duration    `yaml:"duration" validate:"omitempty,gte=10m,lte=12h"`
+	Limit       int              `yaml:"limit" validate:"omitempty,gte=1"`
+	LeaveWindow time.Duration    `yaml:"leave_window" validate:"gte=0"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type DeparturesStop struct {
+	Id          string        `yaml:"id" validate:"required"`
+	Name        string        `yaml:"name"`
+	Lines       []string      `yaml:"lines"`
+	WalkingTime time.Duration `yaml:"walking_time" validate:"gte=0"`
+}
+
+func (ds *DeparturesConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp DeparturesConfig
+
+	conf := &tmp{
+		Provider:    DeparturesProviderHafas,
+		LeaveWindow: 5 * time.Minute,
+		Cached:      true,
+		CacheExpiry: 1 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = DeparturesConfig(*conf)
+	return nil
+}
+
+func (ds *DeparturesConfig) Type() string {
+	return Departures
+}
+
+func (ds *DeparturesConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *DeparturesConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
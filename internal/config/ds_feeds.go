@@ -0,0 +1,54 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type FeedsConfig struct {
+	Feeds        []FeedConfig  `yaml:"feeds" validate:"required,dive"`
+	Window       time.Duration `yaml:"window"`
+	NewFor       time.Duration `yaml:"new_for" validate:"gte=0"`
+	Limit        int           `yaml:"limit" validate:"omitempty,gte=1"`
+	SummaryItems int           `yaml:"summary_items" validate:"gte=0"`
+	StateFile    string        `yaml:"state_file" validate:"omitempty,filepath"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type FeedConfig struct {
+	Name string `yaml:"name"`
+	Url  string `yaml:"url" validate:"required,url"`
+}
+
+func (ds *FeedsConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp FeedsConfig
+
+	conf := &tmp{
+		SummaryItems: 5,
+		Cached:       true,
+		CacheExpiry:  30 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = FeedsConfig(*conf)
+	return nil
+}
+
+func (ds *FeedsConfig) Type() string {
+	return Feeds
+}
+
+func (ds *FeedsConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *FeedsConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ForgeConfig struct {
+	Flavor    string   `yaml:"flavor" validate:"required,oneof=github gitea forgejo"`
+	Endpoint  string   `yaml:"endpoint" validate:"required_unless=Flavor github,omitempty,url"`
+	Token     string   `yaml:"token" validate:"required_without=TokenFile,excluded_with=TokenFile"`
+	TokenFile string   `yaml:"token_file" validate:"required_without=Token,omitempty,filepath"`
+	Repos     []string `yaml:"repos" validate:"dive,required,contains=/"`
+	NiceName  string   `yaml:"nice_name"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+func (ds *ForgeConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp ForgeConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 10 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = ForgeConfig(*conf)
+	return nil
+}
+
+func (ds *ForgeConfig) Type() string {
+	return Forge
+}
+
+func (ds *ForgeConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *ForgeConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FxProviderEcb  = "ecb"
+	FxProviderJson = "json"
+)
+
+type FxConfig struct {
+	Provider   string   `yaml:"provider" validate:"oneof=ecb json"`
+	Endpoint   string   `yaml:"endpoint" validate:"required_if=Provider json,omitempty,url"`
+	Base       string   `yaml:"base" validate:"len=3"`
+	Currencies []string `yaml:"currencies" validate:"required,dive,len=3"`
+
+	// Crypto contains CoinGecko ids, e.g. "bitcoin".
+	Crypto         []string `yaml:"crypto"`
+	CryptoEndpoint string   `yaml:"crypto_endpoint" validate:"omitempty,url"`
+
+	StateFile       string  `yaml:"state_file" validate:"omitempty,filepath"`
+	Threshold       float64 `yaml:"threshold" validate:"gte=0"`
+	CryptoThreshold float64 `yaml:"crypto_threshold" validate:"gte=0"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+func (ds *FxConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp FxConfig
+
+	conf := &tmp{
+		Provider:        FxProviderEcb,
+		Base:            "EUR",
+		Threshold:       1,
+		CryptoThreshold: 5,
+		Cached:          true,
+		CacheExpiry:     1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = FxConfig(*conf)
+	return nil
+}
+
+func (ds *FxConfig) Type() string {
+	return Fx
+}
+
+func (ds *FxConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *FxConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type HolidaysConfig struct {
+	// Country is the ISO 3166-1 code of the country, e.g. DE
+	Country string `yaml:"country" validate:"required,len=2"`
+	// Region is the ISO 3166-2 subdivision of the country, e.g. BY or DE-BY
+	Region string `yaml:"region"`
+	// VacationsIcs is the URL or path of an iCalendar file containing school vacations
+	VacationsIcs string            `yaml:"vacations_ics"`
+	Vacations    []HolidayVacation `yaml:"vacations" validate:"dive"`
+	HorizonDays  int               `yaml:"horizon_days" validate:"omitempty,gte=1,lte=366"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type HolidayVacation struct {
+	Name  string `yaml:"name" validate:"required"`
+	Start string `yaml:"start" validate:"required,datetime=2006-01-02"`
+	End   string `yaml:"end" validate:"required,datetime=2006-01-02"`
+}
+
+func (ds *HolidaysConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp HolidaysConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = HolidaysConfig(*conf)
+	return nil
+}
+
+func (ds *HolidaysConfig) Type() string {
+	return Holidays
+}
+
+func (ds *HolidaysConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *HolidaysConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type HomeAssistantConfig struct {
+	Endpoint   string                   `yaml:"endpoint" validate:"required,url"`
+	Token      string                   `yaml:"token" validate:"required_without=TokenFile,excluded_with=TokenFile"`
+	TokenFile  string                   `yaml:"token_file" validate:"required_without=Token,omitempty,filepath"`
+	Entities   []HomeAssistantEntity    `yaml:"entities" validate:"required_without=Conditions,dive"`
+	Conditions []HomeAssistantCondition `yaml:"conditions" validate:"dive"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type HomeAssistantEntity struct {
+	Id       string   `yaml:"id" validate:"required"`
+	Name     string   `yaml:"name"`
+	Warning  *float64 `yaml:"warning"`
+	Critical *float64 `yaml:"critical"`
+	Below    bool     `yaml:"below"`
+}
+
+type HomeAssistantCondition struct {
+	Expression string `yaml:"expression" validate:"required"`
+	Message    string `yaml:"message" validate:"required"`
+}
+
+func (ds *HomeAssistantConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp HomeAssistantConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 5 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = HomeAssistantConfig(*conf)
+	return nil
+}
+
+func (ds *HomeAssistantConfig) Type() string {
+	return HomeAssistant
+}
+
+func (ds *HomeAssistantConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *HomeAssistantConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
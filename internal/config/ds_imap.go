@@ -0,0 +1,57 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ImapConfig struct {
+	Accounts  []ImapAccount `yaml:"accounts" validate:"required,dive"`
+	Latest    int           `yaml:"latest" validate:"gte=0,lte=50"`
+	Timeout   time.Duration `yaml:"timeout" validate:"omitempty,gte=1s,lte=2m"`
+	TlsCaFile string        `yaml:"tls_ca_file" validate:"omitempty,filepath"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type ImapAccount struct {
+	Name         string   `yaml:"name"`
+	Host         string   `yaml:"host" validate:"required,hostname_port"`
+	Security     string   `yaml:"security" validate:"omitempty,oneof=tls starttls"`
+	Username     string   `yaml:"username" validate:"required"`
+	Password     string   `yaml:"password" validate:"required_without=PasswordFile,excluded_with=PasswordFile"`
+	PasswordFile string   `yaml:"password_file" validate:"required_without=Password,omitempty,filepath"`
+	Folders      []string `yaml:"folders" validate:"dive,required"`
+}
+
+func (ds *ImapConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp ImapConfig
+
+	conf := &tmp{
+		Latest:      5,
+		Cached:      true,
+		CacheExpiry: 5 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = ImapConfig(*conf)
+	return nil
+}
+
+func (ds *ImapConfig) Type() string {
+	return Imap
+}
+
+func (ds *ImapConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *ImapConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -6,10 +6,37 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	LogsBackendVictorialogs  = "victorialogs"
+	LogsBackendLoki          = "loki"
+	LogsBackendOpensearch    = "opensearch"
+	LogsBackendElasticsearch = "elasticsearch"
+)
+
 type LogsConfig struct {
-	Endpoint string `yaml:"endpoint" validate:"required,url"`
-	Query    string `yaml:"query"`
-	Limit    int    `yaml:"limit" validate:"omitempty,gte=1,lte=50"`
+	Backend  string        `yaml:"backend" validate:"oneof=victorialogs loki opensearch elasticsearch"`
+	Endpoint string        `yaml:"endpoint" validate:"required,url"`
+	Query    string        `yaml:"query" validate:"excluded_with=Queries"`
+	Limit    int           `yaml:"limit" validate:"omitempty,gte=1,lte=50"`
+	Lookback time.Duration `yaml:"lookback"`
+
+	// Queries defines multiple named queries and can not be used in conjunction with Query.
+	Queries []LogsQuery `yaml:"queries" validate:"dive"`
+
+	// Stats defines queries whose counts are displayed as trends. Only supported by the victorialogs backend.
+	Stats []LogsStatsQuery `yaml:"stats" validate:"excluded_unless=Backend victorialogs,dive"`
+
+	Username     string `yaml:"username" validate:"required_with=Password PasswordFile"`
+	Password     string `yaml:"password" validate:"excluded_with=PasswordFile"`
+	PasswordFile string `yaml:"password_file" validate:"omitempty,filepath"`
+
+	// TenantId is sent as X-Scope-OrgID header to multi-tenant Loki installations.
+	TenantId string `yaml:"tenant_id"`
+
+	// Index, TimestampField and MessageField are only used by the opensearch and elasticsearch backends.
+	Index          string `yaml:"index" validate:"required_if=Backend opensearch,required_if=Backend elasticsearch"`
+	TimestampField string `yaml:"timestamp_field"`
+	MessageField   string `yaml:"message_field"`
 
 	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
 	Cached             bool          `yaml:"cached"`
@@ -17,10 +44,30 @@ type LogsConfig struct {
 	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
 }
 
+type LogsQuery struct {
+	Name        string        `yaml:"name" validate:"required"`
+	Query       string        `yaml:"query" validate:"required"`
+	Limit       int           `yaml:"limit" validate:"omitempty,gte=1,lte=50"`
+	Lookback    time.Duration `yaml:"lookback"`
+	Fields      []string      `yaml:"fields"`
+	SourceField string        `yaml:"source_field"`
+	Group       bool          `yaml:"group"`
+}
+
+type LogsStatsQuery struct {
+	Name     string        `yaml:"name" validate:"required"`
+	Query    string        `yaml:"query" validate:"required"`
+	GroupBy  string        `yaml:"group_by"`
+	Window   time.Duration `yaml:"window"`
+	Step     time.Duration `yaml:"step"`
+	Baseline float64       `yaml:"baseline" validate:"gte=0"`
+}
+
 func (ds *LogsConfig) UnmarshalYAML(node *yaml.Node) error {
 	type tmp LogsConfig
 
 	conf := &tmp{
+		Backend:     LogsBackendVictorialogs,
 		Cached:      true,
 		CacheExpiry: 1 * time.Minute,
 	}
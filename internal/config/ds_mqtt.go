@@ -0,0 +1,59 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type MqttConfig struct {
+	Broker       string        `yaml:"broker" validate:"required,url"`
+	ClientId     string        `yaml:"client_id"`
+	Username     string        `yaml:"username" validate:"required_with=Password PasswordFile"`
+	Password     string        `yaml:"password" validate:"excluded_with=PasswordFile"`
+	PasswordFile string        `yaml:"password_file" validate:"omitempty,filepath"`
+	TlsCaFile    string        `yaml:"tls_ca_file" validate:"omitempty,filepath"`
+	NiceName     string        `yaml:"nice_name"`
+	StaleAfter   time.Duration `yaml:"stale_after" validate:"omitempty,gte=1m"`
+	Topics       []MqttTopic   `yaml:"topics" validate:"required,dive"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type MqttTopic struct {
+	Name       string        `yaml:"name"`
+	Topic      string        `yaml:"topic" validate:"required"`
+	Path       string        `yaml:"path"`
+	Unit       string        `yaml:"unit"`
+	StaleAfter time.Duration `yaml:"stale_after" validate:"omitempty,gte=1m"`
+}
+
+func (ds *MqttConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp MqttConfig
+
+	// values are kept in memory, caching them makes no sense
+	conf := &tmp{
+		Cached: false,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = MqttConfig(*conf)
+	return nil
+}
+
+func (ds *MqttConfig) Type() string {
+	return Mqtt
+}
+
+func (ds *MqttConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *MqttConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
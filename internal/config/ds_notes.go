@@ -0,0 +1,44 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type NotesConfig struct {
+	Files    []string `yaml:"files" validate:"required,dive,required"`
+	NiceName string   `yaml:"nice_name"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+func (ds *NotesConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp NotesConfig
+
+	// notes are re-read on every refresh so edits show up immediately
+	conf := &tmp{
+		Cached: false,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = NotesConfig(*conf)
+	return nil
+}
+
+func (ds *NotesConfig) Type() string {
+	return Notes
+}
+
+func (ds *NotesConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *NotesConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
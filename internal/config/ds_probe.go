@@ -0,0 +1,53 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type ProbeConfig struct {
+	Targets         []ProbeTarget `yaml:"targets" validate:"required,dive"`
+	Timeout         time.Duration `yaml:"timeout" validate:"omitempty,gte=100ms,lte=1m"`
+	CertWarningDays int           `yaml:"cert_warning_days" validate:"omitempty,gte=1"`
+	SlowThreshold   time.Duration `yaml:"slow_threshold" validate:"gte=0"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type ProbeTarget struct {
+	Name           string `yaml:"name"`
+	Type           string `yaml:"type" validate:"required,oneof=http tcp tls dns"`
+	Address        string `yaml:"address" validate:"required"`
+	ExpectedStatus []int  `yaml:"expected_status" validate:"dive,gte=100,lte=599"`
+}
+
+func (ds *ProbeConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp ProbeConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 5 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = ProbeConfig(*conf)
+	return nil
+}
+
+func (ds *ProbeConfig) Type() string {
+	return Probe
+}
+
+func (ds *ProbeConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *ProbeConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
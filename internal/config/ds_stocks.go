@@ -7,7 +7,16 @@ import (
 )
 
 type StocksConfig struct {
-	Symbols []string `yaml:"symbols"`
+	Provider string `yaml:"provider" validate:"oneof=yahoo"`
+	Endpoint string `yaml:"endpoint" validate:"omitempty,url"`
+
+	Symbols  []string        `yaml:"symbols" validate:"required_without=Holdings"`
+	Holdings []StocksHolding `yaml:"holdings" validate:"dive"`
+	// Currency is the currency the value of the holdings is converted into.
+	Currency string `yaml:"currency" validate:"required_with=Holdings,omitempty,len=3"`
+
+	Ranges         []string `yaml:"ranges" validate:"dive,oneof=1d 1w 1mo 3mo 6mo ytd 1y 5y"`
+	MoverThreshold float64  `yaml:"mover_threshold" validate:"gte=0"`
 
 	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
 	Cached             bool          `yaml:"cached"`
@@ -15,12 +24,19 @@ type StocksConfig struct {
 	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
 }
 
+type StocksHolding struct {
+	Symbol string  `yaml:"symbol" validate:"required"`
+	Shares float64 `yaml:"shares" validate:"gt=0"`
+}
+
 func (ds *StocksConfig) UnmarshalYAML(node *yaml.Node) error {
 	type tmp StocksConfig
 
 	conf := &tmp{
-		Cached:      true,
-		CacheExpiry: 15 * time.Minute,
+		Provider:       "yahoo",
+		MoverThreshold: 3,
+		Cached:         true,
+		CacheExpiry:    15 * time.Minute,
 	}
 	if err := node.Decode(&conf); err != nil {
 		return err
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type SystemConfig struct {
+	ProcDir       string   `yaml:"proc_dir" validate:"omitempty,dirpath"`
+	Mounts        []string `yaml:"mounts" validate:"omitempty,dive,required"`
+	RebootMarkers []string `yaml:"reboot_markers" validate:"omitempty,dive,filepath"`
+
+	// SystemdUnits enables checking for failed systemd units, SystemdUser checks the units of the user's manager instead.
+	SystemdUnits bool `yaml:"systemd_units"`
+	SystemdUser  bool `yaml:"systemd_user"`
+
+	DiskThreshold   float64 `yaml:"disk_threshold" validate:"omitempty,gt=0,lte=100"`
+	MemoryThreshold float64 `yaml:"memory_threshold" validate:"omitempty,gt=0,lte=100"`
+	LoadThreshold   float64 `yaml:"load_threshold" validate:"omitempty,gt=0"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+func (ds *SystemConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp SystemConfig
+
+	conf := &tmp{
+		SystemdUnits: true,
+		Cached:       true,
+		CacheExpiry:  1 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = SystemConfig(*conf)
+	return nil
+}
+
+func (ds *SystemConfig) Type() string {
+	return System
+}
+
+func (ds *SystemConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *SystemConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -17,6 +17,10 @@ type TaskwarriorConfig struct {
 
 	SummaryDays        int  `yaml:"summary_days" validate:"omitempty,gte=1,lte=14"`
 	ExcludeFromSummary bool `yaml:"exclude_from_summary"`
+
+	// HolidaysCountry and HolidaysRegion enable counting only working days for the summary
+	HolidaysCountry string `yaml:"holidays_country"`
+	HolidaysRegion  string `yaml:"holidays_region" validate:"excluded_without=HolidaysCountry"`
 }
 
 func (ds *TaskwarriorConfig) UnmarshalYAML(node *yaml.Node) error {
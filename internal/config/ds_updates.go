@@ -0,0 +1,51 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type UpdatesConfig struct {
+	// PackageManager is one of apt, dnf, apk or auto to pick the one found in PATH
+	PackageManager string `yaml:"package_manager" validate:"required_without=ContainerRuntime,omitempty,oneof=auto apt dnf apk"`
+	// ContainerRuntime is used to look up the local digests of the images
+	ContainerRuntime string `yaml:"container_runtime" validate:"omitempty,oneof=docker podman"`
+	// Images are checked against their registries, if empty the images of all running containers are checked
+	Images             []string      `yaml:"images" validate:"excluded_without=ContainerRuntime"`
+	InsecureRegistries []string      `yaml:"insecure_registries"`
+	Limit              int           `yaml:"limit" validate:"omitempty,gte=1"`
+	Timeout            time.Duration `yaml:"timeout" validate:"omitempty,gte=1s,lte=10m"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+func (ds *UpdatesConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp UpdatesConfig
+
+	conf := &tmp{
+		Cached:      true,
+		CacheExpiry: 1 * time.Hour,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = UpdatesConfig(*conf)
+	return nil
+}
+
+func (ds *UpdatesConfig) Type() string {
+	return Updates
+}
+
+func (ds *UpdatesConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *UpdatesConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type WasteConfig struct {
+	// Ics is the URL or path of an iCalendar file
+	Ics string `yaml:"ics" validate:"required_without=File,excluded_with=File"`
+	// File is the path of a YAML or CSV file containing date and bin per collection
+	File        string        `yaml:"file" validate:"required_without=Ics,omitempty,filepath"`
+	Bins        []WasteBin    `yaml:"bins" validate:"dive"`
+	Horizon     time.Duration `yaml:"horizon" validate:"omitempty,gte=24h"`
+	Count       int           `yaml:"count" validate:"omitempty,gte=1"`
+	EveningHour int           `yaml:"evening_hour" validate:"gte=0,lte=23"`
+
+	TemplateFile       string        `yaml:"template_file" validate:"omitempty,filepath"`
+	Cached             bool          `yaml:"cached"`
+	CacheExpiry        time.Duration `yaml:"cache_expiry"`
+	ExcludeFromSummary bool          `yaml:"exclude_from_summary"`
+}
+
+type WasteBin struct {
+	Name  string   `yaml:"name" validate:"required"`
+	Emoji string   `yaml:"emoji"`
+	Color string   `yaml:"color" validate:"omitempty,oneof=red orange yellow green blue lightblue"`
+	Match []string `yaml:"match"`
+}
+
+func (ds *WasteConfig) UnmarshalYAML(node *yaml.Node) error {
+	type tmp WasteConfig
+
+	conf := &tmp{
+		EveningHour: 16,
+		Cached:      true,
+		// the summary depends on the time of day, a long expiry would delay the reminders
+		CacheExpiry: 5 * time.Minute,
+	}
+	if err := node.Decode(&conf); err != nil {
+		return err
+	}
+
+	*ds = WasteConfig(*conf)
+	return nil
+}
+
+func (ds *WasteConfig) Type() string {
+	return Waste
+}
+
+func (ds *WasteConfig) IsCached() bool {
+	return ds.Cached
+}
+
+func (ds *WasteConfig) GetCacheExpiry() time.Duration {
+	return ds.CacheExpiry
+}
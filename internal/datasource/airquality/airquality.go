@@ -0,0 +1,135 @@
+package airquality
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+type Lat float64
+type Lon float64
+
+type Client interface {
+	GetAirQuality(ctx context.Context) ([]Day, error)
+	// Lat, lon
+	GetLocation() (Lat, Lon)
+	GetNiceName() string
+}
+
+type Opt func(datasource *AirQualityDatasource) error
+
+type AirQualityDatasource struct {
+	client             Client
+	thresholds         Thresholds
+	regularTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+var funcMap = template.FuncMap{
+	"weekday":             formatWeekday,
+	"getClassForAqi":      getClassForAqi,
+	"getClassForPm25":     getClassForPm25,
+	"getClassForPm10":     getClassForPm10,
+	"getClassForOzone":    getClassForOzone,
+	"getClassForUvIndex":  getClassForUvIndex,
+	"getClassForPollen":   getClassForPollen,
+	"getEmojiForUvIndex":  getEmojiForUvIndex,
+	"getDescriptionByAqi": getDescriptionByAqi,
+}
+
+func New(client Client, templateData templates.TemplateData, opts ...Opt) (*AirQualityDatasource, error) {
+	if client == nil {
+		return nil, errors.New("nil client passed")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid template data: %w", err)
+	}
+
+	ds := &AirQualityDatasource{
+		client:     client,
+		thresholds: DefaultThresholds(),
+	}
+
+	var err error
+	ds.regularTemplate, err = template.New("airquality-regular").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("airquality-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
+}
+
+func (a *AirQualityDatasource) Name() string {
+	if a.client.GetNiceName() != "" {
+		return fmt.Sprintf("Air Quality %s", a.client.GetNiceName())
+	}
+	lat, lon := a.client.GetLocation()
+	return fmt.Sprintf("Air Quality lat %f, lon %f", lat, lon)
+}
+
+func formatWeekday(t time.Time) string {
+	return t.Weekday().String()
+}
+
+func (a *AirQualityDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	days, err := a.client.GetAirQuality(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	data := AirQualityData{
+		Days:     days,
+		Now:      now.Format("2006-01-02"),
+		Tomorrow: now.AddDate(0, 0, 1).Format("2006-01-02"),
+		HtmlId:   pkg.NameToId(a.Name()),
+		NiceName: a.client.GetNiceName(),
+	}
+
+	var regularTemplateData bytes.Buffer
+	if err := a.regularTemplate.Execute(&regularTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", a.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if a.simpleTemplate != nil {
+		if err := a.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", a.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !a.excludeFromSummary {
+		summary = getSummary(days, a.thresholds, now)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    regularTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package airquality
+
+// The levels follow the bands of the European Air Quality Index.
+const (
+	aqiVeryPoor = 80
+	aqiPoor     = 60
+	aqiModerate = 40
+	aqiFair     = 20
+
+	pm25VeryPoor = 50
+	pm25Poor     = 25
+	pm25Moderate = 20
+
+	pm10VeryPoor = 100
+	pm10Poor     = 50
+	pm10Moderate = 40
+
+	ozoneVeryPoor = 240
+	ozonePoor     = 130
+	ozoneModerate = 100
+
+	uvVeryHigh = 8
+	uvHigh     = 6
+	uvModerate = 3
+
+	pollenHigh     = 100
+	pollenModerate = 50
+	pollenLow      = 10
+)
+
+func getClassForAqi(aqi float64) string {
+	if aqi >= aqiVeryPoor {
+		return "red"
+	}
+	if aqi >= aqiPoor {
+		return "orange"
+	}
+	if aqi >= aqiModerate {
+		return "yellow"
+	}
+	return ""
+}
+
+func getDescriptionByAqi(aqi float64) string {
+	if aqi >= 100 {
+		return "extremely poor"
+	}
+	if aqi >= aqiVeryPoor {
+		return "very poor"
+	}
+	if aqi >= aqiPoor {
+		return "poor"
+	}
+	if aqi >= aqiModerate {
+		return "moderate"
+	}
+	if aqi >= aqiFair {
+		return "fair"
+	}
+	return "good"
+}
+
+func getClassForPm25(pm25 float64) string {
+	if pm25 >= pm25VeryPoor {
+		return "red"
+	}
+	if pm25 >= pm25Poor {
+		return "orange"
+	}
+	if pm25 >= pm25Moderate {
+		return "yellow"
+	}
+	return ""
+}
+
+func getClassForPm10(pm10 float64) string {
+	if pm10 >= pm10VeryPoor {
+		return "red"
+	}
+	if pm10 >= pm10Poor {
+		return "orange"
+	}
+	if pm10 >= pm10Moderate {
+		return "yellow"
+	}
+	return ""
+}
+
+func getClassForOzone(ozone float64) string {
+	if ozone >= ozoneVeryPoor {
+		return "red"
+	}
+	if ozone >= ozonePoor {
+		return "orange"
+	}
+	if ozone >= ozoneModerate {
+		return "yellow"
+	}
+	return ""
+}
+
+func getClassForUvIndex(uv float64) string {
+	if uv >= uvVeryHigh {
+		return "red"
+	}
+	if uv >= uvHigh {
+		return "orange"
+	}
+	if uv >= uvModerate {
+		return "yellow"
+	}
+	return ""
+}
+
+func getEmojiForUvIndex(uv float64) string {
+	if uv >= uvHigh {
+		return "🕶️"
+	}
+	if uv >= uvModerate {
+		return "🧴"
+	}
+	return ""
+}
+
+func getClassForPollen(pollen float64) string {
+	if pollen >= pollenHigh {
+		return "red"
+	}
+	if pollen >= pollenModerate {
+		return "orange"
+	}
+	if pollen >= pollenLow {
+		return "yellow"
+	}
+	return ""
+}
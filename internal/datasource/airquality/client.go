@@ -0,0 +1,96 @@
+package airquality
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultOpenMeteoApiUrl = "https://air-quality-api.open-meteo.com/v1/air-quality"
+	defaultForecastDays    = 3
+)
+
+type OpenMeteoClient struct {
+	httpClient   *http.Client
+	baseUrl      string
+	lat          Lat
+	lon          Lon
+	niceName     string
+	forecastDays int
+}
+
+type OpenMeteoOpt func(client *OpenMeteoClient) error
+
+func NewOpenMeteoClient(lat Lat, lon Lon, niceName string, opts ...OpenMeteoOpt) (*OpenMeteoClient, error) {
+	c := &OpenMeteoClient{
+		lat:          lat,
+		lon:          lon,
+		niceName:     niceName,
+		baseUrl:      defaultOpenMeteoApiUrl,
+		forecastDays: defaultForecastDays,
+		httpClient:   http.DefaultClient,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return c, errs
+}
+
+func (c *OpenMeteoClient) GetLocation() (Lat, Lon) {
+	return c.lat, c.lon
+}
+
+func (c *OpenMeteoClient) GetNiceName() string {
+	return c.niceName
+}
+
+func (c *OpenMeteoClient) GetAirQuality(ctx context.Context) ([]Day, error) {
+	u, err := url.Parse(c.baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse url: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("latitude", strconv.FormatFloat(float64(c.lat), 'f', -1, 64))
+	q.Set("longitude", strconv.FormatFloat(float64(c.lon), 'f', -1, 64))
+	q.Set("hourly", buildHourlyParam())
+	q.Set("timezone", "auto")
+	q.Set("forecast_days", strconv.Itoa(c.forecastDays))
+	u.RawQuery = q.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseOpenMeteoResponse(body)
+}
@@ -0,0 +1,149 @@
+package airquality
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AirQualityData struct {
+	Days []Day
+
+	Now      string
+	Tomorrow string
+	HtmlId   string
+	NiceName string
+}
+
+type Day struct {
+	Date    time.Time
+	Aqi     float64
+	Pm25    float64
+	Pm10    float64
+	Ozone   float64
+	UvIndex float64
+	Pollen  []Pollen
+}
+
+type Pollen struct {
+	Name  string
+	Value float64
+}
+
+// openMeteoResponse is the relevant subset of the response of Open-Meteo's air-quality API.
+type openMeteoResponse struct {
+	Timezone string         `json:"timezone"`
+	Hourly   openMeteoHours `json:"hourly"`
+}
+
+type openMeteoHours struct {
+	Time          []string   `json:"time"`
+	EuropeanAqi   []*float64 `json:"european_aqi"`
+	Pm25          []*float64 `json:"pm2_5"`
+	Pm10          []*float64 `json:"pm10"`
+	Ozone         []*float64 `json:"ozone"`
+	UvIndex       []*float64 `json:"uv_index"`
+	AlderPollen   []*float64 `json:"alder_pollen"`
+	BirchPollen   []*float64 `json:"birch_pollen"`
+	GrassPollen   []*float64 `json:"grass_pollen"`
+	MugwortPollen []*float64 `json:"mugwort_pollen"`
+	OlivePollen   []*float64 `json:"olive_pollen"`
+	RagweedPollen []*float64 `json:"ragweed_pollen"`
+}
+
+var hourlyVariables = []string{
+	"european_aqi",
+	"pm2_5",
+	"pm10",
+	"ozone",
+	"uv_index",
+	"alder_pollen",
+	"birch_pollen",
+	"grass_pollen",
+	"mugwort_pollen",
+	"olive_pollen",
+	"ragweed_pollen",
+}
+
+func parseOpenMeteoResponse(body []byte) ([]Day, error) {
+	resp := openMeteoResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	location := time.UTC
+	if resp.Timezone != "" {
+		var err error
+		location, err = time.LoadLocation(resp.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q: %w", resp.Timezone, err)
+		}
+	}
+
+	h := resp.Hourly
+	pollen := []struct {
+		name   string
+		values []*float64
+	}{
+		{"Alder", h.AlderPollen},
+		{"Birch", h.BirchPollen},
+		{"Grass", h.GrassPollen},
+		{"Mugwort", h.MugwortPollen},
+		{"Olive", h.OlivePollen},
+		{"Ragweed", h.RagweedPollen},
+	}
+
+	var days []Day
+	for index, ts := range h.Time {
+		t, err := time.ParseInLocation("2006-01-02T15:04", ts, location)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse time %q: %w", ts, err)
+		}
+
+		date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+		if len(days) == 0 || !days[len(days)-1].Date.Equal(date) {
+			days = append(days, Day{Date: date})
+		}
+
+		day := &days[len(days)-1]
+		day.Aqi = maxAt(day.Aqi, h.EuropeanAqi, index)
+		day.Pm25 = maxAt(day.Pm25, h.Pm25, index)
+		day.Pm10 = maxAt(day.Pm10, h.Pm10, index)
+		day.Ozone = maxAt(day.Ozone, h.Ozone, index)
+		day.UvIndex = maxAt(day.UvIndex, h.UvIndex, index)
+
+		for pollenIndex, p := range pollen {
+			if len(day.Pollen) < len(pollen) {
+				day.Pollen = append(day.Pollen, Pollen{Name: p.name})
+			}
+			day.Pollen[pollenIndex].Value = maxAt(day.Pollen[pollenIndex].Value, p.values, index)
+		}
+	}
+
+	return days, nil
+}
+
+// maxAt returns the bigger value of cur and the value at the given index of values, ignoring missing values.
+func maxAt(cur float64, values []*float64, index int) float64 {
+	if index >= len(values) || values[index] == nil {
+		return cur
+	}
+
+	return max(cur, *values[index])
+}
+
+// ActivePollen returns all pollen with a value greater than zero.
+func (d Day) ActivePollen() []Pollen {
+	var ret []Pollen
+	for _, p := range d.Pollen {
+		if p.Value > 0 {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+func buildHourlyParam() string {
+	return strings.Join(hourlyVariables, ",")
+}
@@ -0,0 +1,82 @@
+package airquality
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"os"
+)
+
+func WithHttpClient(client *http.Client) OpenMeteoOpt {
+	return func(c *OpenMeteoClient) error {
+		if client == nil {
+			return errors.New("empty http client provided")
+		}
+
+		c.httpClient = client
+		return nil
+	}
+}
+
+func WithEndpoint(endpoint string) OpenMeteoOpt {
+	return func(c *OpenMeteoClient) error {
+		if endpoint == "" {
+			return errors.New("empty endpoint provided")
+		}
+
+		c.baseUrl = endpoint
+		return nil
+	}
+}
+
+func WithForecastDays(days int) OpenMeteoOpt {
+	return func(c *OpenMeteoClient) error {
+		if days < 1 || days > 7 {
+			return errors.New("forecast days must be [1, 7]")
+		}
+
+		c.forecastDays = days
+		return nil
+	}
+}
+
+func WithThresholds(thresholds Thresholds) Opt {
+	return func(ds *AirQualityDatasource) error {
+		if thresholds.Aqi > 0 {
+			ds.thresholds.Aqi = thresholds.Aqi
+		}
+		if thresholds.Pm25 > 0 {
+			ds.thresholds.Pm25 = thresholds.Pm25
+		}
+		if thresholds.Pm10 > 0 {
+			ds.thresholds.Pm10 = thresholds.Pm10
+		}
+		if thresholds.Ozone > 0 {
+			ds.thresholds.Ozone = thresholds.Ozone
+		}
+		if thresholds.UvIndex > 0 {
+			ds.thresholds.UvIndex = thresholds.UvIndex
+		}
+		if thresholds.Pollen > 0 {
+			ds.thresholds.Pollen = thresholds.Pollen
+		}
+		return nil
+	}
+}
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *AirQualityDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("airquality").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.regularTemplate = temp
+		return nil
+	}
+}
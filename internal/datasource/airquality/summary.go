@@ -0,0 +1,78 @@
+package airquality
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+type Thresholds struct {
+	Aqi     float64
+	Pm25    float64
+	Pm10    float64
+	Ozone   float64
+	UvIndex float64
+	Pollen  float64
+}
+
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Aqi:     aqiPoor,
+		Pm25:    pm25Poor,
+		Pm10:    pm10Poor,
+		Ozone:   ozonePoor,
+		UvIndex: uvHigh,
+		Pollen:  pollenModerate,
+	}
+}
+
+func getSummary(days []Day, thresholds Thresholds, now time.Time) []string {
+	tomorrow := now.AddDate(0, 0, 1)
+
+	var summary []string
+	for _, day := range days {
+		var prefix string
+		if pkg.IsToday(day.Date, now) {
+			prefix = "Today"
+		} else if pkg.IsToday(day.Date, tomorrow) {
+			prefix = "Tomorrow"
+		} else {
+			continue
+		}
+
+		var pollution []string
+		if day.Aqi >= thresholds.Aqi {
+			pollution = append(pollution, fmt.Sprintf("AQI %.0f (%s)", day.Aqi, getDescriptionByAqi(day.Aqi)))
+		}
+		if day.Pm25 >= thresholds.Pm25 {
+			pollution = append(pollution, fmt.Sprintf("PM2.5 %.0f µg/m³", day.Pm25))
+		}
+		if day.Pm10 >= thresholds.Pm10 {
+			pollution = append(pollution, fmt.Sprintf("PM10 %.0f µg/m³", day.Pm10))
+		}
+		if day.Ozone >= thresholds.Ozone {
+			pollution = append(pollution, fmt.Sprintf("ozone %.0f µg/m³", day.Ozone))
+		}
+		if len(pollution) > 0 {
+			summary = append(summary, fmt.Sprintf("😷 %s: %s", prefix, strings.Join(pollution, ", ")))
+		}
+
+		var pollen []string
+		for _, p := range day.Pollen {
+			if p.Value >= thresholds.Pollen {
+				pollen = append(pollen, fmt.Sprintf("%s %.0f", p.Name, p.Value))
+			}
+		}
+		if len(pollen) > 0 {
+			summary = append(summary, fmt.Sprintf("🤧 %s: High pollen count (%s)", prefix, strings.Join(pollen, ", ")))
+		}
+
+		if day.UvIndex >= thresholds.UvIndex {
+			summary = append(summary, fmt.Sprintf("🕶️ %s: UV index %.1f", prefix, day.UvIndex))
+		}
+	}
+
+	return summary
+}
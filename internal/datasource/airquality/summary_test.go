@@ -0,0 +1,118 @@
+package airquality
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_getSummary(t *testing.T) {
+	now := time.Date(2025, 4, 10, 8, 0, 0, 0, time.UTC)
+
+	type args struct {
+		days       []Day
+		thresholds Thresholds
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "nothing exceeds thresholds",
+			args: args{
+				days: []Day{
+					{Date: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), Aqi: 25, Pm25: 8, Pm10: 15, Ozone: 60, UvIndex: 2.5, Pollen: []Pollen{{Name: "Birch", Value: 12}}},
+				},
+				thresholds: DefaultThresholds(),
+			},
+			want: nil,
+		},
+		{
+			name: "today and tomorrow exceed thresholds",
+			args: args{
+				days: []Day{
+					{Date: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), Aqi: 65, Pm25: 30, Pm10: 20, Ozone: 60, UvIndex: 6.4, Pollen: []Pollen{{Name: "Birch", Value: 120}, {Name: "Grass", Value: 3}}},
+					{Date: time.Date(2025, 4, 11, 0, 0, 0, 0, time.UTC), Aqi: 30, Pm25: 8, Pm10: 15, Ozone: 140, UvIndex: 3},
+				},
+				thresholds: DefaultThresholds(),
+			},
+			want: []string{
+				"😷 Today: AQI 65 (poor), PM2.5 30 µg/m³",
+				"🤧 Today: High pollen count (Birch 120)",
+				"🕶️ Today: UV index 6.4",
+				"😷 Tomorrow: ozone 140 µg/m³",
+			},
+		},
+		{
+			name: "days in the past and far future are ignored",
+			args: args{
+				days: []Day{
+					{Date: time.Date(2025, 4, 9, 0, 0, 0, 0, time.UTC), Aqi: 90},
+					{Date: time.Date(2025, 4, 12, 0, 0, 0, 0, time.UTC), Aqi: 90},
+				},
+				thresholds: DefaultThresholds(),
+			},
+			want: nil,
+		},
+		{
+			name: "custom thresholds",
+			args: args{
+				days: []Day{
+					{Date: time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC), UvIndex: 4, Pollen: []Pollen{{Name: "Grass", Value: 15}}},
+				},
+				thresholds: Thresholds{Aqi: 100, Pm25: 100, Pm10: 100, Ozone: 500, UvIndex: 4, Pollen: 10},
+			},
+			want: []string{
+				"🤧 Today: High pollen count (Grass 15)",
+				"🕶️ Today: UV index 4.0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.args.days, tt.args.thresholds, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseOpenMeteoResponse(t *testing.T) {
+	body := []byte(`{
+		"timezone": "UTC",
+		"hourly": {
+			"time": ["2025-04-10T00:00", "2025-04-10T12:00", "2025-04-11T00:00"],
+			"european_aqi": [20, 45, null],
+			"pm2_5": [5.5, 12.1, 3],
+			"pm10": [10, 22, 8],
+			"ozone": [40, 110, 35],
+			"uv_index": [0, 5.2, 0],
+			"birch_pollen": [12, 80, 4],
+			"grass_pollen": [0, 0, 0]
+		}
+	}`)
+
+	got, err := parseOpenMeteoResponse(body)
+	if err != nil {
+		t.Fatalf("parseOpenMeteoResponse() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(got))
+	}
+
+	first := got[0]
+	if first.Aqi != 45 || first.Pm25 != 12.1 || first.Pm10 != 22 || first.Ozone != 110 || first.UvIndex != 5.2 {
+		t.Errorf("unexpected values for first day: %+v", first)
+	}
+
+	wantPollen := []Pollen{{Name: "Birch", Value: 80}}
+	if !reflect.DeepEqual(first.ActivePollen(), wantPollen) {
+		t.Errorf("ActivePollen() = %v, want %v", first.ActivePollen(), wantPollen)
+	}
+
+	if got[1].Aqi != 0 || got[1].Pm25 != 3 {
+		t.Errorf("unexpected values for second day: %+v", got[1])
+	}
+}
@@ -3,51 +3,57 @@ package alertmanager
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"html/template"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/go-openapi/strfmt"
-	"github.com/prometheus/alertmanager/api/v2/client"
 	"github.com/prometheus/alertmanager/api/v2/client/alert"
+	"github.com/prometheus/alertmanager/api/v2/client/silence"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/rs/zerolog/log"
 	"github.com/soerenschneider/aether/internal"
 	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/multierr"
 	"golang.org/x/exp/maps"
 )
 
 const defaultLimit = 10
 
-type Alert struct {
-	Name     string
-	Severity string
-	Count    int
-}
+var defaultLabels = []string{"instance", "job"}
 
 type AlertmanagerDatasource struct {
-	client   alert.ClientService
-	basePath string
-	scheme   string
+	clients []*alertmanagerClient
 
 	defaultTemplate    *template.Template
 	simpleTemplate     *template.Template
 	limit              int
+	labels             []string
+	filters            []string
+	receiver           string
+	silenceMatchers    []string
 	excludeFromSummary bool
 }
 
 type Opt func(datasource *AlertmanagerDatasource) error
 
-func New(host string, templateData templates.TemplateData, opts ...Opt) (*AlertmanagerDatasource, error) {
-	strf := strfmt.NewFormats()
+func New(endpoints []Endpoint, templateData templates.TemplateData, opts ...Opt) (*AlertmanagerDatasource, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("no endpoints supplied")
+	}
 
 	if err := templateData.Validate(); err != nil {
 		return nil, err
 	}
 
 	ds := &AlertmanagerDatasource{
-		limit:    defaultLimit,
-		scheme:   "http",
-		basePath: "/api/v2",
+		limit:  defaultLimit,
+		labels: defaultLabels,
 	}
 
 	var errs error
@@ -57,27 +63,26 @@ func New(host string, templateData templates.TemplateData, opts ...Opt) (*Alertm
 		}
 	}
 
-	transportConf := client.DefaultTransportConfig().
-		WithHost(host).
-		WithSchemes([]string{ds.scheme}).
-		WithBasePath(ds.basePath)
-
-	apiClient := client.NewHTTPClientWithConfig(
-		strf,
-		transportConf,
-	)
-
-	alertClient := alert.New(apiClient.Transport, strf)
-	ds.client = alertClient
+	for _, endpoint := range endpoints {
+		client, err := buildClient(endpoint)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		ds.clients = append(ds.clients, client)
+	}
 
-	var err error
-	ds.defaultTemplate, err = template.New("alertmanager-default").Parse(string(templateData.DefaultTemplate))
-	if err != nil {
-		return nil, err
+	if ds.defaultTemplate == nil {
+		var err error
+		ds.defaultTemplate, err = template.New("alertmanager-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if len(templateData.SimpleTemplate) > 0 {
-		ds.simpleTemplate, err = template.New("alertmanager-simple").Parse(string(templateData.SimpleTemplate))
+		var err error
+		ds.simpleTemplate, err = template.New("alertmanager-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
 		if err != nil {
 			return nil, err
 		}
@@ -86,36 +91,42 @@ func New(host string, templateData templates.TemplateData, opts ...Opt) (*Alertm
 	return ds, errs
 }
 
+var funcMap = template.FuncMap{
+	"getCssClass": getCssClass,
+	"formatAge":   formatAge,
+}
+
 func (a *AlertmanagerDatasource) Name() string {
 	return "Alertmanager"
 }
 
 func (a *AlertmanagerDatasource) GetData(ctx context.Context) (*internal.Data, error) {
-	alerts, err := a.getActiveAlerts(ctx)
+	data, err := a.getAlertmanagerData(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	sortAlerts(alerts)
-	if len(alerts) > a.limit {
-		alerts = alerts[0:a.limit]
+	sortAlerts(data.Alerts)
+	if len(data.Alerts) > a.limit {
+		data.Alerts = data.Alerts[0:a.limit]
 	}
 
 	var renderedDefaultTemplate bytes.Buffer
-	if err := a.defaultTemplate.Execute(&renderedDefaultTemplate, alerts); err != nil {
+	if err := a.defaultTemplate.Execute(&renderedDefaultTemplate, data); err != nil {
 		return nil, err
 	}
 
 	var renderedSimpleTemplate bytes.Buffer
 	if a.simpleTemplate != nil {
-		if err := a.defaultTemplate.Execute(&renderedSimpleTemplate, alerts); err != nil {
+		if err := a.simpleTemplate.Execute(&renderedSimpleTemplate, data); err != nil {
 			return nil, err
 		}
 	}
 
 	var summary []string
 	if !a.excludeFromSummary {
-		summary = getSummary(alerts, time.Now(), true)
+		summary = getSummary(data.Alerts, time.Now(), true)
+		summary = append(summary, getSuppressedSummary(data)...)
 	}
 
 	ret := &internal.Data{
@@ -144,33 +155,234 @@ func sortAlerts(alerts []*Alert) {
 	})
 }
 
-func (a *AlertmanagerDatasource) getActiveAlerts(ctx context.Context) ([]*Alert, error) {
-	resp, err := a.client.GetAlerts(alert.NewGetAlertsParamsWithContext(ctx))
+// endpointData holds the raw alerts and silences of a single Alertmanager endpoint.
+type endpointData struct {
+	cluster  string
+	alerts   models.GettableAlerts
+	silences models.GettableSilences
+}
+
+func (a *AlertmanagerDatasource) queryEndpoint(ctx context.Context, client *alertmanagerClient) (*endpointData, error) {
+	alertParams := alert.NewGetAlertsParamsWithContext(ctx)
+	alertParams.Filter = a.filters
+	if a.receiver != "" {
+		alertParams.Receiver = &a.receiver
+	}
+
+	alerts, err := client.api.Alert.GetAlerts(alertParams)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not get alerts from %q: %w", client.name, err)
 	}
 
-	if resp.GetPayload() == nil {
-		return nil, nil
+	silenceParams := silence.NewGetSilencesParamsWithContext(ctx)
+	silenceParams.Filter = a.silenceMatchers
+	silences, err := client.api.Silence.GetSilences(silenceParams)
+	if err != nil {
+		return nil, fmt.Errorf("could not get silences from %q: %w", client.name, err)
 	}
 
+	return &endpointData{
+		cluster:  client.name,
+		alerts:   alerts.GetPayload(),
+		silences: silences.GetPayload(),
+	}, nil
+}
+
+func (a *AlertmanagerDatasource) getAlertmanagerData(ctx context.Context) (*AlertmanagerData, error) {
+	p := pool.NewWithResults[*endpointData]().WithContext(ctx).WithMaxGoroutines(4)
+	var errs error
+	var mutex sync.Mutex
+	for _, client := range a.clients {
+		p.Go(func(ctx context.Context) (*endpointData, error) {
+			data, err := a.queryEndpoint(ctx, client)
+			if err != nil {
+				mutex.Lock()
+				errs = multierr.Append(errs, err)
+				mutex.Unlock()
+				return nil, nil
+			}
+			return data, nil
+		})
+	}
+
+	results, _ := p.Wait()
+	results = slices.DeleteFunc(results, func(d *endpointData) bool {
+		return d == nil
+	})
+
+	if len(results) == 0 {
+		return nil, errs
+	}
+
+	if errs != nil {
+		log.Warn().Err(errs).Msg("could not query all alertmanager endpoints")
+	}
+
+	now := time.Now()
+	data := &AlertmanagerData{
+		ShowCluster: countClusters(a.clients) > 1,
+	}
+	data.Alerts, data.SilencedCount, data.InhibitedCount = convertAlerts(results, a.labels, now)
+	data.Silences = convertSilences(results, now)
+
+	return data, nil
+}
+
+func countClusters(clients []*alertmanagerClient) int {
+	clusters := map[string]struct{}{}
+	for _, client := range clients {
+		clusters[client.name] = struct{}{}
+	}
+	return len(clusters)
+}
+
+func convertAlerts(results []*endpointData, labels []string, now time.Time) ([]*Alert, int, int) {
+	var silenced, inhibited int
+
+	// alerts are de-duplicated across the peers of a cluster by their fingerprint
+	seen := map[string]struct{}{}
+
 	alerts := map[string]*Alert{}
-	for _, alert := range resp.GetPayload() {
-		if alert.Status != nil && alert.Status.State != nil && *alert.Status.State == "active" {
-			name := alert.Labels["alertname"]
+	for _, result := range results {
+		for _, alert := range result.alerts {
+			if alert.Status == nil || alert.Status.State == nil {
+				continue
+			}
 
+			if alert.Fingerprint != nil {
+				key := result.cluster + "/" + *alert.Fingerprint
+				if _, found := seen[key]; found {
+					continue
+				}
+				seen[key] = struct{}{}
+			}
+
+			if *alert.Status.State == models.AlertStatusStateSuppressed {
+				if len(alert.Status.SilencedBy) > 0 {
+					silenced++
+				} else if len(alert.Status.InhibitedBy) > 0 {
+					inhibited++
+				}
+				continue
+			}
+
+			if *alert.Status.State != models.AlertStatusStateActive {
+				continue
+			}
+
+			name := alert.Labels["alertname"]
 			a, ok := alerts[name]
 			if !ok {
-				alerts[name] = &Alert{
+				a = &Alert{
 					Name:     name,
 					Severity: alert.Labels["severity"],
-					Count:    1,
 				}
-			} else {
-				a.Count += 1
+				alerts[name] = a
+			}
+			a.Count += 1
+
+			instance := convertAlertInstance(alert, labels, now)
+			instance.Cluster = result.cluster
+			a.Instances = append(a.Instances, instance)
+		}
+	}
+
+	ret := maps.Values(alerts)
+	for _, alert := range ret {
+		sort.Slice(alert.Instances, func(i, j int) bool {
+			return alert.Instances[i].StartsAt.Before(alert.Instances[j].StartsAt)
+		})
+	}
+
+	return ret, silenced, inhibited
+}
+
+func convertAlertInstance(alert *models.GettableAlert, labels []string, now time.Time) AlertInstance {
+	instance := AlertInstance{
+		Summary:      alert.Annotations["summary"],
+		Description:  alert.Annotations["description"],
+		GeneratorURL: alert.GeneratorURL.String(),
+	}
+
+	for _, label := range labels {
+		if value, ok := alert.Labels[label]; ok {
+			instance.Labels = append(instance.Labels, Label{Name: label, Value: value})
+		}
+	}
+
+	if alert.StartsAt != nil {
+		instance.StartsAt = time.Time(*alert.StartsAt)
+		instance.Age = now.Sub(instance.StartsAt)
+	}
+
+	return instance
+}
+
+func convertSilences(results []*endpointData, now time.Time) []Silence {
+	seen := map[string]struct{}{}
+
+	var ret []Silence
+	for _, result := range results {
+		for _, s := range result.silences {
+			if s.Status == nil || s.Status.State == nil || *s.Status.State != models.SilenceStatusStateActive {
+				continue
+			}
+
+			silence := Silence{
+				Cluster:  result.cluster,
+				Matchers: formatMatchers(s.Matchers),
+			}
+			if s.ID != nil {
+				silence.Id = *s.ID
+				key := result.cluster + "/" + silence.Id
+				if _, found := seen[key]; found {
+					continue
+				}
+				seen[key] = struct{}{}
+			}
+			if s.CreatedBy != nil {
+				silence.CreatedBy = *s.CreatedBy
+			}
+			if s.Comment != nil {
+				silence.Comment = *s.Comment
 			}
+			if s.EndsAt != nil {
+				silence.EndsAt = time.Time(*s.EndsAt)
+				silence.ExpiresIn = silence.EndsAt.Sub(now)
+			}
+
+			ret = append(ret, silence)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].EndsAt.Before(ret[j].EndsAt)
+	})
+
+	return ret
+}
+
+func formatMatchers(matchers models.Matchers) string {
+	formatted := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+
+		isEqual := m.IsEqual == nil || *m.IsEqual
+		isRegex := m.IsRegex != nil && *m.IsRegex
+
+		op := "="
+		switch {
+		case isEqual && isRegex:
+			op = "=~"
+		case !isEqual && isRegex:
+			op = "!~"
+		case !isEqual:
+			op = "!="
 		}
+		formatted = append(formatted, fmt.Sprintf("%s%s%q", *m.Name, op, *m.Value))
 	}
 
-	return maps.Values(alerts), nil
+	return strings.Join(formatted, ", ")
 }
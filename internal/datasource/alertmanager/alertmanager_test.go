@@ -0,0 +1,152 @@
+package alertmanager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func buildAlert(state string, labels, annotations map[string]string, startsAt time.Time, silencedBy, inhibitedBy []string) *models.GettableAlert {
+	start := strfmt.DateTime(startsAt)
+	return &models.GettableAlert{
+		Annotations: annotations,
+		StartsAt:    &start,
+		Status: &models.AlertStatus{
+			State:       ptr(state),
+			SilencedBy:  silencedBy,
+			InhibitedBy: inhibitedBy,
+		},
+		Alert: models.Alert{
+			Labels:       labels,
+			GeneratorURL: "http://prometheus/graph",
+		},
+	}
+}
+
+func Test_convertAlerts(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	payload := models.GettableAlerts{
+		buildAlert("active", map[string]string{"alertname": "HostDown", "severity": "critical", "instance": "b", "job": "node"}, map[string]string{"summary": "host b down"}, now.Add(-1*time.Hour), nil, nil),
+		buildAlert("active", map[string]string{"alertname": "HostDown", "severity": "critical", "instance": "a", "job": "node"}, map[string]string{"summary": "host a down", "description": "unreachable"}, now.Add(-2*time.Hour), nil, nil),
+		buildAlert("suppressed", map[string]string{"alertname": "DiskFull", "severity": "warning"}, nil, now, []string{"silence-1"}, nil),
+		buildAlert("suppressed", map[string]string{"alertname": "ServiceDown", "severity": "warning"}, nil, now, nil, []string{"abc"}),
+		buildAlert("unprocessed", map[string]string{"alertname": "Other"}, nil, now, nil, nil),
+	}
+	payload[1].Fingerprint = ptr("fp-a")
+
+	// the second peer of cluster "prod" returns the same alert, which must only be counted once
+	duplicate := buildAlert("active", map[string]string{"alertname": "HostDown", "severity": "critical", "instance": "a", "job": "node"}, map[string]string{"summary": "host a down", "description": "unreachable"}, now.Add(-2*time.Hour), nil, nil)
+	duplicate.Fingerprint = ptr("fp-a")
+
+	results := []*endpointData{
+		{cluster: "prod", alerts: payload},
+		{cluster: "prod", alerts: models.GettableAlerts{duplicate}},
+		{cluster: "staging", alerts: models.GettableAlerts{
+			buildAlert("active", map[string]string{"alertname": "HostDown", "severity": "critical", "instance": "c", "job": "node"}, nil, now.Add(-30*time.Minute), nil, nil),
+		}},
+	}
+
+	alerts, silenced, inhibited := convertAlerts(results, defaultLabels, now)
+	if silenced != 1 || inhibited != 1 {
+		t.Errorf("convertAlerts() silenced = %d, inhibited = %d, want 1, 1", silenced, inhibited)
+	}
+
+	want := []*Alert{
+		{
+			Name:     "HostDown",
+			Severity: "critical",
+			Count:    3,
+			Instances: []AlertInstance{
+				{
+					Cluster:      "prod",
+					Labels:       []Label{{Name: "instance", Value: "a"}, {Name: "job", Value: "node"}},
+					Summary:      "host a down",
+					Description:  "unreachable",
+					StartsAt:     now.Add(-2 * time.Hour),
+					Age:          2 * time.Hour,
+					GeneratorURL: "http://prometheus/graph",
+				},
+				{
+					Cluster:      "prod",
+					Labels:       []Label{{Name: "instance", Value: "b"}, {Name: "job", Value: "node"}},
+					Summary:      "host b down",
+					StartsAt:     now.Add(-1 * time.Hour),
+					Age:          1 * time.Hour,
+					GeneratorURL: "http://prometheus/graph",
+				},
+				{
+					Cluster:      "staging",
+					Labels:       []Label{{Name: "instance", Value: "c"}, {Name: "job", Value: "node"}},
+					StartsAt:     now.Add(-30 * time.Minute),
+					Age:          30 * time.Minute,
+					GeneratorURL: "http://prometheus/graph",
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(alerts, want) {
+		t.Errorf("convertAlerts() = %+v, want %+v", alerts, want)
+	}
+}
+
+func Test_convertSilences(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	endsSoon := strfmt.DateTime(now.Add(1 * time.Hour))
+	endsLater := strfmt.DateTime(now.Add(48 * time.Hour))
+
+	payload := models.GettableSilences{
+		{
+			ID:     ptr("2"),
+			Status: &models.SilenceStatus{State: ptr("active")},
+			Silence: models.Silence{
+				Comment:   ptr("maintenance"),
+				CreatedBy: ptr("jane"),
+				EndsAt:    &endsLater,
+				Matchers: models.Matchers{
+					{Name: ptr("alertname"), Value: ptr("DiskFull"), IsRegex: ptr(false)},
+					{Name: ptr("instance"), Value: ptr("db.*"), IsRegex: ptr(true), IsEqual: ptr(true)},
+				},
+			},
+		},
+		{
+			ID:     ptr("1"),
+			Status: &models.SilenceStatus{State: ptr("active")},
+			Silence: models.Silence{
+				Comment:   ptr("known issue"),
+				CreatedBy: ptr("john"),
+				EndsAt:    &endsSoon,
+				Matchers: models.Matchers{
+					{Name: ptr("job"), Value: ptr("node"), IsRegex: ptr(false), IsEqual: ptr(false)},
+				},
+			},
+		},
+		{
+			ID:     ptr("3"),
+			Status: &models.SilenceStatus{State: ptr("expired")},
+		},
+	}
+
+	// silences are replicated across the peers of a cluster
+	results := []*endpointData{
+		{cluster: "prod", silences: payload},
+		{cluster: "prod", silences: payload[:1]},
+	}
+
+	want := []Silence{
+		{Cluster: "prod", Id: "1", Matchers: `job!="node"`, CreatedBy: "john", Comment: "known issue", EndsAt: now.Add(1 * time.Hour), ExpiresIn: 1 * time.Hour},
+		{Cluster: "prod", Id: "2", Matchers: `alertname="DiskFull", instance=~"db.*"`, CreatedBy: "jane", Comment: "maintenance", EndsAt: now.Add(48 * time.Hour), ExpiresIn: 48 * time.Hour},
+	}
+
+	if got := convertSilences(results, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("convertSilences() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+package alertmanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/client"
+)
+
+const (
+	defaultScheme   = "http"
+	defaultBasePath = "/api/v2"
+)
+
+// Endpoint describes a single Alertmanager instance. Endpoints that share the same name are treated as peers of the
+// same HA cluster and their alerts and silences are de-duplicated.
+type Endpoint struct {
+	Name     string
+	Host     string
+	BasePath string
+	Scheme   string
+
+	Username    string
+	Password    string
+	BearerToken string
+	TlsCaFile   string
+}
+
+func (e *Endpoint) GetName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+	return e.Host
+}
+
+func (e *Endpoint) validate() error {
+	if e.Host == "" {
+		return errors.New("empty host")
+	}
+
+	if e.Scheme != "http" && e.Scheme != "https" {
+		return errors.New("scheme must be either https or http")
+	}
+
+	if e.BearerToken != "" && e.Username != "" {
+		return errors.New("basic auth and bearer token are mutually exclusive")
+	}
+
+	return nil
+}
+
+type alertmanagerClient struct {
+	name string
+	api  *client.AlertmanagerAPI
+}
+
+func buildClient(endpoint Endpoint) (*alertmanagerClient, error) {
+	endpoint.Scheme = strings.ToLower(endpoint.Scheme)
+	if endpoint.Scheme == "" {
+		endpoint.Scheme = defaultScheme
+	}
+	if endpoint.BasePath == "" {
+		endpoint.BasePath = defaultBasePath
+	}
+
+	if err := endpoint.validate(); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint.GetName(), err)
+	}
+
+	schemes := []string{endpoint.Scheme}
+	var transport *httptransport.Runtime
+	if endpoint.TlsCaFile != "" {
+		httpClient, err := httptransport.TLSClient(httptransport.TLSClientOptions{
+			CA: endpoint.TlsCaFile,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not build tls client for endpoint %q: %w", endpoint.GetName(), err)
+		}
+		transport = httptransport.NewWithClient(endpoint.Host, endpoint.BasePath, schemes, httpClient)
+	} else {
+		transport = httptransport.New(endpoint.Host, endpoint.BasePath, schemes)
+	}
+
+	var auth runtime.ClientAuthInfoWriter
+	if endpoint.BearerToken != "" {
+		auth = httptransport.BearerToken(endpoint.BearerToken)
+	} else if endpoint.Username != "" {
+		auth = httptransport.BasicAuth(endpoint.Username, endpoint.Password)
+	}
+	if auth != nil {
+		transport.DefaultAuthentication = auth
+	}
+
+	return &alertmanagerClient{
+		name: endpoint.GetName(),
+		api:  client.New(transport, strfmt.Default),
+	}, nil
+}
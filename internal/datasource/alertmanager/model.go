@@ -0,0 +1,71 @@
+package alertmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+type AlertmanagerData struct {
+	// ShowCluster is true if alerts from more than a single cluster are displayed
+	ShowCluster    bool
+	Alerts         []*Alert
+	SilencedCount  int
+	InhibitedCount int
+	Silences       []Silence
+}
+
+type Alert struct {
+	Name      string
+	Severity  string
+	Count     int
+	Instances []AlertInstance
+}
+
+type AlertInstance struct {
+	Cluster      string
+	Labels       []Label
+	Summary      string
+	Description  string
+	StartsAt     time.Time
+	Age          time.Duration
+	GeneratorURL string
+}
+
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Silence struct {
+	Cluster   string
+	Id        string
+	Matchers  string
+	CreatedBy string
+	Comment   string
+	EndsAt    time.Time
+	ExpiresIn time.Duration
+}
+
+func getCssClass(severity string) string {
+	switch severities[severity] {
+	case 3:
+		return "red"
+	case 2:
+		return "orange"
+	case 1:
+		return "yellow"
+	}
+	return ""
+}
+
+func formatAge(d time.Duration) string {
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return pkg.DurationToString(d)
+}
@@ -2,25 +2,64 @@ package alertmanager
 
 import (
 	"errors"
+	"fmt"
 	"html/template"
 	"os"
-	"strings"
+	"regexp"
 )
 
-func WithBasePath(path string) Opt {
+func WithLimit(limit int) Opt {
 	return func(ds *AlertmanagerDatasource) error {
-		ds.basePath = path
+		if limit < 1 {
+			return errors.New("limit can not be < 1")
+		}
+		ds.limit = limit
+		return nil
+	}
+}
+
+// WithFilters sets the matchers, e.g. `severity="critical"`, that are used to filter the alerts.
+func WithFilters(filters []string) Opt {
+	return func(ds *AlertmanagerDatasource) error {
+		for _, filter := range filters {
+			if filter == "" {
+				return errors.New("empty filter supplied")
+			}
+		}
+		ds.filters = filters
+		return nil
+	}
+}
+
+// WithReceiver sets a regex matching the receivers to filter the alerts by.
+func WithReceiver(receiver string) Opt {
+	return func(ds *AlertmanagerDatasource) error {
+		if _, err := regexp.Compile(receiver); err != nil {
+			return fmt.Errorf("invalid receiver regex: %w", err)
+		}
+		ds.receiver = receiver
+		return nil
+	}
+}
+
+func WithLabels(labels []string) Opt {
+	return func(ds *AlertmanagerDatasource) error {
+		if len(labels) == 0 {
+			return errors.New("no labels supplied")
+		}
+		ds.labels = labels
 		return nil
 	}
 }
 
-func WithScheme(scheme string) Opt {
+func WithSilenceMatchers(matchers []string) Opt {
 	return func(ds *AlertmanagerDatasource) error {
-		scheme = strings.ToLower(scheme)
-		if scheme != "http" && scheme != "https" {
-			return errors.New("scheme must be either https or http")
+		for _, matcher := range matchers {
+			if matcher == "" {
+				return errors.New("empty silence matcher supplied")
+			}
 		}
-		ds.scheme = scheme
+		ds.silenceMatchers = matchers
 		return nil
 	}
 }
@@ -32,7 +71,7 @@ func WithTemplateFile(file string) Opt {
 			return err
 		}
 
-		temp, err := template.New("alertmanager").Parse(string(data))
+		temp, err := template.New("alertmanager").Funcs(funcMap).Parse(string(data))
 		if err != nil {
 			return err
 		}
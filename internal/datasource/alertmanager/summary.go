@@ -45,3 +45,11 @@ func getSummary(alerts []*Alert, _ time.Time, addSummaryForNoEvents bool) []stri
 
 	return []string{fmt.Sprintf("🔥 %d active alerts: %s", totalCnt, strings.Join(summary, ", "))}
 }
+
+func getSuppressedSummary(data *AlertmanagerData) []string {
+	if data.SilencedCount == 0 && data.InhibitedCount == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("🔕 %d silenced, %d inhibited alerts (%d active silences)", data.SilencedCount, data.InhibitedCount, len(data.Silences))}
+}
@@ -2,21 +2,23 @@ package astral
 
 import (
 	"bytes"
-	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"html/template"
+	"math"
 	"time"
 
 	"github.com/sj14/astral/pkg/astral"
 	"github.com/soerenschneider/aether/internal"
 	"github.com/soerenschneider/aether/internal/templates"
 	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
 )
 
 type Astral struct {
 	location           *time.Location
-	observer           astral.Observer
+	observers          []Observer
 	defaultTemplate    *template.Template
 	simpleTemplate     *template.Template
 	excludeFromSummary bool
@@ -25,26 +27,62 @@ type Astral struct {
 type Lat float64
 type Lon float64
 
-func New(lat Lat, lon Lon, templateData templates.TemplateData, location *time.Location) (*Astral, error) {
-	if err := templateData.Validate(); err != nil {
-		return nil, err
+// Observer is a named location for which the astral data is calculated.
+type Observer struct {
+	Name      string
+	Latitude  Lat
+	Longitude Lon
+	// Elevation above sea level in metres. Locations below sea level are treated as being at sea level, as the horizon
+	// is only corrected for observers above it.
+	Elevation float64
+	// Location is the timezone the times are shown in, defaults to the timezone of the datasource
+	Location *time.Location
+}
+
+func (o Observer) toAstralObserver() astral.Observer {
+	return astral.Observer{
+		Latitude:  float64(o.Latitude),
+		Longitude: float64(o.Longitude),
+		Elevation: math.Max(o.Elevation, 0),
 	}
+}
 
-	ds := &Astral{
-		observer: astral.Observer{
-			Latitude:  float64(lat),
-			Longitude: float64(lon),
-		},
-		location: cmp.Or(location, time.UTC),
+// in returns the time in the observer's timezone, if it has one.
+func (o Observer) in(t time.Time) time.Time {
+	if o.Location == nil {
+		return t
+	}
+	return t.In(o.Location)
+}
+
+type Opt func(datasource *Astral) error
+
+func convertDegrees(deg float64) string {
+	dir, emoji := pkg.TranslateDegreeToDirection(deg)
+	return fmt.Sprintf("(%s %s)", dir, emoji)
+}
+
+var funcMap = template.FuncMap{
+	"degreesToCompass": convertDegrees,
+	"formatDuration":   formatDuration,
+	"formatDelta":      formatDelta,
+	"percent": func(f float64) string {
+		return fmt.Sprintf("%.0f%%", f*100)
+	},
+}
+
+func New(observers []Observer, templateData templates.TemplateData, opts ...Opt) (*Astral, error) {
+	if len(observers) == 0 {
+		return nil, errors.New("no observers supplied")
 	}
 
-	convertDegrees := func(deg float64) string {
-		dir, emoji := pkg.TranslateDegreeToDirection(deg)
-		return fmt.Sprintf("(%s %s)", dir, emoji)
+	if err := templateData.Validate(); err != nil {
+		return nil, err
 	}
 
-	funcMap := template.FuncMap{
-		"degreesToCompass": convertDegrees,
+	ds := &Astral{
+		observers: observers,
+		location:  time.UTC,
 	}
 
 	var err error
@@ -60,13 +98,32 @@ func New(lat Lat, lon Lon, templateData templates.TemplateData, location *time.L
 		}
 	}
 
-	return ds, nil
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
 }
 
 func (b *Astral) GetData(_ context.Context) (*internal.Data, error) {
-	data, err := b.get(time.Now().In(b.location))
-	if err != nil {
-		return nil, err
+	now := time.Now().In(b.location)
+
+	data := AstralTemplateData{
+		Locations: make([]LocationData, 0, len(b.observers)),
+	}
+	for _, observer := range b.observers {
+		astralData, err := b.get(observer.toAstralObserver(), observer.in(now))
+		if err != nil {
+			return nil, fmt.Errorf("could not calculate astral data for %q: %w", observer.Name, err)
+		}
+
+		data.Locations = append(data.Locations, LocationData{
+			Name:       observer.Name,
+			AstralData: *astralData,
+		})
 	}
 
 	var renderedDefaultTemplate bytes.Buffer
@@ -76,14 +133,17 @@ func (b *Astral) GetData(_ context.Context) (*internal.Data, error) {
 
 	var renderedSimpleTemplate bytes.Buffer
 	if b.simpleTemplate != nil {
-		if err := b.defaultTemplate.Execute(&renderedDefaultTemplate, data); err != nil {
+		if err := b.simpleTemplate.Execute(&renderedSimpleTemplate, data); err != nil {
 			return nil, err
 		}
 	}
 
 	var summary []string
 	if !b.excludeFromSummary {
-		summary = getSummary(*data, time.Now())
+		summary = getSummary(data.Locations[0].AstralData, b.observers[0].in(now))
+		for _, location := range data.Locations[1:] {
+			summary = append(summary, getLocationSummary(location))
+		}
 	}
 
 	return &internal.Data{
@@ -93,47 +153,65 @@ func (b *Astral) GetData(_ context.Context) (*internal.Data, error) {
 	}, nil
 }
 
-func (b *Astral) get(date time.Time) (*AstralData, error) {
+func (b *Astral) get(observer astral.Observer, date time.Time) (*AstralData, error) {
 	ret := &AstralData{}
 
 	var err error
-	ret.Sunrise, err = astral.Sunrise(b.observer, date)
+	ret.Sunrise, err = astral.Sunrise(observer, date)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.Sunset, err = astral.Sunset(b.observer, date)
+	ret.Sunset, err = astral.Sunset(observer, date)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.AzimuthSunrise = astral.Azimuth(b.observer, ret.Sunrise)
-	ret.AzimuthSunset = astral.Azimuth(b.observer, ret.Sunset)
+	ret.AzimuthSunrise = astral.Azimuth(observer, ret.Sunrise)
+	ret.AzimuthSunset = astral.Azimuth(observer, ret.Sunset)
 
-	ret.BlueHourRising.Start, ret.BlueHourRising.End, err = astral.BlueHour(b.observer, date, astral.SunDirectionRising)
+	ret.BlueHourRising.Start, ret.BlueHourRising.End, err = astral.BlueHour(observer, date, astral.SunDirectionRising)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.BlueHourSetting.Start, ret.BlueHourSetting.End, err = astral.BlueHour(b.observer, date, astral.SunDirectionSetting)
+	ret.BlueHourSetting.Start, ret.BlueHourSetting.End, err = astral.BlueHour(observer, date, astral.SunDirectionSetting)
 	if err != nil {
 		return nil, err
 	}
-	ret.AzimuthBlueHourRising = astral.Azimuth(b.observer, ret.BlueHourRising.Start)
-	ret.AzimuthBlueHourSetting = astral.Azimuth(b.observer, ret.BlueHourSetting.End)
+	ret.AzimuthBlueHourRising = astral.Azimuth(observer, ret.BlueHourRising.Start)
+	ret.AzimuthBlueHourSetting = astral.Azimuth(observer, ret.BlueHourSetting.End)
 
-	ret.GoldenHourRising.Start, ret.GoldenHourRising.End, err = astral.GoldenHour(b.observer, date, astral.SunDirectionRising)
+	ret.GoldenHourRising.Start, ret.GoldenHourRising.End, err = astral.GoldenHour(observer, date, astral.SunDirectionRising)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.GoldenHourSetting.Start, ret.GoldenHourSetting.End, err = astral.GoldenHour(b.observer, date, astral.SunDirectionSetting)
+	ret.GoldenHourSetting.Start, ret.GoldenHourSetting.End, err = astral.GoldenHour(observer, date, astral.SunDirectionSetting)
 	if err != nil {
 		return nil, err
 	}
 
-	ret.AzimuthGoldenHourRising = astral.Azimuth(b.observer, ret.GoldenHourRising.Start)
-	ret.AzimuthGoldenHourSetting = astral.Azimuth(b.observer, ret.GoldenHourSetting.End)
+	ret.AzimuthGoldenHourRising = astral.Azimuth(observer, ret.GoldenHourRising.Start)
+	ret.AzimuthGoldenHourSetting = astral.Azimuth(observer, ret.GoldenHourSetting.End)
+
+	ret.DayLength = ret.Sunset.Sub(ret.Sunrise)
+	yesterday := date.AddDate(0, 0, -1)
+	sunriseYesterday, errRise := astral.Sunrise(observer, yesterday)
+	sunsetYesterday, errSet := astral.Sunset(observer, yesterday)
+	if errRise == nil && errSet == nil {
+		ret.DayLengthDelta = ret.DayLength - sunsetYesterday.Sub(sunriseYesterday)
+	}
+
+	illumination := getMoonIllumination(date)
+	phase := getMoonPhase(illumination.Phase)
+	ret.MoonPhase = illumination.Phase
+	ret.MoonIllumination = illumination.Fraction
+	ret.MoonPhaseName = phase.name
+	ret.MoonPhaseEmoji = phase.emoji
+	ret.Moonrise, ret.Moonset = getMoonTimes(date, observer.Latitude, observer.Longitude)
+
+	ret.NextSeason = getNextSeason(pkg.Today(date))
 
 	return ret, nil
 }
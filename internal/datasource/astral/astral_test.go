@@ -0,0 +1,86 @@
+package astral
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sj14/astral/pkg/astral"
+	"github.com/soerenschneider/aether/internal/templates"
+)
+
+func TestAstral_GetData(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	sydney, _ := time.LoadLocation("Australia/Sydney")
+
+	observers := []Observer{
+		{Name: "Home", Latitude: 52.52, Longitude: 13.40, Elevation: 34},
+		{Name: "Sydney", Latitude: -33.87, Longitude: 151.21, Location: sydney},
+		{Name: "Dead Sea", Latitude: 31.56, Longitude: 35.47, Elevation: -430},
+	}
+
+	templateData := templates.TemplateData{
+		DefaultTemplate: []byte(`{{ range .Locations }}{{ .Name }}|{{ .Sunrise.Location }}|{{ .Sunrise.Hour }}|{{ .Sunset.After .Sunrise }};{{ end }}`),
+	}
+
+	ds, err := New(observers, templateData, WithLocation(berlin))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	data, err := ds.GetData(context.Background())
+	if err != nil {
+		t.Fatalf("GetData() error = %v", err)
+	}
+
+	locations := strings.Split(strings.TrimSuffix(string(data.RenderedDefaultTemplate), ";"), ";")
+	wantZones := []string{"Europe/Berlin", "Australia/Sydney", "Europe/Berlin"}
+	if len(locations) != len(wantZones) {
+		t.Fatalf("GetData() rendered %d locations, want %d", len(locations), len(wantZones))
+	}
+
+	for idx, location := range locations {
+		fields := strings.Split(location, "|")
+		if fields[0] != observers[idx].Name || fields[1] != wantZones[idx] || fields[3] != "true" {
+			t.Errorf("GetData() rendered %q for %q, want sunrise before sunset in %s", location, observers[idx].Name, wantZones[idx])
+		}
+		// sunrise in the local timezone of the location is always in the morning
+		if hour, _ := strconv.Atoi(fields[2]); hour < 3 || hour > 9 {
+			t.Errorf("GetData() sunrise hour %d for %q not in the morning", hour, observers[idx].Name)
+		}
+	}
+
+	var locationSummaries []string
+	for _, line := range data.Summary {
+		if strings.HasPrefix(line, "☀️Sydney: ") || strings.HasPrefix(line, "☀️Dead Sea: ") {
+			locationSummaries = append(locationSummaries, line)
+		}
+	}
+	if len(locationSummaries) != 2 || !strings.HasPrefix(data.Summary[len(data.Summary)-2], "☀️Sydney: ") {
+		t.Errorf("GetData() summary = %v, want a summary line per additional location", data.Summary)
+	}
+}
+
+func TestObserver_elevation(t *testing.T) {
+	date := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	sunrise := func(elevation float64) time.Time {
+		observer := Observer{Latitude: 31.56, Longitude: 35.47, Elevation: elevation}
+		rise, err := astral.Sunrise(observer.toAstralObserver(), date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rise
+	}
+
+	seaLevel := sunrise(0)
+	// the horizon of an elevated observer is lower, the sun rises earlier
+	if elevated := sunrise(1000); seaLevel.Sub(elevated) < 2*time.Minute {
+		t.Errorf("sunrise at 1000m = %v, expected it to be earlier than at sea level %v", elevated, seaLevel)
+	}
+	// locations below sea level are treated as being at sea level
+	if below := sunrise(-430); !below.Equal(seaLevel) {
+		t.Errorf("sunrise at -430m = %v, want %v", below, seaLevel)
+	}
+}
@@ -2,6 +2,15 @@ package astral
 
 import "time"
 
+type AstralTemplateData struct {
+	Locations []LocationData
+}
+
+type LocationData struct {
+	Name string
+	AstralData
+}
+
 type AstralData struct {
 	BlueHourRising         TimeDuration
 	BlueHourSetting        TimeDuration
@@ -17,6 +26,18 @@ type AstralData struct {
 	Sunset         time.Time
 	AzimuthSunrise float64
 	AzimuthSunset  float64
+
+	DayLength      time.Duration
+	DayLengthDelta time.Duration
+
+	MoonPhase        float64
+	MoonPhaseName    string
+	MoonPhaseEmoji   string
+	MoonIllumination float64
+	Moonrise         time.Time
+	Moonset          time.Time
+
+	NextSeason Season
 }
 
 type TimeDuration struct {
@@ -0,0 +1,428 @@
+package astral
+
+import (
+	"math"
+	"time"
+)
+
+// The positions of sun and moon are calculated following Jean Meeus, Astronomical Algorithms (2nd edition), chapters
+// 22, 25 and 47. The moon's position is accurate to about 10 arcseconds, which puts the times of the phases within a
+// minute or two and moonrise and moonset within a few minutes of the published ephemerides.
+
+const (
+	rad          = math.Pi / 180
+	julian1970   = 2440588
+	julian2000   = 2451545
+	millisPerDay = 1000 * 60 * 60 * 24
+	// earthRadius is the equatorial radius of the earth in km
+	earthRadius = 6378.14
+	// auKm is the astronomical unit in km
+	auKm = 149597870.7
+)
+
+type MoonIllumination struct {
+	// Fraction is the illuminated fraction of the moon, ranging from 0 (new moon) to 1 (full moon).
+	Fraction float64
+	// Phase ranges from 0 to 1: 0 is new moon, 0.25 first quarter, 0.5 full moon and 0.75 last quarter.
+	Phase float64
+}
+
+type moonPhase struct {
+	name  string
+	emoji string
+}
+
+var moonPhases = []moonPhase{
+	{"New Moon", "🌑"},
+	{"Waxing Crescent", "🌒"},
+	{"First Quarter", "🌓"},
+	{"Waxing Gibbous", "🌔"},
+	{"Full Moon", "🌕"},
+	{"Waning Gibbous", "🌖"},
+	{"Last Quarter", "🌗"},
+	{"Waning Crescent", "🌘"},
+}
+
+func getMoonPhase(phase float64) moonPhase {
+	index := int(math.Floor(phase*8+0.5)) % len(moonPhases)
+	return moonPhases[index]
+}
+
+// julianDay returns the julian day of the given time in universal time.
+func julianDay(t time.Time) float64 {
+	return float64(t.UnixMilli())/millisPerDay - 0.5 + julian1970
+}
+
+// julianCenturies returns the julian centuries since J2000.0 in terrestrial time.
+func julianCenturies(t time.Time) float64 {
+	return (julianDay(t) + estimateDeltaT(t.Year()).Seconds()/86400 - julian2000) / 36525
+}
+
+func normalizeDegrees(deg float64) float64 {
+	deg = math.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+func sin(deg float64) float64 {
+	return math.Sin(deg * rad)
+}
+
+func cos(deg float64) float64 {
+	return math.Cos(deg * rad)
+}
+
+// nutation returns the nutation in longitude and the true obliquity of the ecliptic in degrees (Meeus chapter 22).
+func nutation(t float64) (float64, float64) {
+	omega := 125.04452 - 1934.136261*t
+	sunLon := 280.4665 + 36000.7698*t
+	moonLon := 218.3165 + 481267.8813*t
+
+	deltaPsi := (-17.20*sin(omega) - 1.32*sin(2*sunLon) - 0.23*sin(2*moonLon) + 0.21*sin(2*omega)) / 3600
+	deltaEps := (9.20*cos(omega) + 0.57*cos(2*sunLon) + 0.10*cos(2*moonLon) - 0.09*cos(2*omega)) / 3600
+	eps0 := 23.4392911111 - 0.0130041667*t - 1.6389e-7*t*t + 5.0361e-7*t*t*t
+
+	return deltaPsi, eps0 + deltaEps
+}
+
+type eclipticCoords struct {
+	// lon and lat are the apparent geocentric ecliptic longitude and latitude in degrees
+	lon float64
+	lat float64
+	// dist is the distance from the center of the earth in km
+	dist float64
+}
+
+// sunPosition returns the apparent position of the sun (Meeus chapter 25).
+func sunPosition(t float64) eclipticCoords {
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := 357.52911 + 35999.05029*t - 0.0001537*t*t
+	e := 0.016708634 - 0.000042037*t - 0.0000001267*t*t
+	c := (1.914602-0.004817*t-0.000014*t*t)*sin(m) + (0.019993-0.000101*t)*sin(2*m) + 0.000289*sin(3*m)
+
+	trueLon := l0 + c
+	anomaly := m + c
+	dist := 1.000001018 * (1 - e*e) / (1 + e*cos(anomaly))
+
+	omega := 125.04 - 1934.136*t
+	return eclipticCoords{
+		lon:  normalizeDegrees(trueLon - 0.00569 - 0.00478*sin(omega)),
+		dist: dist * auKm,
+	}
+}
+
+// moonTerm is a periodic term of the moon's longitude and distance or latitude, given by the multiples of the
+// arguments D, M, M' and F.
+type moonTerm struct {
+	d, m, mp, f int
+	l, r        float64
+}
+
+// moonLonDist are the periodic terms for the longitude (in 1e-6 degrees) and distance (in 1e-3 km), Meeus table 47.A.
+var moonLonDist = []moonTerm{
+	{0, 0, 1, 0, 6288774, -20905355},
+	{2, 0, -1, 0, 1274027, -3699111},
+	{2, 0, 0, 0, 658314, -2955968},
+	{0, 0, 2, 0, 213618, -569925},
+	{0, 1, 0, 0, -185116, 48888},
+	{0, 0, 0, 2, -114332, -3149},
+	{2, 0, -2, 0, 58793, 246158},
+	{2, -1, -1, 0, 57066, -152138},
+	{2, 0, 1, 0, 53322, -170733},
+	{2, -1, 0, 0, 45758, -204586},
+	{0, 1, -1, 0, -40923, -129620},
+	{1, 0, 0, 0, -34720, 108743},
+	{0, 1, 1, 0, -30383, 104755},
+	{2, 0, 0, -2, 15327, 10321},
+	{0, 0, 1, 2, -12528, 0},
+	{0, 0, 1, -2, 10980, 79661},
+	{4, 0, -1, 0, 10675, -34782},
+	{0, 0, 3, 0, 10034, -23210},
+	{4, 0, -2, 0, 8548, -21636},
+	{2, 1, -1, 0, -7888, 24208},
+	{2, 1, 0, 0, -6766, 30824},
+	{1, 0, -1, 0, -5163, -8379},
+	{1, 1, 0, 0, 4987, -16675},
+	{2, -1, 1, 0, 4036, -12831},
+	{2, 0, 2, 0, 3994, -10445},
+	{4, 0, 0, 0, 3861, -11650},
+	{2, 0, -3, 0, 3665, 14403},
+	{0, 1, -2, 0, -2689, -7003},
+	{2, 0, -1, 2, -2602, 0},
+	{2, -1, -2, 0, 2390, 10056},
+	{1, 0, 1, 0, -2348, 6322},
+	{2, -2, 0, 0, 2236, -9884},
+	{0, 1, 2, 0, -2120, 5751},
+	{0, 2, 0, 0, -2069, 0},
+	{2, -2, -1, 0, 2048, -4950},
+	{2, 0, 1, -2, -1773, 4130},
+	{2, 0, 0, 2, -1595, 0},
+	{4, -1, -1, 0, 1215, -3958},
+	{0, 0, 2, 2, -1110, 0},
+	{3, 0, -1, 0, -892, 3258},
+	{2, 1, 1, 0, -810, 2616},
+	{4, -1, -2, 0, 759, -1897},
+	{0, 2, -1, 0, -713, -2117},
+	{2, 2, -1, 0, -700, 2354},
+	{2, 1, -2, 0, 691, 0},
+	{2, -1, 0, -2, 596, 0},
+	{4, 0, 1, 0, 549, -1423},
+	{0, 0, 4, 0, 537, -1117},
+	{4, -1, 0, 0, 520, -1571},
+	{1, 0, -2, 0, -487, -1739},
+	{2, 1, 0, -2, -399, 0},
+	{0, 0, 2, -2, -381, -4421},
+	{1, 1, 1, 0, 351, 0},
+	{3, 0, -2, 0, -340, 0},
+	{4, 0, -3, 0, 330, 0},
+	{2, -1, 2, 0, 327, 0},
+	{0, 2, 1, 0, -323, 1165},
+	{1, 1, -1, 0, 299, 0},
+	{2, 0, 3, 0, 294, 0},
+	{2, 0, -1, -2, 0, 8752},
+}
+
+// moonLat are the periodic terms for the latitude (in 1e-6 degrees), Meeus table 47.B.
+var moonLat = []moonTerm{
+	{0, 0, 0, 1, 5128122, 0},
+	{0, 0, 1, 1, 280602, 0},
+	{0, 0, 1, -1, 277693, 0},
+	{2, 0, 0, -1, 173237, 0},
+	{2, 0, -1, 1, 55413, 0},
+	{2, 0, -1, -1, 46271, 0},
+	{2, 0, 0, 1, 32573, 0},
+	{0, 0, 2, 1, 17198, 0},
+	{2, 0, 1, -1, 9266, 0},
+	{0, 0, 2, -1, 8822, 0},
+	{2, -1, 0, -1, 8216, 0},
+	{2, 0, -2, -1, 4324, 0},
+	{2, 0, 1, 1, 4200, 0},
+	{2, 1, 0, -1, -3359, 0},
+	{2, -1, -1, 1, 2463, 0},
+	{2, -1, 0, 1, 2211, 0},
+	{2, -1, -1, -1, 2065, 0},
+	{0, 1, -1, -1, -1870, 0},
+	{4, 0, -1, -1, 1828, 0},
+	{0, 1, 0, 1, -1794, 0},
+	{0, 0, 0, 3, -1749, 0},
+	{0, 1, -1, 1, -1565, 0},
+	{1, 0, 0, 1, -1491, 0},
+	{0, 1, 1, 1, -1475, 0},
+	{0, 1, 1, -1, -1410, 0},
+	{0, 1, 0, -1, -1344, 0},
+	{1, 0, 0, -1, -1335, 0},
+	{0, 0, 3, 1, 1107, 0},
+	{4, 0, 0, -1, 1021, 0},
+	{4, 0, -1, 1, 833, 0},
+	{0, 0, 1, -3, 777, 0},
+	{4, 0, -2, 1, 671, 0},
+	{2, 0, 0, -3, 607, 0},
+	{2, 0, 2, -1, 596, 0},
+	{2, -1, 1, -1, 491, 0},
+	{2, 0, -2, 1, -451, 0},
+	{0, 0, 3, -1, 439, 0},
+	{2, 0, 2, 1, 422, 0},
+	{2, 0, -3, -1, 421, 0},
+	{2, 1, -1, 1, -366, 0},
+	{2, 1, 0, 1, -351, 0},
+	{4, 0, 0, 1, 331, 0},
+	{2, -1, 1, 1, 315, 0},
+	{2, -2, 0, -1, 302, 0},
+	{0, 0, 1, 3, -283, 0},
+	{2, 1, 1, -1, -229, 0},
+	{1, 1, 0, -1, 223, 0},
+	{1, 1, 0, 1, 223, 0},
+	{0, 1, -2, -1, -220, 0},
+	{2, 1, -1, -1, -220, 0},
+	{1, 0, 1, 1, -185, 0},
+	{2, -1, -2, -1, 181, 0},
+	{0, 1, 2, 1, -177, 0},
+	{4, 0, -2, -1, 176, 0},
+	{4, -1, -1, -1, 166, 0},
+	{1, 0, 1, -1, -164, 0},
+	{4, 0, 1, -1, 132, 0},
+	{1, 0, -1, -1, -119, 0},
+	{4, -1, 0, -1, 115, 0},
+	{2, -2, 0, 1, 107, 0},
+}
+
+// moonGeometricPosition returns the geocentric position of the moon referred to the mean equinox of the date, without
+// nutation (Meeus chapter 47).
+func moonGeometricPosition(t float64) eclipticCoords {
+	lp := 218.3164477 + 481267.88123421*t - 0.0015786*t*t + t*t*t/538841 - t*t*t*t/65194000
+	d := 297.8501921 + 445267.1114034*t - 0.0018819*t*t + t*t*t/545868 - t*t*t*t/113065000
+	m := 357.5291092 + 35999.0502909*t - 0.0001536*t*t + t*t*t/24490000
+	mp := 134.9633964 + 477198.8675055*t + 0.0087414*t*t + t*t*t/69699 - t*t*t*t/14712000
+	f := 93.2720950 + 483202.0175233*t - 0.0036539*t*t - t*t*t/3526000 + t*t*t*t/863310000
+	a1 := 119.75 + 131.849*t
+	a2 := 53.09 + 479264.290*t
+	a3 := 313.45 + 481266.484*t
+	e := 1 - 0.002516*t - 0.0000074*t*t
+
+	// terms containing the sun's anomaly M decrease with the eccentricity of the earth's orbit
+	eccentricity := func(term moonTerm) float64 {
+		switch term.m {
+		case 1, -1:
+			return e
+		case 2, -2:
+			return e * e
+		default:
+			return 1
+		}
+	}
+
+	var sumL, sumR, sumB float64
+	for _, term := range moonLonDist {
+		arg := float64(term.d)*d + float64(term.m)*m + float64(term.mp)*mp + float64(term.f)*f
+		sumL += eccentricity(term) * term.l * sin(arg)
+		sumR += eccentricity(term) * term.r * cos(arg)
+	}
+	for _, term := range moonLat {
+		arg := float64(term.d)*d + float64(term.m)*m + float64(term.mp)*mp + float64(term.f)*f
+		sumB += eccentricity(term) * term.l * sin(arg)
+	}
+
+	sumL += 3958*sin(a1) + 1962*sin(lp-f) + 318*sin(a2)
+	sumB += -2235*sin(lp) + 382*sin(a3) + 175*sin(a1-f) + 175*sin(a1+f) + 127*sin(lp-mp) - 115*sin(lp+mp)
+
+	return eclipticCoords{
+		lon:  normalizeDegrees(lp + sumL/1e6),
+		lat:  sumB / 1e6,
+		dist: 385000.56 + sumR/1000,
+	}
+}
+
+// moonPosition returns the apparent geocentric position of the moon.
+func moonPosition(t float64) eclipticCoords {
+	pos := moonGeometricPosition(t)
+	deltaPsi, _ := nutation(t)
+	pos.lon = normalizeDegrees(pos.lon + deltaPsi)
+	return pos
+}
+
+// toEquatorial returns the right ascension and declination in degrees.
+func toEquatorial(pos eclipticCoords, obliquity float64) (float64, float64) {
+	ra := math.Atan2(sin(pos.lon)*cos(obliquity)-math.Tan(pos.lat*rad)*sin(obliquity), cos(pos.lon)) / rad
+	dec := math.Asin(sin(pos.lat)*cos(obliquity)+cos(pos.lat)*sin(obliquity)*sin(pos.lon)) / rad
+	return ra, dec
+}
+
+// siderealTime returns the greenwich mean sidereal time in degrees (Meeus chapter 12).
+func siderealTime(t time.Time) float64 {
+	jd := julianDay(t)
+	c := (jd - julian2000) / 36525
+	return normalizeDegrees(280.46061837 + 360.98564736629*(jd-julian2000) + 0.000387933*c*c - c*c*c/38710000)
+}
+
+// moonAltitude returns the geocentric altitude of the moon's center above the horizon minus the altitude at which
+// the moon rises and sets, both in degrees. The latter accounts for the moon's parallax, its semi-diameter and the
+// atmospheric refraction at the horizon, as used by the published ephemerides (Meeus chapter 15).
+func moonAltitude(t time.Time, lat, lon float64) float64 {
+	c := julianCenturies(t)
+	pos := moonPosition(c)
+	_, obliquity := nutation(c)
+	ra, dec := toEquatorial(pos, obliquity)
+
+	hourAngle := siderealTime(t) + lon - ra
+	alt := math.Asin(sin(lat)*sin(dec)+cos(lat)*cos(dec)*cos(hourAngle)) / rad
+
+	parallax := math.Asin(earthRadius/pos.dist) / rad
+	return alt - (0.7275*parallax - 0.5667)
+}
+
+// getMoonIllumination returns the illuminated fraction and the phase of the moon. The phase is derived from the
+// difference of the apparent longitudes of moon and sun, which is how the instants of the phases are defined.
+func getMoonIllumination(t time.Time) MoonIllumination {
+	c := julianCenturies(t)
+	sun := sunPosition(c)
+	moon := moonPosition(c)
+
+	elongation := normalizeDegrees(moon.lon - sun.lon)
+	psi := math.Acos(cos(moon.lat) * cos(elongation))
+	inc := math.Atan2(sun.dist*math.Sin(psi), moon.dist-sun.dist*math.Cos(psi))
+
+	return MoonIllumination{
+		Fraction: (1 + math.Cos(inc)) / 2,
+		Phase:    elongation / 360,
+	}
+}
+
+// getMoonTimes returns the moonrise and moonset for the day of the given date in the date's location. If the moon
+// does not rise or set on that day, the respective value is the zero time.
+func getMoonTimes(date time.Time, lat, lon float64) (rise time.Time, set time.Time) {
+	return getRiseSet(date, func(t time.Time) float64 {
+		return moonAltitude(t, lat, lon)
+	})
+}
+
+// getRiseSet returns the times on the day of the given date at which the altitude, relative to the altitude of rising
+// and setting, turns positive and negative. Parabolas are fitted through the altitudes sampled every hour.
+func getRiseSet(date time.Time, altitude func(time.Time) float64) (rise time.Time, set time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	hoursLater := func(h float64) time.Time {
+		return start.Add(time.Duration(h * float64(time.Hour)))
+	}
+
+	var riseHour, setHour float64
+	h0 := altitude(start)
+	for i := 1.; i <= 24; i += 2 {
+		h1 := altitude(hoursLater(i))
+		h2 := altitude(hoursLater(i + 1))
+
+		// fit a parabola through the three points and find its roots
+		a := (h0+h2)/2 - h1
+		b := (h2 - h0) / 2
+		xe := -b / (2 * a)
+		ye := (a*xe+b)*xe + h1
+		d := b*b - 4*a*h1
+
+		roots := 0
+		var x1, x2 float64
+		if d >= 0 {
+			dx := math.Sqrt(d) / (math.Abs(a) * 2)
+			x1 = xe - dx
+			x2 = xe + dx
+			if math.Abs(x1) <= 1 {
+				roots++
+			}
+			if math.Abs(x2) <= 1 {
+				roots++
+			}
+			if x1 < -1 {
+				x1 = x2
+			}
+		}
+
+		if roots == 1 {
+			if h0 < 0 {
+				riseHour = i + x1
+			} else {
+				setHour = i + x1
+			}
+		} else if roots == 2 {
+			if ye < 0 {
+				riseHour = i + x2
+				setHour = i + x1
+			} else {
+				riseHour = i + x1
+				setHour = i + x2
+			}
+		}
+
+		if riseHour != 0 && setHour != 0 {
+			break
+		}
+
+		h0 = h2
+	}
+
+	if riseHour != 0 {
+		rise = hoursLater(riseHour)
+	}
+	if setHour != 0 {
+		set = hoursLater(setHour)
+	}
+	return rise, set
+}
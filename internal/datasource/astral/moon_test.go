@@ -0,0 +1,204 @@
+package astral
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func Test_getMoonIllumination(t *testing.T) {
+	tests := []struct {
+		name         string
+		date         time.Time
+		wantFraction float64
+		wantPhase    string
+	}{
+		{
+			name:         "full moon 2025-03-14",
+			date:         time.Date(2025, 3, 14, 6, 55, 0, 0, time.UTC),
+			wantFraction: 1,
+			wantPhase:    "Full Moon",
+		},
+		{
+			name:         "new moon 2025-03-29",
+			date:         time.Date(2025, 3, 29, 10, 58, 0, 0, time.UTC),
+			wantFraction: 0,
+			wantPhase:    "New Moon",
+		},
+		{
+			name:         "first quarter 2025-03-06",
+			date:         time.Date(2025, 3, 6, 16, 32, 0, 0, time.UTC),
+			wantFraction: 0.5,
+			wantPhase:    "First Quarter",
+		},
+		{
+			name:         "last quarter 2025-03-22",
+			date:         time.Date(2025, 3, 22, 11, 29, 0, 0, time.UTC),
+			wantFraction: 0.5,
+			wantPhase:    "Last Quarter",
+		},
+		{
+			name:         "waxing crescent 2025-03-02",
+			date:         time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC),
+			wantFraction: 0.08,
+			wantPhase:    "Waxing Crescent",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getMoonIllumination(tt.date)
+			if math.Abs(got.Fraction-tt.wantFraction) > 0.02 {
+				t.Errorf("getMoonIllumination().Fraction = %v, want %v", got.Fraction, tt.wantFraction)
+			}
+			if phase := getMoonPhase(got.Phase); phase.name != tt.wantPhase {
+				t.Errorf("getMoonPhase() = %v, want %v", phase.name, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func Test_moonGeometricPosition(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a: 1992 April 12, 0h TD
+	got := moonGeometricPosition(-0.077221081451)
+	if math.Abs(got.lon-133.162655) > 0.00001 || math.Abs(got.lat-(-3.229126)) > 0.00001 || math.Abs(got.dist-368409.7) > 0.1 {
+		t.Errorf("moonGeometricPosition() = %+v, want lon 133.162655, lat -3.229126, dist 368409.7", got)
+	}
+
+	deltaPsi, obliquity := nutation(-0.077221081451)
+	ra, dec := toEquatorial(moonPosition(-0.077221081451), obliquity)
+	if math.Abs(deltaPsi-0.004610) > 0.0001 || math.Abs(ra-134.688470) > 0.0001 || math.Abs(dec-13.768368) > 0.0001 {
+		t.Errorf("apparent position = %v, %v (nutation %v), want 134.688470, 13.768368 (nutation 0.004610)", ra, dec, deltaPsi)
+	}
+}
+
+// findPhase returns the time the phase of the moon reaches the given value within a day around the given time.
+func findPhase(around time.Time, phase float64) time.Time {
+	diff := func(t time.Time) float64 {
+		return math.Remainder(getMoonIllumination(t).Phase-phase, 1)
+	}
+
+	lo, hi := around.Add(-12*time.Hour), around.Add(12*time.Hour)
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if diff(mid) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
+
+func Test_getMoonIllumination_phaseTimes(t *testing.T) {
+	// the times of the phases as published by the US Naval Observatory
+	tests := []struct {
+		name  string
+		phase float64
+		want  time.Time
+	}{
+		{name: "full moon 2025-01-13", phase: 0.5, want: time.Date(2025, 1, 13, 22, 27, 0, 0, time.UTC)},
+		{name: "new moon 2025-01-29", phase: 0, want: time.Date(2025, 1, 29, 12, 36, 0, 0, time.UTC)},
+		{name: "first quarter 2025-03-06", phase: 0.25, want: time.Date(2025, 3, 6, 16, 32, 0, 0, time.UTC)},
+		{name: "full moon 2025-03-14", phase: 0.5, want: time.Date(2025, 3, 14, 6, 55, 0, 0, time.UTC)},
+		{name: "last quarter 2025-03-22", phase: 0.75, want: time.Date(2025, 3, 22, 11, 29, 0, 0, time.UTC)},
+		{name: "new moon 2025-03-29", phase: 0, want: time.Date(2025, 3, 29, 10, 58, 0, 0, time.UTC)},
+		{name: "new moon 2024-04-08", phase: 0, want: time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findPhase(tt.want, tt.phase); got.Sub(tt.want).Abs() > 3*time.Minute {
+				t.Errorf("phase %v reached at %v, want %v", tt.phase, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_siderealTime(t *testing.T) {
+	// Meeus, Astronomical Algorithms, examples 12.a and 12.b
+	tests := []struct {
+		date time.Time
+		want float64
+	}{
+		{date: time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), want: 197.693195},
+		{date: time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC), want: 128.7378734},
+	}
+	for _, tt := range tests {
+		if got := siderealTime(tt.date); math.Abs(got-tt.want) > 0.00001 {
+			t.Errorf("siderealTime(%v) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func Test_getRiseSet(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 15.a: Venus at Boston on 1988 March 20, using the apparent right
+	// ascensions and declinations of the day before, the day and the day after at 0h dynamical time
+	ra := [3]float64{40.68021, 41.73129, 42.78204}
+	dec := [3]float64{18.04761, 18.44092, 18.82742}
+	lat, lon := 42.3333, -71.0833
+	deltaT := 56 * time.Second
+
+	day := time.Date(1988, 3, 20, 0, 0, 0, 0, time.UTC)
+	interpolate := func(y [3]float64, n float64) float64 {
+		a, b := y[1]-y[0], y[2]-y[1]
+		return y[1] + n/2*(a+b+n*(b-a))
+	}
+	altitude := func(t time.Time) float64 {
+		n := float64(t.Add(deltaT).Sub(day)) / float64(24*time.Hour)
+		r, d := interpolate(ra, n), interpolate(dec, n)
+		hourAngle := siderealTime(t) + lon - r
+		return math.Asin(sin(lat)*sin(d)+cos(lat)*cos(d)*cos(hourAngle))/rad + 0.5667
+	}
+
+	rise, set := getRiseSet(day, altitude)
+	if want := time.Date(1988, 3, 20, 12, 25, 0, 0, time.UTC); rise.Sub(want).Abs() > time.Minute {
+		t.Errorf("getRiseSet() rise = %v, want %v", rise, want)
+	}
+	if want := time.Date(1988, 3, 20, 2, 55, 0, 0, time.UTC); set.Sub(want).Abs() > time.Minute {
+		t.Errorf("getRiseSet() set = %v, want %v", set, want)
+	}
+}
+
+// Test_getMoonTimes checks the moon's position at the computed times. The position is verified against Meeus in
+// Test_moonGeometricPosition and the solver in Test_getRiseSet.
+func Test_getMoonTimes(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		lat  float64
+		lon  float64
+	}{
+		{name: "berlin full moon 2025-03-14", date: time.Date(2025, 3, 14, 12, 0, 0, 0, berlin), lat: 52.52, lon: 13.405},
+		{name: "berlin new moon 2025-03-29", date: time.Date(2025, 3, 29, 12, 0, 0, 0, berlin), lat: 52.52, lon: 13.405},
+		{name: "sydney full moon 2025-03-14", date: time.Date(2025, 3, 14, 12, 0, 0, 0, time.FixedZone("AEDT", 11*60*60)), lat: -33.87, lon: 151.21},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rise, set := getMoonTimes(tt.date, tt.lat, tt.lon)
+			if rise.IsZero() || set.IsZero() {
+				t.Fatalf("getMoonTimes() rise = %v, set = %v, expected both to be set", rise, set)
+			}
+
+			for _, event := range []time.Time{rise, set} {
+				if event.Day() != tt.date.Day() {
+					t.Errorf("getMoonTimes() returned %v, not on the requested day", event)
+				}
+				// the moon's upper limb touches the horizon, an altitude error of 0.05° is about ten seconds
+				if alt := moonAltitude(event, tt.lat, tt.lon); math.Abs(alt) > 0.05 {
+					t.Errorf("moon altitude at %v = %v, want 0", event, alt)
+				}
+			}
+
+			if moonAltitude(rise.Add(10*time.Minute), tt.lat, tt.lon) <= 0 {
+				t.Errorf("moon is not rising at %v", rise)
+			}
+			if moonAltitude(set.Add(10*time.Minute), tt.lat, tt.lon) >= 0 {
+				t.Errorf("moon is not setting at %v", set)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package astral
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithLocation(location *time.Location) Opt {
+	return func(ds *Astral) error {
+		if location == nil {
+			return errors.New("empty location")
+		}
+		ds.location = location
+		return nil
+	}
+}
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *Astral) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("astral").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
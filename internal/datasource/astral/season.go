@@ -0,0 +1,102 @@
+package astral
+
+import (
+	"math"
+	"time"
+)
+
+// Solstices and equinoxes are calculated using the algorithm from Jean Meeus, Astronomical Algorithms, chapter 27,
+// which is accurate to about a minute for the years 1000 to 3000.
+
+type Season struct {
+	Name  string
+	Emoji string
+	Time  time.Time
+}
+
+type seasonCoefficients struct {
+	name  string
+	emoji string
+	terms [5]float64
+}
+
+var seasons = []seasonCoefficients{
+	{"March Equinox", "🌸", [5]float64{2451623.80984, 365242.37404, 0.05169, -0.00411, -0.00057}},
+	{"June Solstice", "☀️", [5]float64{2451716.56767, 365241.62603, 0.00325, 0.00888, -0.00030}},
+	{"September Equinox", "🍂", [5]float64{2451810.21715, 365242.01767, -0.11575, 0.00337, 0.00078}},
+	{"December Solstice", "❄️", [5]float64{2451900.05952, 365242.74049, -0.06223, -0.00823, 0.00032}},
+}
+
+// periodicTerms holds the coefficients A, B and C of table 27.C.
+var periodicTerms = [][3]float64{
+	{485, 324.96, 1934.136},
+	{203, 337.23, 32964.467},
+	{199, 342.08, 20.186},
+	{182, 27.85, 445267.112},
+	{156, 73.14, 45036.886},
+	{136, 171.52, 22518.443},
+	{77, 222.54, 65928.934},
+	{74, 296.72, 3034.906},
+	{70, 243.58, 9037.513},
+	{58, 119.81, 33718.147},
+	{52, 297.17, 150.678},
+	{50, 21.02, 2281.226},
+	{45, 247.54, 29929.562},
+	{44, 325.15, 31555.956},
+	{29, 60.93, 4443.417},
+	{18, 155.12, 67555.328},
+	{17, 288.79, 4562.452},
+	{16, 198.04, 62894.029},
+	{14, 199.76, 31436.921},
+	{12, 95.39, 14577.848},
+	{12, 287.11, 31931.756},
+	{12, 320.81, 34777.259},
+	{9, 227.73, 1222.114},
+	{8, 15.45, 16859.074},
+}
+
+func getSeasonStart(year int, season seasonCoefficients) time.Time {
+	y := float64(year-2000) / 1000
+	c := season.terms
+	jde0 := c[0] + c[1]*y + c[2]*y*y + c[3]*y*y*y + c[4]*y*y*y*y
+
+	t := (jde0 - julian2000) / 36525
+	w := rad * (35999.373*t - 2.47)
+	deltaLambda := 1 + 0.0334*math.Cos(w) + 0.0007*math.Cos(2*w)
+
+	s := 0.
+	for _, term := range periodicTerms {
+		s += term[0] * math.Cos(rad*(term[1]+term[2]*t))
+	}
+
+	jde := jde0 + 0.00001*s/deltaLambda
+
+	// convert from dynamical time to universal time
+	deltaT := estimateDeltaT(year)
+	unixMillis := (jde-julian1970+0.5)*millisPerDay - deltaT.Seconds()*1000
+	return time.UnixMilli(int64(math.Round(unixMillis))).UTC()
+}
+
+// estimateDeltaT returns the difference between dynamical time and universal time, using the polynomial expression
+// for the years 2005 to 2050 by Espenak and Meeus.
+func estimateDeltaT(year int) time.Duration {
+	t := float64(year - 2000)
+	seconds := 62.92 + 0.32217*t + 0.005589*t*t
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// getNextSeason returns the next solstice or equinox after the given date.
+func getNextSeason(date time.Time) Season {
+	for year := date.Year(); ; year++ {
+		for _, s := range seasons {
+			start := getSeasonStart(year, s)
+			if start.After(date) {
+				return Season{
+					Name:  s.name,
+					Emoji: s.emoji,
+					Time:  start.In(date.Location()),
+				}
+			}
+		}
+	}
+}
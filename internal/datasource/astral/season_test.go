@@ -0,0 +1,57 @@
+package astral
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_getNextSeason(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     time.Time
+		wantName string
+		wantTime time.Time
+	}{
+		{
+			name:     "march equinox 2025",
+			date:     time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+			wantName: "March Equinox",
+			wantTime: time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC),
+		},
+		{
+			name:     "june solstice 2025",
+			date:     time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC),
+			wantName: "June Solstice",
+			wantTime: time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC),
+		},
+		{
+			name:     "september equinox 2025",
+			date:     time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			wantName: "September Equinox",
+			wantTime: time.Date(2025, 9, 22, 18, 19, 0, 0, time.UTC),
+		},
+		{
+			name:     "december solstice 2025",
+			date:     time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantName: "December Solstice",
+			wantTime: time.Date(2025, 12, 21, 15, 3, 0, 0, time.UTC),
+		},
+		{
+			name:     "march equinox next year",
+			date:     time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC),
+			wantName: "March Equinox",
+			wantTime: time.Date(2026, 3, 20, 14, 46, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getNextSeason(tt.date)
+			if got.Name != tt.wantName {
+				t.Errorf("getNextSeason().Name = %v, want %v", got.Name, tt.wantName)
+			}
+			if d := got.Time.Sub(tt.wantTime); d.Abs() > 2*time.Minute {
+				t.Errorf("getNextSeason().Time = %v, want %v", got.Time, tt.wantTime)
+			}
+		})
+	}
+}
@@ -2,8 +2,11 @@ package astral
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
+
+	"github.com/soerenschneider/aether/pkg"
 )
 
 func getSummary(data AstralData, now time.Time) []string {
@@ -44,5 +47,73 @@ func getSummary(data AstralData, now time.Time) []string {
 		summary = append(summary, settingStr)
 	}
 
+	if data.DayLength > 0 {
+		dayLength := fmt.Sprintf("⏱️ Day length: %s", formatDuration(data.DayLength))
+		if data.DayLengthDelta != 0 {
+			dayLength += fmt.Sprintf(" (%s)", formatDelta(data.DayLengthDelta))
+		}
+		summary = append(summary, dayLength)
+	}
+
+	if data.MoonPhaseName != "" {
+		moon := fmt.Sprintf("%s %s (%.0f%%)", data.MoonPhaseEmoji, data.MoonPhaseName, data.MoonIllumination*100)
+		if !data.Moonrise.IsZero() {
+			moon += fmt.Sprintf(", moonrise %s", data.Moonrise.Format("15:04"))
+		}
+		if !data.Moonset.IsZero() {
+			moon += fmt.Sprintf(", moonset %s", data.Moonset.Format("15:04"))
+		}
+		summary = append(summary, moon)
+	}
+
+	if season := getSeasonSummary(data.NextSeason, now); season != "" {
+		summary = append(summary, season)
+	}
+
 	return summary
 }
+
+// getLocationSummary returns a condensed summary line for additional locations.
+func getLocationSummary(location LocationData) string {
+	return fmt.Sprintf("☀️%s: %s - %s (%s)", location.Name, location.Sunrise.Format("15:04"), location.Sunset.Format("15:04"), formatDuration(location.DayLength))
+}
+
+const seasonSummaryDays = 7
+
+func getSeasonSummary(season Season, now time.Time) string {
+	if season.Time.IsZero() {
+		return ""
+	}
+
+	today := pkg.Today(now)
+	days := int(math.Round(pkg.Today(season.Time.In(now.Location())).Sub(today).Hours() / 24))
+	switch {
+	case days < 0 || days > seasonSummaryDays:
+		return ""
+	case days == 0:
+		return fmt.Sprintf("%s %s today at %s", season.Emoji, season.Name, season.Time.Format("15:04"))
+	case days == 1:
+		return fmt.Sprintf("%s %s tomorrow at %s", season.Emoji, season.Name, season.Time.Format("15:04"))
+	default:
+		return fmt.Sprintf("%s %s in %d days (%s)", season.Emoji, season.Name, days, season.Time.Format("02.01. 15:04"))
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func formatDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	d = d.Round(time.Second)
+	if d < time.Minute {
+		return fmt.Sprintf("%s%ds", sign, int(d.Seconds()))
+	}
+	return fmt.Sprintf("%s%dm %02ds", sign, int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -34,6 +34,56 @@ func Test_getSummary(t *testing.T) {
 				"🌇 Golden Hour ⬇️️ 17:00 - 17:30, 🌌 Blue Hour ⬇️ 17:50 - 18:10",
 			},
 		},
+		{
+			name: "moon, day length and upcoming equinox",
+			args: args{
+				data: AstralData{
+					Sunrise:          time.Date(2025, 3, 14, 6, 21, 0, 0, time.UTC),
+					Sunset:           time.Date(2025, 3, 14, 18, 10, 0, 0, time.UTC),
+					DayLength:        11*time.Hour + 49*time.Minute,
+					DayLengthDelta:   3*time.Minute + 41*time.Second,
+					MoonPhaseName:    "Full Moon",
+					MoonPhaseEmoji:   "🌕",
+					MoonIllumination: 0.998,
+					Moonrise:         time.Date(2025, 3, 14, 18, 38, 0, 0, time.UTC),
+					Moonset:          time.Date(2025, 3, 14, 6, 41, 0, 0, time.UTC),
+					NextSeason: Season{
+						Name:  "March Equinox",
+						Emoji: "🌸",
+						Time:  time.Date(2025, 3, 20, 9, 1, 0, 0, time.UTC),
+					},
+				},
+				now: time.Date(2025, 3, 14, 20, 0, 0, 0, time.UTC),
+			},
+			want: []string{
+				"⏱️ Day length: 11h 49m (+3m 41s)",
+				"🌕 Full Moon (100%), moonrise 18:38, moonset 06:41",
+				"🌸 March Equinox in 6 days (20.03. 09:01)",
+			},
+		},
+		{
+			name: "shrinking days, solstice tomorrow",
+			args: args{
+				data: AstralData{
+					DayLength:        16*time.Hour + 50*time.Minute,
+					DayLengthDelta:   -12 * time.Second,
+					MoonPhaseName:    "New Moon",
+					MoonPhaseEmoji:   "🌑",
+					MoonIllumination: 0.01,
+					NextSeason: Season{
+						Name:  "June Solstice",
+						Emoji: "☀️",
+						Time:  time.Date(2025, 6, 21, 2, 42, 0, 0, time.UTC),
+					},
+				},
+				now: time.Date(2025, 6, 20, 23, 0, 0, 0, time.UTC),
+			},
+			want: []string{
+				"⏱️ Day length: 16h 50m (-12s)",
+				"🌑 New Moon (1%)",
+				"☀️ June Solstice tomorrow at 02:42",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -43,3 +93,19 @@ func Test_getSummary(t *testing.T) {
 		})
 	}
 }
+
+func Test_getLocationSummary(t *testing.T) {
+	location := LocationData{
+		Name: "Holiday Home",
+		AstralData: AstralData{
+			Sunrise:   time.Date(2025, 2, 10, 7, 41, 0, 0, time.UTC),
+			Sunset:    time.Date(2025, 2, 10, 18, 12, 0, 0, time.UTC),
+			DayLength: 10*time.Hour + 31*time.Minute,
+		},
+	}
+
+	want := "☀️Holiday Home: 07:41 - 18:12 (10h 31m)"
+	if got := getLocationSummary(location); got != want {
+		t.Errorf("getLocationSummary() = %v, want %v", got, want)
+	}
+}
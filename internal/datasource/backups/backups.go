@@ -0,0 +1,147 @@
+package backups
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultMaxAge  = 26 * time.Hour
+	defaultTimeout = 30 * time.Second
+)
+
+type BackupsDatasource struct {
+	jobs    []Job
+	timeout time.Duration
+	run     commandRunner
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *BackupsDatasource) error
+
+var validTypes = []string{JobRestic, JobBorg, JobFile, JobTextfile}
+
+var funcMap = template.FuncMap{
+	"bytes": pkg.FormatBytes,
+}
+
+func New(jobs []Job, templateData templates.TemplateData, opts ...Opt) (*BackupsDatasource, error) {
+	if len(jobs) == 0 {
+		return nil, errors.New("no jobs supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &BackupsDatasource{
+		jobs:    jobs,
+		timeout: defaultTimeout,
+		run:     runCommand,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("backups-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("backups-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for idx, job := range ds.jobs {
+		if !slices.Contains(validTypes, job.Type) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid type %q for job %q", job.Type, job.Name))
+		}
+		if job.Location == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty location for job %q", job.Name))
+		}
+		if job.MaxAge <= 0 {
+			ds.jobs[idx].MaxAge = defaultMaxAge
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *BackupsDatasource) Name() string {
+	return "Backups"
+}
+
+func (d *BackupsDatasource) checkAll(ctx context.Context, now time.Time) []JobRow {
+	rows := make([]JobRow, len(d.jobs))
+
+	p := pool.New().WithMaxGoroutines(4)
+	for idx, job := range d.jobs {
+		p.Go(func() {
+			ctx, cancel := context.WithTimeout(ctx, d.timeout)
+			defer cancel()
+
+			snapshot, err := d.check(ctx, job)
+			// every goroutine writes to its own index, no locking required
+			rows[idx] = buildRow(job, snapshot, err, now)
+			if err != nil {
+				log.Warn().Err(err).Str("job", rows[idx].Name).Msg("could not check backup")
+			}
+		})
+	}
+	p.Wait()
+
+	return rows
+}
+
+func (d *BackupsDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	data := BackupsData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Jobs:   d.checkAll(ctx, time.Now()),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Jobs)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,234 @@
+package backups
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseResticSnapshots(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    Snapshot
+		wantErr bool
+	}{
+		{
+			name: "latest with summary",
+			data: `[
+				{"time":"2025-03-01T02:00:05.123456789+01:00","id":"a","summary":{"total_bytes_processed":1048576}},
+				{"time":"2025-03-02T02:00:07.5+01:00","id":"b","summary":{"total_bytes_processed":2097152}}
+			]`,
+			want: Snapshot{Time: time.Date(2025, 3, 2, 1, 0, 7, 500000000, time.UTC), Size: 2097152},
+		},
+		{
+			name: "old restic without summary",
+			data: `[{"time":"2025-03-01T02:00:05+01:00","id":"a"}]`,
+			want: Snapshot{Time: time.Date(2025, 3, 1, 1, 0, 5, 0, time.UTC)},
+		},
+		{
+			name:    "no snapshots",
+			data:    `[]`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			data:    `Fatal: repository does not exist`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseResticSnapshots([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseResticSnapshots() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Time.Equal(tt.want.Time) || got.Size != tt.want.Size {
+				t.Errorf("parseResticSnapshots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBorgInfo(t *testing.T) {
+	data := `{
+		"archives": [
+			{"name": "host-2025-03-02", "start": "2025-03-02T02:00:01.000000", "end": "2025-03-02T02:13:37.000000",
+			 "stats": {"compressed_size": 100, "deduplicated_size": 10, "nfiles": 3, "original_size": 5368709120}}
+		],
+		"repository": {"id": "abc", "location": "/mnt/backup/borg"}
+	}`
+
+	loc := time.FixedZone("CET", 3600)
+	got, err := parseBorgInfo([]byte(data), loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Snapshot{Time: time.Date(2025, 3, 2, 2, 13, 37, 0, loc), Size: 5368709120}
+	if !got.Time.Equal(want.Time) || got.Size != want.Size {
+		t.Errorf("parseBorgInfo() = %v, want %v", got, want)
+	}
+
+	if _, err := parseBorgInfo([]byte(`{"archives": []}`), loc); !errors.Is(err, errNoSnapshot) {
+		t.Errorf("expected errNoSnapshot, got %v", err)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	dir := t.TempDir()
+	older := filepath.Join(dir, "db-1.sql.gz")
+	newer := filepath.Join(dir, "db-2.sql.gz")
+	if err := os.WriteFile(older, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(newer, []byte("newer"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	olderTime := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	newerTime := time.Date(2025, 3, 2, 3, 0, 0, 0, time.UTC)
+	_ = os.Chtimes(older, olderTime, olderTime)
+	_ = os.Chtimes(newer, newerTime, newerTime)
+
+	got, err := checkFile(filepath.Join(dir, "db-*.sql.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Time.Equal(newerTime) || got.Size != 5 {
+		t.Errorf("checkFile() = %v, want %v with size 5", got, newerTime)
+	}
+
+	if _, err := checkFile(filepath.Join(dir, "missing-*")); err == nil {
+		t.Error("expected error for no matching files")
+	}
+}
+
+func TestCheckTextfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backup.prom")
+	content := `# HELP backup_last_success_timestamp_seconds Time of the last successful backup.
+# TYPE backup_last_success_timestamp_seconds gauge
+backup_last_success_timestamp_seconds{job="nas"} 1.7408808e+09
+backup_last_success_timestamp_seconds{job="db"} 1740794400
+# TYPE backup_size_bytes gauge
+backup_size_bytes{job="nas"} 1024
+backup_size_bytes{job="db"} 2048
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		job     Job
+		want    Snapshot
+		wantErr bool
+	}{
+		{
+			name: "default metric with labels",
+			job:  Job{Location: path, SizeMetric: "backup_size_bytes", Labels: map[string]string{"job": "db"}},
+			want: Snapshot{Time: time.Unix(1740794400, 0), Size: 2048},
+		},
+		{
+			name: "first series without labels",
+			job:  Job{Location: path},
+			want: Snapshot{Time: time.Unix(1740880800, 0)},
+		},
+		{
+			name:    "no matching labels",
+			job:     Job{Location: path, Labels: map[string]string{"job": "unknown"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown metric",
+			job:     Job{Location: path, TimestampMetric: "restic_last_snapshot"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkTextfile(tt.job)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkTextfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Time.Equal(tt.want.Time) || got.Size != tt.want.Size {
+				t.Errorf("checkTextfile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRestic(t *testing.T) {
+	var gotArgs []string
+	ds := &BackupsDatasource{
+		run: func(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+			gotArgs = append([]string{name}, args...)
+			return []byte(`[{"time":"2025-03-02T02:00:00Z"}]`), nil
+		},
+	}
+
+	_, err := ds.check(context.Background(), Job{Type: JobRestic, Location: "sftp:nas:/restic", PasswordFile: "/etc/restic/pw"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"restic", "--repo", "sftp:nas:/restic", "snapshots", "--json", "--latest", "1", "--no-lock", "--password-file", "/etc/restic/pw"}
+	if !reflect.DeepEqual(gotArgs, want) {
+		t.Errorf("restic called with %v, want %v", gotArgs, want)
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rows []JobRow
+		want []string
+	}{
+		{
+			name: "all fresh",
+			rows: []JobRow{
+				buildRow(Job{Name: "nas", MaxAge: 26 * time.Hour}, Snapshot{Time: now.Add(-10 * time.Hour)}, nil, now),
+			},
+		},
+		{
+			name: "overdue and failed",
+			rows: []JobRow{
+				buildRow(Job{Name: "nas", MaxAge: 26 * time.Hour}, Snapshot{Time: now.Add(-51 * time.Hour)}, nil, now),
+				buildRow(Job{Name: "laptop", MaxAge: 7 * 24 * time.Hour}, Snapshot{Time: now.Add(-51 * time.Hour)}, nil, now),
+				buildRow(Job{Location: "/mnt/borg", MaxAge: 26 * time.Hour}, Snapshot{}, errors.New("repository locked"), now),
+			},
+			want: []string{"🔴 Backups overdue: nas (2d 3h ago), /mnt/borg (failed to check)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCssClass(t *testing.T) {
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{age: time.Hour, want: "green"},
+		{age: 20 * time.Hour, want: "yellow"},
+		{age: 25 * time.Hour, want: "red"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.age.String(), func(t *testing.T) {
+			if got := getCssClass(tt.age, 24*time.Hour); got != tt.want {
+				t.Errorf("getCssClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package backups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	defaultTimestampMetric = "backup_last_success_timestamp_seconds"
+	borgTimeLayout         = "2006-01-02T15:04:05.999999"
+)
+
+var errNoSnapshot = errors.New("no snapshot found")
+
+// commandRunner runs an external command and returns its stdout.
+type commandRunner func(ctx context.Context, env []string, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, env []string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), env...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return out, nil
+}
+
+func (d *BackupsDatasource) check(ctx context.Context, job Job) (Snapshot, error) {
+	switch job.Type {
+	case JobRestic:
+		return d.checkRestic(ctx, job)
+	case JobBorg:
+		return d.checkBorg(ctx, job)
+	case JobFile:
+		return checkFile(job.Location)
+	case JobTextfile:
+		return checkTextfile(job)
+	default:
+		return Snapshot{}, fmt.Errorf("unknown type %q", job.Type)
+	}
+}
+
+func (d *BackupsDatasource) checkRestic(ctx context.Context, job Job) (Snapshot, error) {
+	args := []string{"--repo", job.Location, "snapshots", "--json", "--latest", "1", "--no-lock"}
+	if job.PasswordFile != "" {
+		args = append(args, "--password-file", job.PasswordFile)
+	}
+
+	out, err := d.run(ctx, nil, "restic", args...)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return parseResticSnapshots(out)
+}
+
+func (d *BackupsDatasource) checkBorg(ctx context.Context, job Job) (Snapshot, error) {
+	var env []string
+	if job.PasswordFile != "" {
+		password, err := os.ReadFile(job.PasswordFile)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("could not read password from file %q: %w", job.PasswordFile, err)
+		}
+		env = append(env, "BORG_PASSPHRASE="+strings.TrimSpace(string(password)))
+	}
+
+	out, err := d.run(ctx, env, "borg", "info", "--json", "--last", "1", job.Location)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return parseBorgInfo(out, time.Local)
+}
+
+// parseResticSnapshots parses the output of `restic snapshots --json` and returns the latest snapshot.
+func parseResticSnapshots(data []byte) (Snapshot, error) {
+	var snapshots []struct {
+		Time    time.Time `json:"time"`
+		Summary *struct {
+			TotalBytesProcessed uint64 `json:"total_bytes_processed"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return Snapshot{}, fmt.Errorf("could not parse restic snapshots: %w", err)
+	}
+
+	var latest Snapshot
+	for _, snapshot := range snapshots {
+		if snapshot.Time.Before(latest.Time) {
+			continue
+		}
+		latest = Snapshot{Time: snapshot.Time}
+		// the summary is only available for snapshots created by restic >= 0.17
+		if snapshot.Summary != nil {
+			latest.Size = snapshot.Summary.TotalBytesProcessed
+		}
+	}
+
+	if latest.Time.IsZero() {
+		return Snapshot{}, errNoSnapshot
+	}
+	return latest, nil
+}
+
+// parseBorgInfo parses the output of `borg info --json --last 1`. Borg prints timestamps in local time without
+// a zone, therefore the location has to be supplied.
+func parseBorgInfo(data []byte, loc *time.Location) (Snapshot, error) {
+	var info struct {
+		Archives []struct {
+			End   string `json:"end"`
+			Stats struct {
+				OriginalSize uint64 `json:"original_size"`
+			} `json:"stats"`
+		} `json:"archives"`
+	}
+	if err := json.Unmarshal(data, &info); err != nil {
+		return Snapshot{}, fmt.Errorf("could not parse borg info: %w", err)
+	}
+
+	var latest Snapshot
+	for _, archive := range info.Archives {
+		end, err := time.ParseInLocation(borgTimeLayout, archive.End, loc)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("could not parse borg archive time %q: %w", archive.End, err)
+		}
+		if end.After(latest.Time) {
+			latest = Snapshot{Time: end, Size: archive.Stats.OriginalSize}
+		}
+	}
+
+	if latest.Time.IsZero() {
+		return Snapshot{}, errNoSnapshot
+	}
+	return latest, nil
+}
+
+// checkFile returns the modification time and size of the newest file matching the pattern.
+func checkFile(pattern string) (Snapshot, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	var latest Snapshot
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if info.ModTime().After(latest.Time) {
+			latest = Snapshot{Time: info.ModTime(), Size: uint64(info.Size())}
+		}
+	}
+
+	if latest.Time.IsZero() {
+		return Snapshot{}, fmt.Errorf("no file matching %q", pattern)
+	}
+	return latest, nil
+}
+
+func checkTextfile(job Job) (Snapshot, error) {
+	file, err := os.Open(job.Location)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(file)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("could not parse textfile %q: %w", job.Location, err)
+	}
+
+	timestampMetric := job.TimestampMetric
+	if timestampMetric == "" {
+		timestampMetric = defaultTimestampMetric
+	}
+
+	timestamp, err := getMetricValue(families, timestampMetric, job.Labels)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if timestamp <= 0 {
+		return Snapshot{}, errNoSnapshot
+	}
+
+	sec, frac := math.Modf(timestamp)
+	snapshot := Snapshot{Time: time.Unix(int64(sec), int64(frac*1e9))}
+
+	if job.SizeMetric != "" {
+		size, err := getMetricValue(families, job.SizeMetric, job.Labels)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		snapshot.Size = uint64(size)
+	}
+
+	return snapshot, nil
+}
+
+// getMetricValue returns the value of the first series of the metric that carries all the given labels.
+func getMetricValue(families map[string]*dto.MetricFamily, name string, labels map[string]string) (float64, error) {
+	family, found := families[name]
+	if !found {
+		return 0, fmt.Errorf("metric %q not found", name)
+	}
+
+	for _, metric := range family.GetMetric() {
+		if !matchesLabels(metric.GetLabel(), labels) {
+			continue
+		}
+
+		switch {
+		case metric.GetGauge() != nil:
+			return metric.GetGauge().GetValue(), nil
+		case metric.GetCounter() != nil:
+			return metric.GetCounter().GetValue(), nil
+		case metric.GetUntyped() != nil:
+			return metric.GetUntyped().GetValue(), nil
+		}
+	}
+
+	return 0, fmt.Errorf("no series of metric %q matches labels %v", name, labels)
+}
+
+func matchesLabels(pairs []*dto.LabelPair, labels map[string]string) bool {
+	for key, val := range labels {
+		if !slices.ContainsFunc(pairs, func(pair *dto.LabelPair) bool {
+			return pair.GetName() == key && pair.GetValue() == val
+		}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package backups
+
+import (
+	"time"
+)
+
+const (
+	JobRestic   = "restic"
+	JobBorg     = "borg"
+	JobFile     = "file"
+	JobTextfile = "textfile"
+)
+
+// Job describes a single backup that is checked for freshness.
+type Job struct {
+	Name string
+	// Type is one of restic, borg, file or textfile.
+	Type string
+	// Location is the repository for restic and borg, a path or glob pattern for file and the path to the
+	// Prometheus textfile for textfile.
+	Location string
+	// PasswordFile is the file containing the password of restic and borg repositories.
+	PasswordFile string
+	// MaxAge is the age from which on a backup is considered stale.
+	MaxAge time.Duration
+
+	// TimestampMetric and SizeMetric are the names of the metrics read from Prometheus textfiles, Labels
+	// are used to select a single series.
+	TimestampMetric string
+	SizeMetric      string
+	Labels          map[string]string
+}
+
+type Snapshot struct {
+	Time time.Time
+	// Size is the size of the snapshot in bytes, zero if unknown.
+	Size uint64
+}
+
+type JobRow struct {
+	Name     string
+	Type     string
+	Last     time.Time
+	Size     uint64
+	Age      string
+	Overdue  bool
+	Error    string
+	CssClass string
+}
+
+type BackupsData struct {
+	HtmlId string
+	Jobs   []JobRow
+}
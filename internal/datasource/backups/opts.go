@@ -0,0 +1,37 @@
+package backups
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *BackupsDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("backups-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout for checking a single job, listing remote repositories may take a while.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(ds *BackupsDatasource) error {
+		if timeout < time.Second || timeout > 5*time.Minute {
+			return errors.New("timeout must be [1s, 5m]")
+		}
+
+		ds.timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,79 @@
+package backups
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func buildRow(job Job, snapshot Snapshot, err error, now time.Time) JobRow {
+	row := JobRow{
+		Name: job.Name,
+		Type: job.Type,
+	}
+	if row.Name == "" {
+		row.Name = job.Location
+	}
+
+	if err != nil {
+		row.Error = err.Error()
+		row.Overdue = true
+		row.CssClass = "red"
+		return row
+	}
+
+	row.Last = snapshot.Time
+	row.Size = snapshot.Size
+	age := now.Sub(snapshot.Time)
+	row.Age = formatAge(age)
+	row.Overdue = age > job.MaxAge
+	row.CssClass = getCssClass(age, job.MaxAge)
+	return row
+}
+
+func getCssClass(age, maxAge time.Duration) string {
+	if age > maxAge {
+		return "red"
+	}
+	if age > maxAge*3/4 {
+		return "yellow"
+	}
+	return "green"
+}
+
+func formatAge(age time.Duration) string {
+	if age < 0 {
+		age = 0
+	}
+
+	days := int(age.Hours() / 24)
+	hours := int(age.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	if hours > 0 {
+		return fmt.Sprintf("%dh %dm", hours, int(age.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(age.Minutes()))
+}
+
+func getSummary(rows []JobRow) []string {
+	var overdue []string
+	for _, row := range rows {
+		if !row.Overdue {
+			continue
+		}
+
+		if row.Error != "" {
+			overdue = append(overdue, fmt.Sprintf("%s (failed to check)", row.Name))
+		} else {
+			overdue = append(overdue, fmt.Sprintf("%s (%s ago)", row.Name, row.Age))
+		}
+	}
+
+	if len(overdue) == 0 {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("🔴 Backups overdue: %s", strings.Join(overdue, ", "))}
+}
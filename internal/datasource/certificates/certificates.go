@@ -0,0 +1,137 @@
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultTimeout   = 10 * time.Second
+	defaultThreshold = 14 * 24 * time.Hour
+)
+
+type CertificatesDatasource struct {
+	sources   []Source
+	timeout   time.Duration
+	threshold time.Duration
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *CertificatesDatasource) error
+
+var validTypes = []string{SourceFile, SourceSecret, SourceEndpoint}
+
+func New(sources []Source, templateData templates.TemplateData, opts ...Opt) (*CertificatesDatasource, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no sources supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &CertificatesDatasource{
+		sources:   sources,
+		timeout:   defaultTimeout,
+		threshold: defaultThreshold,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("certificates-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("certificates-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, source := range ds.sources {
+		if !slices.Contains(validTypes, source.Type) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid type %q for source %q", source.Type, source.Location))
+		}
+		if source.Location == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty location for source %q", source.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *CertificatesDatasource) Name() string {
+	return "Certificates"
+}
+
+func (d *CertificatesDatasource) inspectAll(ctx context.Context) []Certificate {
+	var certs []Certificate
+	var mutex sync.Mutex
+
+	p := pool.New().WithMaxGoroutines(8)
+	for _, source := range d.sources {
+		p.Go(func() {
+			inspected := inspect(ctx, source, d.timeout)
+			mutex.Lock()
+			certs = append(certs, inspected...)
+			mutex.Unlock()
+		})
+	}
+	p.Wait()
+
+	return certs
+}
+
+func (d *CertificatesDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	now := time.Now()
+	data := CertificatesData{
+		HtmlId:       pkg.NameToId(d.Name()),
+		Certificates: buildRows(d.inspectAll(ctx), now),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Certificates, d.threshold, now)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
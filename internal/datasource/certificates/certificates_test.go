@@ -0,0 +1,189 @@
+package certificates
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateCert(t *testing.T, cn string, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		DNSNames:     []string{cn, "www." + cn},
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	certA := generateCert(t, "a.example.com", notAfter)
+	certB := generateCert(t, "b.example.com", notAfter.Add(24*time.Hour))
+
+	write := func(name string, data []byte) {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// a chain, only the leaf is expected
+	write("a.pem", append(append([]byte{}, certA...), certB...))
+	write("b.pem", certB)
+	write("invalid.pem", []byte("no certificate"))
+	write("secret.yaml", []byte(fmt.Sprintf(`apiVersion: v1
+kind: Secret
+type: kubernetes.io/tls
+metadata:
+  name: web-tls
+  namespace: default
+data:
+  tls.crt: %s
+  tls.key: c2VjcmV0
+`, base64.StdEncoding.EncodeToString(certA))))
+	write("secrets.json", []byte(fmt.Sprintf(`{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"kind": "Secret", "metadata": {"name": "b-tls", "namespace": "prod"}, "data": {"tls.crt": %q}},
+    {"kind": "Secret", "metadata": {"name": "opaque", "namespace": "prod"}, "data": {"password": "c2VjcmV0"}}
+  ]
+}`, base64.StdEncoding.EncodeToString(certB))))
+
+	tests := []struct {
+		name   string
+		source Source
+		want   []Certificate
+	}{
+		{
+			name:   "pem file",
+			source: Source{Name: "web", Type: SourceFile, Location: filepath.Join(dir, "a.pem")},
+			want:   []Certificate{{Source: "web", Subject: "CN=a.example.com", Sans: []string{"a.example.com", "www.a.example.com"}, Issuer: "CN=a.example.com", NotAfter: notAfter}},
+		},
+		{
+			name:   "invalid pem file",
+			source: Source{Type: SourceFile, Location: filepath.Join(dir, "invalid.pem")},
+			want:   []Certificate{{Source: filepath.Join(dir, "invalid.pem"), Error: "no certificate found"}},
+		},
+		{
+			name:   "no files",
+			source: Source{Type: SourceFile, Location: filepath.Join(dir, "*.crt")},
+			want:   []Certificate{{Source: filepath.Join(dir, "*.crt"), Error: "no files found"}},
+		},
+		{
+			name:   "secret",
+			source: Source{Type: SourceSecret, Location: filepath.Join(dir, "secret.yaml")},
+			want:   []Certificate{{Source: "default/web-tls", Subject: "CN=a.example.com", Sans: []string{"a.example.com", "www.a.example.com"}, Issuer: "CN=a.example.com", NotAfter: notAfter}},
+		},
+		{
+			name:   "secret list",
+			source: Source{Name: "ignored", Type: SourceSecret, Location: filepath.Join(dir, "secrets.json")},
+			want:   []Certificate{{Source: "ignored", Subject: "CN=b.example.com", Sans: []string{"b.example.com", "www.b.example.com"}, Issuer: "CN=b.example.com", NotAfter: notAfter.Add(24 * time.Hour)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := inspect(context.Background(), tt.source, time.Second)
+			for i := range got {
+				got[i].NotAfter = got[i].NotAfter.UTC()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("inspect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// globs pick up several files
+	got := inspect(context.Background(), Source{Name: "web", Type: SourceFile, Location: filepath.Join(dir, "*.pem")}, time.Second)
+	if len(got) != 3 {
+		t.Errorf("expected 3 results, got %+v", got)
+	}
+}
+
+func TestInspectEndpoint(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	address := strings.TrimPrefix(server.URL, "https://")
+	got := inspect(context.Background(), Source{Type: SourceEndpoint, Location: address, ServerName: "example.com"}, time.Second)
+	if len(got) != 1 || got[0].Error != "" {
+		t.Fatalf("inspect() = %+v", got)
+	}
+
+	if got[0].Source != address || !got[0].NotAfter.Equal(server.Certificate().NotAfter) || !reflect.DeepEqual(got[0].Sans, []string{"example.com", "*.example.com", "127.0.0.1", "::1"}) {
+		t.Errorf("inspect() = %+v", got[0])
+	}
+}
+
+func TestReport(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	certs := []Certificate{
+		{Source: "far", Sans: []string{"far.example.com"}, NotAfter: now.Add(90 * 24 * time.Hour)},
+		{Source: "soon", Sans: []string{"soon.example.com"}, NotAfter: now.Add(10*24*time.Hour + time.Hour)},
+		{Source: "broken", Error: "no certificate found"},
+		{Source: "expired", Subject: "CN=expired", NotAfter: now.Add(-2 * 24 * time.Hour)},
+		{Source: "month", Sans: []string{"month.example.com"}, NotAfter: now.Add(20 * 24 * time.Hour)},
+		{Source: "tomorrow", Sans: []string{"tomorrow.example.com"}, NotAfter: now.Add(30 * time.Hour)},
+	}
+
+	rows := buildRows(certs, now)
+
+	var order, classes []string
+	var days []int
+	for _, row := range rows {
+		order = append(order, row.Source)
+		classes = append(classes, row.CssClass)
+		days = append(days, row.DaysLeft)
+	}
+
+	if want := []string{"broken", "expired", "tomorrow", "soon", "month", "far"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if want := []string{"red", "red", "red", "orange", "yellow", ""}; !reflect.DeepEqual(classes, want) {
+		t.Errorf("classes = %v, want %v", classes, want)
+	}
+	if want := []int{0, -2, 1, 10, 20, 90}; !reflect.DeepEqual(days, want) {
+		t.Errorf("days = %v, want %v", days, want)
+	}
+
+	want := []string{
+		"❌ Could not inspect certificate broken: no certificate found",
+		"🔐 Certificate expired (CN=expired) has expired",
+		"🔐 Certificate tomorrow (tomorrow.example.com) expires tomorrow",
+		"🔐 Certificate soon (soon.example.com) expires in 10 days",
+	}
+	if got := getSummary(rows, 14*24*time.Hour, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+}
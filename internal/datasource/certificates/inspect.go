@@ -0,0 +1,221 @@
+package certificates
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func inspect(ctx context.Context, source Source, timeout time.Duration) []Certificate {
+	switch source.Type {
+	case SourceFile:
+		return inspectFiles(source, readPemFile)
+	case SourceSecret:
+		return inspectFiles(source, readSecretFile)
+	case SourceEndpoint:
+		return []Certificate{inspectEndpoint(ctx, source, timeout)}
+	default:
+		return []Certificate{{Source: getName(source, source.Location), Error: fmt.Sprintf("unknown source type %q", source.Type)}}
+	}
+}
+
+func getName(source Source, location string) string {
+	if source.Name != "" {
+		return source.Name
+	}
+	return location
+}
+
+type certReader func(file string) (map[string]*x509.Certificate, error)
+
+func inspectFiles(source Source, reader certReader) []Certificate {
+	files, err := filepath.Glob(source.Location)
+	if err == nil && len(files) == 0 {
+		err = errors.New("no files found")
+	}
+	if err != nil {
+		return []Certificate{{Source: getName(source, source.Location), Error: err.Error()}}
+	}
+
+	var ret []Certificate
+	for _, file := range files {
+		certs, err := reader(file)
+		if err != nil {
+			ret = append(ret, Certificate{Source: getName(source, file), Error: err.Error()})
+			continue
+		}
+
+		for name, cert := range certs {
+			// names are only needed to tell apart multiple files or secrets
+			if len(files) == 1 && len(certs) == 1 && source.Name != "" {
+				name = source.Name
+			}
+			ret = append(ret, toCertificate(name, cert))
+		}
+	}
+
+	return ret
+}
+
+func toCertificate(source string, cert *x509.Certificate) Certificate {
+	sans := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+
+	return Certificate{
+		Source:   source,
+		Subject:  cert.Subject.String(),
+		Sans:     sans,
+		Issuer:   cert.Issuer.String(),
+		NotAfter: cert.NotAfter,
+	}
+}
+
+// parseLeaf returns the first certificate of PEM encoded data, which is the leaf certificate for chains.
+func parseLeaf(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no certificate found")
+		}
+
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+func readPemFile(file string) (map[string]*x509.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := parseLeaf(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*x509.Certificate{file: cert}, nil
+}
+
+type secret struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+	StringData map[string]string `yaml:"stringData"`
+	Items      []secret          `yaml:"items"`
+}
+
+func (s secret) getName() string {
+	if s.Metadata.Namespace != "" {
+		return fmt.Sprintf("%s/%s", s.Metadata.Namespace, s.Metadata.Name)
+	}
+	return s.Metadata.Name
+}
+
+// readSecretFile reads TLS secrets from the output of `kubectl get secret -o yaml` or `-o json`, both a single secret and
+// a list of secrets are supported.
+func readSecretFile(file string) (map[string]*x509.Certificate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed secret
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("could not parse secret: %w", err)
+	}
+
+	secrets := []secret{parsed}
+	if len(parsed.Items) > 0 {
+		secrets = parsed.Items
+	}
+
+	ret := map[string]*x509.Certificate{}
+	for _, s := range secrets {
+		if s.Kind != "" && s.Kind != "Secret" {
+			continue
+		}
+
+		var encoded []byte
+		if val, found := s.StringData["tls.crt"]; found {
+			encoded = []byte(val)
+		} else if val, found := s.Data["tls.crt"]; found {
+			encoded, err = base64.StdEncoding.DecodeString(strings.TrimSpace(val))
+			if err != nil {
+				return nil, fmt.Errorf("could not decode tls.crt of secret %q: %w", s.getName(), err)
+			}
+		} else {
+			continue
+		}
+
+		cert, err := parseLeaf(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tls.crt of secret %q: %w", s.getName(), err)
+		}
+		ret[s.getName()] = cert
+	}
+
+	if len(ret) == 0 {
+		return nil, errors.New("no tls secrets found")
+	}
+
+	return ret, nil
+}
+
+func inspectEndpoint(ctx context.Context, source Source, timeout time.Duration) Certificate {
+	name := getName(source, source.Location)
+
+	serverName := source.ServerName
+	if serverName == "" {
+		host, _, err := net.SplitHostPort(source.Location)
+		if err != nil {
+			return Certificate{Source: name, Error: err.Error()}
+		}
+		serverName = host
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config: &tls.Config{
+			ServerName: serverName,
+			// the certificate is only inspected, not trusted, so expired or otherwise invalid ones can be reported
+			InsecureSkipVerify: true, // #nosec G402
+			MinVersion:         tls.VersionTLS12,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", source.Location)
+	if err != nil {
+		return Certificate{Source: name, Error: err.Error()}
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return Certificate{Source: name, Error: "no certificate presented"}
+	}
+
+	return toCertificate(name, certs[0])
+}
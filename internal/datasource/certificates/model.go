@@ -0,0 +1,42 @@
+package certificates
+
+import (
+	"time"
+)
+
+const (
+	SourceFile     = "file"
+	SourceSecret   = "secret"
+	SourceEndpoint = "endpoint"
+)
+
+// Source describes where certificates are read from.
+type Source struct {
+	Name string
+	// Type is one of file, secret or endpoint.
+	Type string
+	// Location is a path or glob pattern for files and secrets and host:port for endpoints.
+	Location string
+	// ServerName optionally overrides the SNI sent to endpoints.
+	ServerName string
+}
+
+type Certificate struct {
+	Source   string
+	Subject  string
+	Sans     []string
+	Issuer   string
+	NotAfter time.Time
+	Error    string
+}
+
+type CertificateRow struct {
+	Certificate
+	DaysLeft int
+	CssClass string
+}
+
+type CertificatesData struct {
+	HtmlId       string
+	Certificates []CertificateRow
+}
@@ -0,0 +1,49 @@
+package certificates
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *CertificatesDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("certificates-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout for connecting to endpoints.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(ds *CertificatesDatasource) error {
+		if timeout < 100*time.Millisecond || timeout > time.Minute {
+			return errors.New("timeout must be [100ms, 1m]")
+		}
+
+		ds.timeout = timeout
+		return nil
+	}
+}
+
+// WithThresholdDays sets the number of days before expiry from which on certificates are added to the summary.
+func WithThresholdDays(days int) Opt {
+	return func(ds *CertificatesDatasource) error {
+		if days < 1 {
+			return errors.New("threshold days can not be < 1")
+		}
+
+		ds.threshold = time.Duration(days) * 24 * time.Hour
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package certificates
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+func buildRows(certs []Certificate, now time.Time) []CertificateRow {
+	rows := make([]CertificateRow, 0, len(certs))
+	for _, cert := range certs {
+		row := CertificateRow{Certificate: cert}
+		if cert.Error != "" {
+			row.CssClass = "red"
+		} else {
+			until := cert.NotAfter.Sub(now)
+			row.DaysLeft = int(math.Floor(until.Hours() / 24))
+			row.CssClass = getCssClass(until)
+		}
+		rows = append(rows, row)
+	}
+
+	// errors first, then the certificates expiring first
+	slices.SortStableFunc(rows, func(a, b CertificateRow) int {
+		if (a.Error != "") != (b.Error != "") {
+			if a.Error != "" {
+				return -1
+			}
+			return 1
+		}
+		if c := a.NotAfter.Compare(b.NotAfter); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	return rows
+}
+
+func getCssClass(until time.Duration) string {
+	if until <= 7*24*time.Hour {
+		return "red"
+	}
+	if until <= 14*24*time.Hour {
+		return "orange"
+	}
+	if until <= 30*24*time.Hour {
+		return "yellow"
+	}
+
+	return ""
+}
+
+func getCommonName(cert CertificateRow) string {
+	if len(cert.Sans) > 0 {
+		return cert.Sans[0]
+	}
+	return cert.Subject
+}
+
+func getSummary(rows []CertificateRow, threshold time.Duration, now time.Time) []string {
+	var summary []string
+	for _, row := range rows {
+		if row.Error != "" {
+			summary = append(summary, fmt.Sprintf("❌ Could not inspect certificate %s: %s", row.Source, row.Error))
+			continue
+		}
+
+		if row.NotAfter.Sub(now) > threshold {
+			continue
+		}
+
+		switch {
+		case row.NotAfter.Before(now):
+			summary = append(summary, fmt.Sprintf("🔐 Certificate %s (%s) has expired", row.Source, getCommonName(row)))
+		case row.DaysLeft == 1:
+			summary = append(summary, fmt.Sprintf("🔐 Certificate %s (%s) expires tomorrow", row.Source, getCommonName(row)))
+		case row.DaysLeft == 0:
+			summary = append(summary, fmt.Sprintf("🔐 Certificate %s (%s) expires today", row.Source, getCommonName(row)))
+		default:
+			summary = append(summary, fmt.Sprintf("🔐 Certificate %s (%s) expires in %d days", row.Source, getCommonName(row), row.DaysLeft))
+		}
+	}
+
+	return summary
+}
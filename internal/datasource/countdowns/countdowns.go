@@ -0,0 +1,112 @@
+package countdowns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const defaultLeadDays = 14
+
+type CountdownsDatasource struct {
+	items           []Item
+	defaultLeadDays int
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *CountdownsDatasource) error
+
+func New(items []Item, templateData templates.TemplateData, opts ...Opt) (*CountdownsDatasource, error) {
+	if len(items) == 0 {
+		return nil, errors.New("no items supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &CountdownsDatasource{
+		items:           items,
+		defaultLeadDays: defaultLeadDays,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("countdowns-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("countdowns-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for idx, item := range ds.items {
+		if item.Name == "" || item.Date.IsZero() {
+			errs = multierr.Append(errs, fmt.Errorf("name and date must be set for item %d", idx))
+		}
+		if item.EveryMonths < 0 || item.LeadDays < 0 {
+			errs = multierr.Append(errs, fmt.Errorf("recurrence and lead days can not be negative for item %q", item.Name))
+		}
+		if item.LeadDays == 0 {
+			ds.items[idx].LeadDays = ds.defaultLeadDays
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *CountdownsDatasource) Name() string {
+	return "Countdowns"
+}
+
+func (d *CountdownsDatasource) GetData(_ context.Context) (*internal.Data, error) {
+	countdowns := buildCountdowns(d.items, time.Now())
+	data := CountdownsData{
+		HtmlId:     pkg.NameToId(d.Name()),
+		Categories: groupByCategory(countdowns),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(countdowns)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
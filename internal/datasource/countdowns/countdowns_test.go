@@ -0,0 +1,166 @@
+package countdowns
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    int
+		wantErr bool
+	}{
+		{rule: "", want: 0},
+		{rule: "once", want: 0},
+		{rule: "monthly", want: 1},
+		{rule: "Yearly", want: 12},
+		{rule: "every month", want: 1},
+		{rule: "every 6 months", want: 6},
+		{rule: "every  2 years", want: 24},
+		{rule: "every 1 year", want: 12},
+		{rule: "every 0 months", wantErr: true},
+		{rule: "weekly", wantErr: true},
+		{rule: "every 3 weeks", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseRecurrence(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRecurrence() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRecurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	today := date(2025, 3, 15)
+
+	tests := []struct {
+		name      string
+		item      Item
+		want      time.Time
+		wantFound bool
+	}{
+		{
+			name:      "once in the future",
+			item:      Item{Date: date(2025, 6, 1)},
+			want:      date(2025, 6, 1),
+			wantFound: true,
+		},
+		{
+			name:      "once today",
+			item:      Item{Date: date(2025, 3, 15)},
+			want:      date(2025, 3, 15),
+			wantFound: true,
+		},
+		{
+			name: "once in the past",
+			item: Item{Date: date(2025, 3, 14)},
+		},
+		{
+			name:      "yearly",
+			item:      Item{Date: date(2019, 3, 10), EveryMonths: 12},
+			want:      date(2026, 3, 10),
+			wantFound: true,
+		},
+		{
+			name:      "yearly later this year",
+			item:      Item{Date: date(2019, 3, 20), EveryMonths: 12},
+			want:      date(2025, 3, 20),
+			wantFound: true,
+		},
+		{
+			name:      "every 2 years",
+			item:      Item{Date: date(2022, 1, 5), EveryMonths: 24},
+			want:      date(2026, 1, 5),
+			wantFound: true,
+		},
+		{
+			name:      "every 6 months",
+			item:      Item{Date: date(2023, 5, 1), EveryMonths: 6},
+			want:      date(2025, 5, 1),
+			wantFound: true,
+		},
+		{
+			name:      "monthly at end of month is clamped",
+			item:      Item{Date: date(2025, 1, 31), EveryMonths: 1},
+			want:      date(2025, 3, 31),
+			wantFound: true,
+		},
+		{
+			name:      "leap day",
+			item:      Item{Date: date(2024, 2, 29), EveryMonths: 12},
+			want:      date(2026, 2, 28),
+			wantFound: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := nextOccurrence(tt.item, today)
+			if found != tt.wantFound {
+				t.Fatalf("nextOccurrence() found = %v, want %v", found, tt.wantFound)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("nextOccurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// leap day in a non-leap year
+	got, _ := nextOccurrence(Item{Date: date(2024, 2, 29), EveryMonths: 12}, date(2025, 1, 1))
+	if want := date(2025, 2, 28); !got.Equal(want) {
+		t.Errorf("nextOccurrence() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildCountdowns(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 30, 0, 0, time.UTC)
+	items := []Item{
+		{Name: "Passport expires", Date: date(2025, 6, 17), LeadDays: 120, Category: "Documents", Emoji: "🛂"},
+		{Name: "Vehicle inspection", Date: date(2023, 3, 16), EveryMonths: 24, LeadDays: 30, Category: "Car"},
+		{Name: "Internet contract", Date: date(2024, 9, 1), EveryMonths: 12, LeadDays: 30, Category: "Contracts"},
+		{Name: "ID card", Date: date(2030, 1, 1), LeadDays: 60, Category: "Documents"},
+		{Name: "Old", Date: date(2025, 1, 1), LeadDays: 60},
+	}
+
+	countdowns := buildCountdowns(items, now)
+
+	var names []string
+	for _, countdown := range countdowns {
+		names = append(names, countdown.Name+" "+countdown.Humanized+" "+countdown.CssClass)
+	}
+	wantNames := []string{
+		"Vehicle inspection tomorrow red",
+		"Passport expires in 3 months, 4 days lightblue",
+		"Internet contract in 5 months, 20 days ",
+		"ID card in 4 years, 9 months, 23 days ",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("buildCountdowns() = %v, want %v", names, wantNames)
+	}
+
+	var categories []string
+	for _, category := range groupByCategory(countdowns) {
+		categories = append(categories, category.Name)
+	}
+	if want := []string{"Car", "Documents", "Contracts"}; !reflect.DeepEqual(categories, want) {
+		t.Errorf("groupByCategory() = %v, want %v", categories, want)
+	}
+
+	wantSummary := []string{
+		"⏳ Vehicle inspection tomorrow",
+		"🛂 Passport expires in 3 months, 4 days",
+	}
+	if got := getSummary(countdowns); !reflect.DeepEqual(got, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", got, wantSummary)
+	}
+}
@@ -0,0 +1,37 @@
+package countdowns
+
+import (
+	"time"
+)
+
+type Item struct {
+	Name string
+	// Date is the date of the first occurrence.
+	Date time.Time
+	// EveryMonths is the interval of recurring items, 0 for items that occur once.
+	EveryMonths int
+	// LeadDays is the number of days before an occurrence from which on the item is added to the summary.
+	LeadDays int
+	Category string
+	Emoji    string
+}
+
+type Countdown struct {
+	Item
+	Next          time.Time
+	Days          int
+	Humanized     string
+	DateFormatted string
+	WithinLead    bool
+	CssClass      string
+}
+
+type Category struct {
+	Name       string
+	Countdowns []Countdown
+}
+
+type CountdownsData struct {
+	HtmlId     string
+	Categories []Category
+}
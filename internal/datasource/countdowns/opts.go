@@ -0,0 +1,36 @@
+package countdowns
+
+import (
+	"errors"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *CountdownsDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("countdowns-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithDefaultLeadDays sets the lead days for items that do not define their own.
+func WithDefaultLeadDays(days int) Opt {
+	return func(ds *CountdownsDatasource) error {
+		if days < 0 {
+			return errors.New("lead days can not be negative")
+		}
+
+		ds.defaultLeadDays = days
+		return nil
+	}
+}
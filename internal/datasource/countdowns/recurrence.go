@@ -0,0 +1,78 @@
+package countdowns
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var everyRegex = regexp.MustCompile(`^every (\d+ )?(month|year)s?$`)
+
+// ParseRecurrence translates a recurrence rule such as "yearly" or "every 6 months" to an interval in months. An empty
+// rule or "once" returns 0.
+func ParseRecurrence(rule string) (int, error) {
+	rule = strings.ToLower(strings.Join(strings.Fields(rule), " "))
+	switch rule {
+	case "", "once":
+		return 0, nil
+	case "monthly":
+		return 1, nil
+	case "quarterly":
+		return 3, nil
+	case "yearly", "annually":
+		return 12, nil
+	}
+
+	match := everyRegex.FindStringSubmatch(rule)
+	if match == nil {
+		return 0, fmt.Errorf("invalid recurrence %q, expected e.g. yearly or every 6 months", rule)
+	}
+
+	count := 1
+	if match[1] != "" {
+		count, _ = strconv.Atoi(strings.TrimSpace(match[1]))
+		if count < 1 {
+			return 0, fmt.Errorf("invalid recurrence %q, interval must be at least 1", rule)
+		}
+	}
+
+	if match[2] == "year" {
+		return count * 12, nil
+	}
+	return count, nil
+}
+
+// addMonths adds months to the date, clamping the day to the end of the resulting month so that e.g. the 31st
+// of January plus a month is the last day of February.
+func addMonths(date time.Time, months int) time.Time {
+	month := int(date.Month()) - 1 + months
+	year := date.Year() + month/12
+	month = month%12 + 1
+
+	lastDay := time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, date.Location()).Day()
+	return time.Date(year, time.Month(month), min(date.Day(), lastDay), 0, 0, 0, 0, date.Location())
+}
+
+// nextOccurrence returns the next occurrence of the item at or after today, false if a one-time item has passed.
+func nextOccurrence(item Item, today time.Time) (time.Time, bool) {
+	date := time.Date(item.Date.Year(), item.Date.Month(), item.Date.Day(), 0, 0, 0, 0, today.Location())
+	if !date.Before(today) {
+		return date, true
+	}
+
+	if item.EveryMonths <= 0 {
+		return time.Time{}, false
+	}
+
+	// start shortly before today and step forward, computing every candidate from the original date to not
+	// accumulate clamped days
+	elapsed := (today.Year()-date.Year())*12 + int(today.Month()) - int(date.Month())
+	for k := elapsed / item.EveryMonths; ; k++ {
+		candidate := addMonths(date, k*item.EveryMonths)
+		if !candidate.Before(today) {
+			return candidate, true
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package countdowns
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+const defaultEmoji = "⏳"
+
+func buildCountdowns(items []Item, now time.Time) []Countdown {
+	today := pkg.Today(now)
+
+	var countdowns []Countdown
+	for _, item := range items {
+		next, found := nextOccurrence(item, today)
+		if !found {
+			continue
+		}
+
+		days := int(math.Round(next.Sub(today).Hours() / 24))
+		countdown := Countdown{
+			Item:          item,
+			Next:          next,
+			Days:          days,
+			Humanized:     humanize(days),
+			DateFormatted: formatDate(next, today),
+			WithinLead:    days <= item.LeadDays,
+		}
+		countdown.CssClass = getCssClass(countdown)
+		countdowns = append(countdowns, countdown)
+	}
+
+	slices.SortStableFunc(countdowns, func(a, b Countdown) int {
+		return a.Next.Compare(b.Next)
+	})
+
+	return countdowns
+}
+
+// groupByCategory groups the sorted countdowns, the categories are ordered by their next occurrence.
+func groupByCategory(countdowns []Countdown) []Category {
+	var categories []Category
+	for _, countdown := range countdowns {
+		idx := slices.IndexFunc(categories, func(c Category) bool {
+			return c.Name == countdown.Category
+		})
+		if idx < 0 {
+			categories = append(categories, Category{Name: countdown.Category})
+			idx = len(categories) - 1
+		}
+		categories[idx].Countdowns = append(categories[idx].Countdowns, countdown)
+	}
+	return categories
+}
+
+func humanize(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	default:
+		return "in " + pkg.DurationToString(time.Duration(days)*24*time.Hour)
+	}
+}
+
+func formatDate(date, today time.Time) string {
+	if date.Year() == today.Year() {
+		return date.Format("Mon, 02.01.")
+	}
+	return date.Format("Mon, 02.01.2006")
+}
+
+func getCssClass(countdown Countdown) string {
+	if !countdown.WithinLead {
+		return ""
+	}
+
+	switch {
+	case countdown.Days <= 1:
+		return "red"
+	case countdown.Days <= 3:
+		return "orange"
+	case countdown.Days <= 7:
+		return "yellow"
+	default:
+		return "lightblue"
+	}
+}
+
+func getSummary(countdowns []Countdown) []string {
+	var summary []string
+	for _, countdown := range countdowns {
+		if !countdown.WithinLead {
+			continue
+		}
+
+		emoji := countdown.Emoji
+		if emoji == "" {
+			emoji = defaultEmoji
+		}
+		summary = append(summary, fmt.Sprintf("%s %s %s", emoji, countdown.Name, countdown.Humanized))
+	}
+	return summary
+}
@@ -0,0 +1,173 @@
+package departures
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultDuration    = 60 * time.Minute
+	defaultLimit       = 8
+	defaultLeaveWindow = 5 * time.Minute
+)
+
+type DeparturesDatasource struct {
+	provider    Provider
+	stops       []Stop
+	duration    time.Duration
+	limit       int
+	leaveWindow time.Duration
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *DeparturesDatasource) error
+
+var funcMap = template.FuncMap{
+	"minutes": func(d time.Duration) int {
+		return int(d.Round(time.Minute).Minutes())
+	},
+}
+
+func New(provider Provider, stops []Stop, templateData templates.TemplateData, opts ...Opt) (*DeparturesDatasource, error) {
+	if provider == nil {
+		return nil, errors.New("nil provider passed")
+	}
+
+	if len(stops) == 0 {
+		return nil, errors.New("no stops supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &DeparturesDatasource{
+		provider:    provider,
+		stops:       stops,
+		duration:    defaultDuration,
+		limit:       defaultLimit,
+		leaveWindow: defaultLeaveWindow,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("departures-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("departures-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, stop := range ds.stops {
+		if stop.Id == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty id for stop %q", stop.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *DeparturesDatasource) Name() string {
+	return "Departures"
+}
+
+func (d *DeparturesDatasource) getDepartures(ctx context.Context, now time.Time) ([]StopDepartures, error) {
+	results := make([]*StopDepartures, len(d.stops))
+
+	var errs error
+	var mutex sync.Mutex
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(4)
+	for index, stop := range d.stops {
+		p.Go(func(ctx context.Context) error {
+			// also fetch the departures we can't catch anymore to be able to display them
+			departures, err := d.provider.GetDepartures(ctx, stop.Id, d.duration+stop.WalkingTime)
+			if err != nil {
+				log.Warn().Err(err).Str("stop", stop.Id).Msg("could not get departures")
+				mutex.Lock()
+				errs = multierr.Append(errs, err)
+				mutex.Unlock()
+				return nil
+			}
+
+			result := buildStopDepartures(stop, departures, d.limit, now)
+			results[index] = &result
+			return nil
+		})
+	}
+	_ = p.Wait()
+
+	ret := make([]StopDepartures, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			ret = append(ret, *result)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, errs
+	}
+
+	return ret, nil
+}
+
+func (d *DeparturesDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	now := time.Now()
+	stops, err := d.getDepartures(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	data := DeparturesData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Stops:  stops,
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(stops, d.leaveWindow, now)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package departures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// EfaProvider talks to the departure monitor of EFA backends using the rapidJSON output format, e.g.
+// https://efa.vvo-online.de/std3 or https://www.vrr.de/vrr-efa.
+type EfaProvider struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewEfaProvider(endpoint string, client *http.Client) (*EfaProvider, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &EfaProvider{
+		endpoint:   endpoint,
+		httpClient: client,
+	}, nil
+}
+
+type efaResponse struct {
+	StopEvents []efaStopEvent `json:"stopEvents"`
+}
+
+type efaStopEvent struct {
+	IsCancelled            bool       `json:"isCancelled"`
+	RealtimeStatus         []string   `json:"realtimeStatus"`
+	DepartureTimePlanned   *time.Time `json:"departureTimePlanned"`
+	DepartureTimeEstimated *time.Time `json:"departureTimeEstimated"`
+	Location               struct {
+		Properties struct {
+			Platform string `json:"platform"`
+		} `json:"properties"`
+	} `json:"location"`
+	Transportation struct {
+		Number           string `json:"number"`
+		DisassembledName string `json:"disassembledName"`
+		Destination      struct {
+			Name string `json:"name"`
+		} `json:"destination"`
+		Product struct {
+			Name string `json:"name"`
+		} `json:"product"`
+	} `json:"transportation"`
+}
+
+func (p *EfaProvider) GetDepartures(ctx context.Context, stopId string, duration time.Duration) ([]Departure, error) {
+	u, err := url.JoinPath(p.endpoint, "XML_DM_REQUEST")
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("outputFormat", "rapidJSON")
+	params.Set("type_dm", "stop")
+	params.Set("name_dm", stopId)
+	params.Set("mode", "direct")
+	params.Set("depArrMacro", "dep")
+	params.Set("useRealtime", "1")
+	params.Set("limit", "40")
+	params.Set("timeSpan", strconv.Itoa(int(duration.Minutes())))
+	u = u + "?" + params.Encode()
+
+	body, err := doRequest(ctx, p.httpClient, u)
+	if err != nil {
+		return nil, err
+	}
+
+	departures, err := parseEfaResponse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	// not all EFA instances honour the time span
+	limit := time.Now().Add(duration)
+	return slices.DeleteFunc(departures, func(dep Departure) bool {
+		return dep.When.After(limit)
+	}), nil
+}
+
+func parseEfaResponse(body []byte) ([]Departure, error) {
+	var resp efaResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse departures: %w", err)
+	}
+
+	ret := make([]Departure, 0, len(resp.StopEvents))
+	for _, event := range resp.StopEvents {
+		if event.DepartureTimePlanned == nil {
+			continue
+		}
+
+		line := event.Transportation.DisassembledName
+		if line == "" {
+			line = event.Transportation.Number
+		}
+
+		departure := Departure{
+			Line:      line,
+			Product:   event.Transportation.Product.Name,
+			Direction: event.Transportation.Destination.Name,
+			Platform:  event.Location.Properties.Platform,
+			Planned:   *event.DepartureTimePlanned,
+			When:      *event.DepartureTimePlanned,
+			Cancelled: event.IsCancelled || slices.Contains(event.RealtimeStatus, "TRIP_CANCELLED"),
+		}
+
+		if event.DepartureTimeEstimated != nil {
+			departure.When = *event.DepartureTimeEstimated
+			departure.Delay = departure.When.Sub(departure.Planned)
+		}
+
+		ret = append(ret, departure)
+	}
+
+	return ret, nil
+}
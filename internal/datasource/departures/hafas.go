@@ -0,0 +1,116 @@
+package departures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HafasProvider talks to HAFAS backends through the REST API of hafas-rest-api, e.g. v6.db.transport.rest or
+// v6.bvg.transport.rest.
+type HafasProvider struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewHafasProvider(endpoint string, client *http.Client) (*HafasProvider, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &HafasProvider{
+		endpoint:   endpoint,
+		httpClient: client,
+	}, nil
+}
+
+type hafasDeparture struct {
+	When            *time.Time `json:"when"`
+	PlannedWhen     *time.Time `json:"plannedWhen"`
+	Delay           *int       `json:"delay"`
+	Platform        *string    `json:"platform"`
+	PlannedPlatform *string    `json:"plannedPlatform"`
+	Direction       string     `json:"direction"`
+	Cancelled       bool       `json:"cancelled"`
+	Line            struct {
+		Name    string `json:"name"`
+		Product string `json:"product"`
+	} `json:"line"`
+}
+
+func (p *HafasProvider) GetDepartures(ctx context.Context, stopId string, duration time.Duration) ([]Departure, error) {
+	u, err := url.JoinPath(p.endpoint, "stops", url.PathEscape(stopId), "departures")
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("duration", strconv.Itoa(int(duration.Minutes())))
+	params.Set("remarks", "false")
+	u = u + "?" + params.Encode()
+
+	body, err := doRequest(ctx, p.httpClient, u)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseHafasResponse(body)
+}
+
+func parseHafasResponse(body []byte) ([]Departure, error) {
+	// hafas-rest-api v6 wraps the departures in an object, older versions return a plain array
+	var wrapped struct {
+		Departures []hafasDeparture `json:"departures"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		if err := json.Unmarshal(body, &wrapped.Departures); err != nil {
+			return nil, fmt.Errorf("could not parse departures: %w", err)
+		}
+	}
+
+	ret := make([]Departure, 0, len(wrapped.Departures))
+	for _, dep := range wrapped.Departures {
+		if dep.PlannedWhen == nil {
+			continue
+		}
+
+		departure := Departure{
+			Line:      dep.Line.Name,
+			Product:   dep.Line.Product,
+			Direction: dep.Direction,
+			Planned:   *dep.PlannedWhen,
+			When:      *dep.PlannedWhen,
+			Cancelled: dep.Cancelled,
+		}
+
+		// cancelled departures don't have a realtime departure
+		if dep.When != nil {
+			departure.When = *dep.When
+		}
+
+		if dep.Delay != nil {
+			departure.Delay = time.Duration(*dep.Delay) * time.Second
+		} else {
+			departure.Delay = departure.When.Sub(departure.Planned)
+		}
+
+		if dep.Platform != nil {
+			departure.Platform = *dep.Platform
+		} else if dep.PlannedPlatform != nil {
+			departure.Platform = *dep.PlannedPlatform
+		}
+
+		ret = append(ret, departure)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,45 @@
+package departures
+
+import (
+	"time"
+)
+
+// Stop is a stop departures are displayed for.
+type Stop struct {
+	Id   string
+	Name string
+	// Lines optionally restricts the displayed departures to the given line names, e.g. "U2" or "S41".
+	Lines []string
+	// WalkingTime is the time it takes to walk to the stop.
+	WalkingTime time.Duration
+}
+
+type Departure struct {
+	Line      string
+	Product   string
+	Direction string
+	Platform  string
+	Planned   time.Time
+	// When is the realtime departure, equals Planned if no realtime data is available.
+	When      time.Time
+	Delay     time.Duration
+	Cancelled bool
+}
+
+type DepartureRow struct {
+	Departure
+	LeaveAt   time.Time
+	Reachable bool
+	CssClass  string
+}
+
+type StopDepartures struct {
+	Name        string
+	WalkingTime time.Duration
+	Departures  []DepartureRow
+}
+
+type DeparturesData struct {
+	HtmlId string
+	Stops  []StopDepartures
+}
@@ -0,0 +1,62 @@
+package departures
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *DeparturesDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("departures-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithDuration sets how far into the future departures are displayed.
+func WithDuration(duration time.Duration) Opt {
+	return func(ds *DeparturesDatasource) error {
+		if duration < 10*time.Minute || duration > 12*time.Hour {
+			return errors.New("duration must be [10m, 12h]")
+		}
+
+		ds.duration = duration
+		return nil
+	}
+}
+
+// WithLimit sets the maximum number of departures displayed per stop.
+func WithLimit(limit int) Opt {
+	return func(ds *DeparturesDatasource) error {
+		if limit < 1 {
+			return errors.New("limit can not be < 1")
+		}
+
+		ds.limit = limit
+		return nil
+	}
+}
+
+// WithLeaveWindow sets the window before the latest time to leave in which a summary line is emitted. A window of 0
+// disables the summary line.
+func WithLeaveWindow(window time.Duration) Opt {
+	return func(ds *DeparturesDatasource) error {
+		if window < 0 {
+			return errors.New("leave window can not be negative")
+		}
+
+		ds.leaveWindow = window
+		return nil
+	}
+}
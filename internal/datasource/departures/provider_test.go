@@ -0,0 +1,136 @@
+package departures
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const hafasFixture = `{
+	"departures": [
+		{
+			"tripId": "1|1234",
+			"stop": {"type": "stop", "id": "900100003", "name": "S+U Alexanderplatz"},
+			"when": "2025-03-14T07:44:00+01:00",
+			"plannedWhen": "2025-03-14T07:42:00+01:00",
+			"delay": 120,
+			"platform": "2",
+			"plannedPlatform": "1",
+			"direction": "S+U Pankow",
+			"line": {"type": "line", "name": "U2", "product": "subway"}
+		},
+		{
+			"tripId": "1|5678",
+			"when": null,
+			"plannedWhen": "2025-03-14T07:50:00+01:00",
+			"delay": null,
+			"platform": null,
+			"plannedPlatform": "3",
+			"direction": "Ruhleben",
+			"cancelled": true,
+			"line": {"type": "line", "name": "U2", "product": "subway"}
+		}
+	],
+	"realtimeDataUpdatedAt": 1741934400
+}`
+
+const efaFixture = `{
+	"version": "10.5.17.3",
+	"stopEvents": [
+		{
+			"location": {"id": "de:14612:28", "name": "Hauptbahnhof", "properties": {"platform": "4"}},
+			"departureTimePlanned": "2025-03-14T06:40:00Z",
+			"departureTimeEstimated": "2025-03-14T06:46:00Z",
+			"realtimeStatus": ["MONITORED"],
+			"transportation": {
+				"number": "Tram 3",
+				"disassembledName": "3",
+				"product": {"name": "Straßenbahn"},
+				"destination": {"name": "Wilder Mann"}
+			}
+		},
+		{
+			"location": {"id": "de:14612:28", "name": "Hauptbahnhof", "properties": {}},
+			"departureTimePlanned": "2025-03-14T06:45:00Z",
+			"realtimeStatus": ["MONITORED", "TRIP_CANCELLED"],
+			"transportation": {
+				"number": "66",
+				"product": {"name": "Bus"},
+				"destination": {"name": "Lockwitz"}
+			}
+		}
+	]
+}`
+
+func TestHafasProvider_GetDepartures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stops/900100003/departures" || r.URL.Query().Get("duration") != "45" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(hafasFixture))
+	}))
+	defer server.Close()
+
+	provider, err := NewHafasProvider(server.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := provider.GetDepartures(context.Background(), "900100003", 45*time.Minute)
+	if err != nil {
+		t.Fatalf("GetDepartures() error = %v", err)
+	}
+
+	cet := time.FixedZone("", 3600)
+	want := []Departure{
+		{Line: "U2", Product: "subway", Direction: "S+U Pankow", Platform: "2", Planned: time.Date(2025, 3, 14, 7, 42, 0, 0, cet), When: time.Date(2025, 3, 14, 7, 44, 0, 0, cet), Delay: 2 * time.Minute},
+		{Line: "U2", Product: "subway", Direction: "Ruhleben", Platform: "3", Planned: time.Date(2025, 3, 14, 7, 50, 0, 0, cet), When: time.Date(2025, 3, 14, 7, 50, 0, 0, cet), Cancelled: true},
+	}
+	assertDepartures(t, got, want)
+}
+
+func TestParseHafasResponse_Array(t *testing.T) {
+	got, err := parseHafasResponse([]byte(`[{"plannedWhen": "2025-03-14T07:42:00+01:00", "when": "2025-03-14T07:42:00+01:00", "delay": 0, "direction": "Pankow", "line": {"name": "U2"}}]`))
+	if err != nil {
+		t.Fatalf("parseHafasResponse() error = %v", err)
+	}
+
+	if len(got) != 1 || got[0].Line != "U2" || got[0].Direction != "Pankow" {
+		t.Errorf("parseHafasResponse() = %+v", got)
+	}
+}
+
+func TestParseEfaResponse(t *testing.T) {
+	got, err := parseEfaResponse([]byte(efaFixture))
+	if err != nil {
+		t.Fatalf("parseEfaResponse() error = %v", err)
+	}
+
+	want := []Departure{
+		{Line: "3", Product: "Straßenbahn", Direction: "Wilder Mann", Platform: "4", Planned: time.Date(2025, 3, 14, 6, 40, 0, 0, time.UTC), When: time.Date(2025, 3, 14, 6, 46, 0, 0, time.UTC), Delay: 6 * time.Minute},
+		{Line: "66", Product: "Bus", Direction: "Lockwitz", Planned: time.Date(2025, 3, 14, 6, 45, 0, 0, time.UTC), When: time.Date(2025, 3, 14, 6, 45, 0, 0, time.UTC), Cancelled: true},
+	}
+	assertDepartures(t, got, want)
+}
+
+func assertDepartures(t *testing.T, got, want []Departure) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d departures, want %d", len(got), len(want))
+	}
+
+	for i := range got {
+		if !got[i].Planned.Equal(want[i].Planned) || !got[i].When.Equal(want[i].When) {
+			t.Errorf("departure %d: got times %v/%v, want %v/%v", i, got[i].Planned, got[i].When, want[i].Planned, want[i].When)
+		}
+		got[i].Planned, got[i].When = want[i].Planned, want[i].When
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("departure %d: got %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package departures
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func buildStopDepartures(stop Stop, departures []Departure, limit int, now time.Time) StopDepartures {
+	filtered := slices.DeleteFunc(slices.Clone(departures), func(dep Departure) bool {
+		if dep.When.Before(now) {
+			return true
+		}
+
+		return len(stop.Lines) > 0 && !slices.ContainsFunc(stop.Lines, func(line string) bool {
+			return strings.EqualFold(line, dep.Line)
+		})
+	})
+
+	slices.SortStableFunc(filtered, func(a, b Departure) int {
+		return a.When.Compare(b.When)
+	})
+
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+
+	rows := make([]DepartureRow, 0, len(filtered))
+	for _, dep := range filtered {
+		leaveAt := dep.When.Add(-stop.WalkingTime)
+		rows = append(rows, DepartureRow{
+			Departure: dep,
+			LeaveAt:   leaveAt,
+			Reachable: !leaveAt.Before(now),
+			CssClass:  getCssClass(dep),
+		})
+	}
+
+	name := stop.Name
+	if name == "" {
+		name = stop.Id
+	}
+
+	return StopDepartures{
+		Name:        name,
+		WalkingTime: stop.WalkingTime,
+		Departures:  rows,
+	}
+}
+
+func getCssClass(dep Departure) string {
+	switch {
+	case dep.Cancelled:
+		return "red"
+	case dep.Delay >= 5*time.Minute:
+		return "orange"
+	case dep.Delay >= time.Minute:
+		return "yellow"
+	default:
+		return ""
+	}
+}
+
+func getSummary(stops []StopDepartures, leaveWindow time.Duration, now time.Time) []string {
+	var summary []string
+	for _, stop := range stops {
+		leaveNowReported := false
+		for _, dep := range stop.Departures {
+			if !dep.Reachable {
+				continue
+			}
+
+			if dep.Cancelled {
+				summary = append(summary, fmt.Sprintf("❌ %s → %s at %s from %s is cancelled", dep.Line, dep.Direction, dep.Planned.Local().Format("15:04"), stop.Name))
+				continue
+			}
+
+			if !leaveNowReported && leaveWindow > 0 && dep.LeaveAt.Sub(now) <= leaveWindow {
+				leaveNowReported = true
+				summary = append(summary, fmt.Sprintf("🚶 Leave now for %s → %s, departs %s from %s", dep.Line, dep.Direction, dep.When.Local().Format("15:04"), stop.Name))
+			}
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,64 @@
+package departures
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	now := time.Date(2025, 3, 14, 7, 30, 0, 0, time.Local)
+	at := func(minutes int) time.Time {
+		return now.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	departures := []Departure{
+		{Line: "U2", Direction: "Pankow", Planned: at(12), When: at(12)},
+		{Line: "U2", Direction: "Pankow", Planned: at(-2), When: at(-1)},
+		{Line: "M48", Direction: "Zehlendorf", Planned: at(20), When: at(20)},
+		{Line: "U2", Direction: "Ruhleben", Planned: at(3), When: at(9), Delay: 6 * time.Minute},
+		{Line: "U2", Direction: "Ruhleben", Planned: at(5), When: at(5), Cancelled: true},
+		{Line: "u2", Direction: "Pankow", Planned: at(30), When: at(31), Delay: time.Minute},
+		{Line: "U2", Direction: "Ruhleben", Planned: at(40), When: at(40), Cancelled: true},
+	}
+
+	stop := Stop{Id: "900100003", Lines: []string{"U2"}, WalkingTime: 6 * time.Minute}
+	got := buildStopDepartures(stop, departures, 4, now)
+
+	want := StopDepartures{
+		Name:        "900100003",
+		WalkingTime: 6 * time.Minute,
+		Departures: []DepartureRow{
+			{Departure: departures[4], LeaveAt: at(-1), Reachable: false, CssClass: "red"},
+			{Departure: departures[3], LeaveAt: at(3), Reachable: true, CssClass: "orange"},
+			{Departure: departures[0], LeaveAt: at(6), Reachable: true},
+			{Departure: departures[5], LeaveAt: at(25), Reachable: true, CssClass: "yellow"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildStopDepartures() = %+v, want %+v", got, want)
+	}
+
+	wantSummary := []string{
+		"🚶 Leave now for U2 → Ruhleben, departs " + at(9).Format("15:04") + " from 900100003",
+	}
+	if summary := getSummary([]StopDepartures{got}, 5*time.Minute, now); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+
+	// the cancellation of a departure that can still be reached is reported
+	got = buildStopDepartures(Stop{Name: "Alexanderplatz"}, departures, 0, now)
+	wantSummary = []string{
+		"❌ U2 → Ruhleben at " + at(5).Format("15:04") + " from Alexanderplatz is cancelled",
+		"🚶 Leave now for U2 → Ruhleben, departs " + at(9).Format("15:04") + " from Alexanderplatz",
+		"❌ U2 → Ruhleben at " + at(40).Format("15:04") + " from Alexanderplatz is cancelled",
+	}
+	if summary := getSummary([]StopDepartures{got}, 10*time.Minute, now); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+
+	// disabled leave window
+	if summary := getSummary([]StopDepartures{got}, 0, now); len(summary) != 2 {
+		t.Errorf("expected only cancellations, got %v", summary)
+	}
+}
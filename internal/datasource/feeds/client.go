@@ -0,0 +1,37 @@
+package feeds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/mmcdole/gofeed"
+)
+
+const userAgent = "aether"
+
+// fetchFeed retrieves and parses a RSS, Atom or JSON feed.
+func fetchFeed(ctx context.Context, client *http.Client, url string) (*gofeed.Feed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response for feed %q: %s", url, resp.Status)
+	}
+
+	feed, err := gofeed.NewParser().Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse feed %q: %w", url, err)
+	}
+
+	return feed, nil
+}
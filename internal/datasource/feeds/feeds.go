@@ -0,0 +1,182 @@
+package feeds
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultWindow       = 24 * time.Hour
+	defaultNewFor       = 24 * time.Hour
+	defaultLimit        = 10
+	defaultSummaryItems = 5
+)
+
+type FeedsDatasource struct {
+	feeds        []Feed
+	httpClient   *http.Client
+	window       time.Duration
+	newFor       time.Duration
+	limit        int
+	summaryItems int
+
+	stateFile string
+	seen      seenItems
+	mutex     sync.Mutex
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *FeedsDatasource) error
+
+func New(feeds []Feed, templateData templates.TemplateData, opts ...Opt) (*FeedsDatasource, error) {
+	if len(feeds) == 0 {
+		return nil, errors.New("no feeds supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &FeedsDatasource{
+		feeds:        feeds,
+		httpClient:   http.DefaultClient,
+		window:       defaultWindow,
+		newFor:       defaultNewFor,
+		limit:        defaultLimit,
+		summaryItems: defaultSummaryItems,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("feeds-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("feeds-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, feed := range ds.feeds {
+		if feed.Url == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty url for feed %q", feed.Name))
+		}
+	}
+
+	ds.seen, err = loadSeenItems(ds.stateFile)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return ds, errs
+}
+
+func (d *FeedsDatasource) Name() string {
+	return "News"
+}
+
+func (d *FeedsDatasource) fetchFeeds(ctx context.Context) ([]fetchedFeed, error) {
+	fetched := make([]*fetchedFeed, len(d.feeds))
+
+	var errs error
+	var mutex sync.Mutex
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(8)
+	for index, feed := range d.feeds {
+		p.Go(func(ctx context.Context) error {
+			parsed, err := fetchFeed(ctx, d.httpClient, feed.Url)
+			if err != nil {
+				log.Warn().Err(err).Str("feed", feed.Url).Msg("could not fetch feed")
+				mutex.Lock()
+				errs = multierr.Append(errs, err)
+				mutex.Unlock()
+				return nil
+			}
+
+			name := feed.Name
+			if name == "" {
+				name = parsed.Title
+			}
+			fetched[index] = &fetchedFeed{name: name, feed: parsed}
+			return nil
+		})
+	}
+	_ = p.Wait()
+
+	// keep the configured order of the feeds
+	ret := make([]fetchedFeed, 0, len(fetched))
+	for _, f := range fetched {
+		if f != nil {
+			ret = append(ret, *f)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, errs
+	}
+
+	return ret, nil
+}
+
+func (d *FeedsDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	fetched, err := d.fetchFeeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	data := buildFeedsData(fetched, d.seen, d.window, d.newFor, d.limit, time.Now())
+	if err := saveSeenItems(d.stateFile, d.seen); err != nil {
+		log.Warn().Err(err).Str("file", d.stateFile).Msg("could not persist seen feed items")
+	}
+	d.mutex.Unlock()
+
+	data.HtmlId = pkg.NameToId(d.Name())
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data, d.summaryItems)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
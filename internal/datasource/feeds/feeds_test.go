@@ -0,0 +1,172 @@
+package feeds
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const rssFixture = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+<channel>
+	<title>RSS News</title>
+	<item>
+		<title>Old story</title>
+		<link>https://example.com/old</link>
+		<guid>rss-old</guid>
+		<pubDate>Mon, 10 Mar 2025 08:00:00 +0000</pubDate>
+	</item>
+	<item>
+		<title>Shared story</title>
+		<link>https://example.com/shared</link>
+		<pubDate>Fri, 14 Mar 2025 09:00:00 +0000</pubDate>
+	</item>
+	<item>
+		<title>Fresh story</title>
+		<link>https://example.com/fresh</link>
+		<guid>rss-fresh</guid>
+		<pubDate>Fri, 14 Mar 2025 11:00:00 +0000</pubDate>
+	</item>
+</channel>
+</rss>`
+
+const atomFixture = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+	<title>Atom Blog</title>
+	<entry>
+		<title>Shared story, again</title>
+		<link href="https://example.com/shared"/>
+		<updated>2025-03-14T09:30:00Z</updated>
+	</entry>
+	<entry>
+		<title>Atom post</title>
+		<link href="https://example.com/atom"/>
+		<id>atom-1</id>
+		<updated>2025-03-14T10:00:00Z</updated>
+	</entry>
+</feed>`
+
+const jsonFixture = `{
+	"version": "https://jsonfeed.org/version/1.1",
+	"title": "JSON Feed",
+	"items": [
+		{"id": "json-1", "title": "Undated item", "url": "https://example.com/json"}
+	]
+}`
+
+func TestFetchAndBuild(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rss":
+			_, _ = w.Write([]byte(rssFixture))
+		case "/atom":
+			_, _ = w.Write([]byte(atomFixture))
+		case "/json":
+			_, _ = w.Write([]byte(jsonFixture))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ds := &FeedsDatasource{
+		feeds: []Feed{
+			{Url: server.URL + "/rss"},
+			{Name: "Blog", Url: server.URL + "/atom"},
+			{Name: "Broken", Url: server.URL + "/missing"},
+			{Url: server.URL + "/json"},
+		},
+		httpClient: http.DefaultClient,
+	}
+
+	fetched, err := ds.fetchFeeds(context.Background())
+	if err != nil {
+		t.Fatalf("fetchFeeds() error = %v", err)
+	}
+
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	seen := seenItems{"json-1": now.Add(-2 * time.Hour)}
+	got := buildFeedsData(fetched, seen, 24*time.Hour, time.Hour, 10, now)
+
+	want := &FeedsData{
+		Feeds: []FeedGroup{
+			{
+				Name: "RSS News",
+				Items: []Item{
+					{Feed: "RSS News", Title: "Fresh story", Link: "https://example.com/fresh", Published: time.Date(2025, 3, 14, 11, 0, 0, 0, time.UTC), New: true},
+					{Feed: "RSS News", Title: "Shared story", Link: "https://example.com/shared", Published: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), New: true},
+				},
+			},
+			{
+				Name: "Blog",
+				Items: []Item{
+					{Feed: "Blog", Title: "Atom post", Link: "https://example.com/atom", Published: time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC), New: true},
+				},
+			},
+			{
+				Name: "JSON Feed",
+				Items: []Item{
+					{Feed: "JSON Feed", Title: "Undated item", Link: "https://example.com/json", Published: now.Add(-2 * time.Hour)},
+				},
+			},
+		},
+	}
+
+	// compare timestamps independent of their location
+	for _, group := range got.Feeds {
+		for i := range group.Items {
+			group.Items[i].Published = group.Items[i].Published.UTC()
+		}
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildFeedsData() = %+v, want %+v", got, want)
+	}
+
+	// all items are remembered, even the ones outside the window
+	if len(seen) != 5 {
+		t.Errorf("expected 5 seen items, got %d", len(seen))
+	}
+
+	// items stay new on the next refresh
+	got = buildFeedsData(fetched, seen, 24*time.Hour, time.Hour, 10, now.Add(time.Minute))
+	if !got.Feeds[0].Items[0].New {
+		t.Errorf("expected item to be new")
+	}
+
+	// items are not new anymore after they have been seen for longer than newFor
+	got = buildFeedsData(fetched, seen, 24*time.Hour, time.Hour, 10, now.Add(time.Hour))
+	if got.Feeds[0].Items[0].New {
+		t.Errorf("expected item to be seen")
+	}
+
+	wantSummary := []string{
+		"📰 RSS News: Fresh story",
+		"📰 Blog: Atom post",
+	}
+	if summary := getSummary(got, 2); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+}
+
+func Test_seenItems_prune(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	seen := seenItems{
+		"vanished-old": now.Add(-48 * time.Hour),
+		"current-old":  now.Add(-48 * time.Hour),
+		"vanished-new": now.Add(-1 * time.Hour),
+	}
+
+	seen.prune(now.Add(-24*time.Hour), map[string]struct{}{"current-old": {}})
+
+	want := seenItems{
+		"current-old":  now.Add(-48 * time.Hour),
+		"vanished-new": now.Add(-1 * time.Hour),
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("prune() = %v, want %v", seen, want)
+	}
+}
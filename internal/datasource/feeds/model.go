@@ -0,0 +1,29 @@
+package feeds
+
+import (
+	"time"
+)
+
+type Feed struct {
+	Name string
+	Url  string
+}
+
+type Item struct {
+	Feed      string
+	Title     string
+	Link      string
+	Published time.Time
+	// New is true if the item has been seen for the first time recently, see WithNewFor.
+	New bool
+}
+
+type FeedGroup struct {
+	Name  string
+	Items []Item
+}
+
+type FeedsData struct {
+	HtmlId string
+	Feeds  []FeedGroup
+}
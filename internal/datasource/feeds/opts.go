@@ -0,0 +1,98 @@
+package feeds
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *FeedsDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("feeds-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+func WithHttpClient(client *http.Client) Opt {
+	return func(ds *FeedsDatasource) error {
+		if client == nil {
+			return errors.New("empty http client provided")
+		}
+
+		ds.httpClient = client
+		return nil
+	}
+}
+
+// WithWindow sets the maximum age of items to be displayed.
+func WithWindow(window time.Duration) Opt {
+	return func(ds *FeedsDatasource) error {
+		if window < time.Hour {
+			return errors.New("window must be at least 1h")
+		}
+
+		ds.window = window
+		return nil
+	}
+}
+
+// WithNewFor sets how long items are marked as new after they have been seen for the first time. It should cover the
+// interval of the email, so the marker is still shown when the email is rendered.
+func WithNewFor(newFor time.Duration) Opt {
+	return func(ds *FeedsDatasource) error {
+		if newFor <= 0 {
+			return errors.New("new_for must be positive")
+		}
+
+		ds.newFor = newFor
+		return nil
+	}
+}
+
+// WithLimit sets the maximum number of items displayed per feed.
+func WithLimit(limit int) Opt {
+	return func(ds *FeedsDatasource) error {
+		if limit < 1 {
+			return errors.New("limit can not be < 1")
+		}
+
+		ds.limit = limit
+		return nil
+	}
+}
+
+// WithSummaryItems sets the number of headlines included in the summary.
+func WithSummaryItems(items int) Opt {
+	return func(ds *FeedsDatasource) error {
+		if items < 0 {
+			return errors.New("summary items can not be negative")
+		}
+
+		ds.summaryItems = items
+		return nil
+	}
+}
+
+// WithStateFile sets the file the seen items are persisted to.
+func WithStateFile(file string) Opt {
+	return func(ds *FeedsDatasource) error {
+		if file == "" {
+			return errors.New("empty state file supplied")
+		}
+
+		ds.stateFile = file
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package feeds
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func getItemKey(item *gofeed.Item) string {
+	if item.GUID != "" {
+		return item.GUID
+	}
+
+	if item.Link != "" {
+		return item.Link
+	}
+
+	return item.Title
+}
+
+func getItemTime(item *gofeed.Item) (time.Time, bool) {
+	if item.PublishedParsed != nil {
+		return *item.PublishedParsed, true
+	}
+
+	if item.UpdatedParsed != nil {
+		return *item.UpdatedParsed, true
+	}
+
+	return time.Time{}, false
+}
+
+type fetchedFeed struct {
+	name string
+	feed *gofeed.Feed
+}
+
+// buildFeedsData converts the fetched feeds into groups. Items are de-duplicated across all feeds, items that are
+// older than the window are dropped. Items without a date are considered to be published when they have been seen
+// for the first time. Items that have been seen for the first time within newFor are marked as new.
+func buildFeedsData(fetched []fetchedFeed, seen seenItems, window, newFor time.Duration, limit int, now time.Time) *FeedsData {
+	data := &FeedsData{}
+	cutoff := now.Add(-window)
+	newCutoff := now.Add(-newFor)
+	keys := map[string]struct{}{}
+
+	for _, f := range fetched {
+		group := FeedGroup{
+			Name: f.name,
+		}
+
+		for _, feedItem := range f.feed.Items {
+			key := getItemKey(feedItem)
+			if key == "" {
+				continue
+			}
+
+			if _, duplicate := keys[key]; duplicate {
+				continue
+			}
+			keys[key] = struct{}{}
+
+			firstSeen, known := seen[key]
+			if !known {
+				firstSeen = now
+				seen[key] = now
+			}
+
+			published, ok := getItemTime(feedItem)
+			if !ok {
+				published = firstSeen
+			}
+
+			if published.Before(cutoff) {
+				continue
+			}
+
+			group.Items = append(group.Items, Item{
+				Feed:      f.name,
+				Title:     feedItem.Title,
+				Link:      feedItem.Link,
+				Published: published,
+				New:       firstSeen.After(newCutoff),
+			})
+		}
+
+		sort.SliceStable(group.Items, func(i, j int) bool {
+			return group.Items[i].Published.After(group.Items[j].Published)
+		})
+
+		if len(group.Items) > limit {
+			group.Items = group.Items[:limit]
+		}
+
+		if len(group.Items) > 0 {
+			data.Feeds = append(data.Feeds, group)
+		}
+	}
+
+	// items that vanished from the feeds and are older than the window will not be displayed anymore
+	seen.prune(cutoff, keys)
+
+	return data
+}
+
+func getSummary(data *FeedsData, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	var items []Item
+	for _, group := range data.Feeds {
+		items = append(items, group.Items...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Published.After(items[j].Published)
+	})
+
+	if len(items) > n {
+		items = items[:n]
+	}
+
+	summary := make([]string, 0, len(items))
+	for _, item := range items {
+		summary = append(summary, fmt.Sprintf("📰 %s: %s", item.Feed, item.Title))
+	}
+
+	return summary
+}
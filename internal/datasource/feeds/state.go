@@ -0,0 +1,58 @@
+package feeds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+// seenItems maps the key of an item to the time it has been seen for the first time.
+type seenItems map[string]time.Time
+
+// prune removes all items that have been seen before the given time and that are not part of the current items.
+func (s seenItems) prune(before time.Time, current map[string]struct{}) {
+	for key, firstSeen := range s {
+		if _, found := current[key]; found {
+			continue
+		}
+		if firstSeen.Before(before) {
+			delete(s, key)
+		}
+	}
+}
+
+// loadSeenItems reads the seen items from the given file. A missing file yields no seen items.
+func loadSeenItems(file string) (seenItems, error) {
+	seen := seenItems{}
+	if file == "" {
+		return seen, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return seen, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &seen); err != nil {
+		return nil, fmt.Errorf("could not parse state file %q: %w", file, err)
+	}
+
+	return seen, nil
+}
+
+// saveSeenItems atomically writes the seen items to the given file.
+func saveSeenItems(file string, seen seenItems) error {
+	if file == "" {
+		return nil
+	}
+
+	return pkg.WriteJSONAtomic(file, seen)
+}
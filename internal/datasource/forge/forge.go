@@ -0,0 +1,177 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+type ForgeDatasource struct {
+	provider Provider
+	name     string
+	repos    []string
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *ForgeDatasource) error
+
+func New(provider Provider, templateData templates.TemplateData, opts ...Opt) (*ForgeDatasource, error) {
+	if provider == nil {
+		return nil, errors.New("empty provider supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &ForgeDatasource{
+		provider: provider,
+		name:     "Forge",
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("forge-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("forge-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *ForgeDatasource) Name() string {
+	return d.name
+}
+
+func (d *ForgeDatasource) getForgeData(ctx context.Context) (ForgeData, error) {
+	data := ForgeData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Name:   d.Name(),
+	}
+
+	var mutex sync.Mutex
+	var errs error
+	requests := 0
+	failed := 0
+	addErr := func(err error) {
+		log.Warn().Err(err).Str("forge", d.Name()).Msg("could not get data")
+		mutex.Lock()
+		defer mutex.Unlock()
+		failed++
+		errs = multierr.Append(errs, err)
+		data.Errors = append(data.Errors, err.Error())
+	}
+
+	p := pool.New().WithMaxGoroutines(4)
+
+	requests++
+	p.Go(func() {
+		items, err := d.provider.GetReviewRequests(ctx)
+		if err != nil {
+			addErr(fmt.Errorf("could not get review requests: %w", err))
+			return
+		}
+		mutex.Lock()
+		data.ReviewRequests = items
+		mutex.Unlock()
+	})
+
+	requests++
+	p.Go(func() {
+		items, err := d.provider.GetAssignedIssues(ctx)
+		if err != nil {
+			addErr(fmt.Errorf("could not get assigned issues: %w", err))
+			return
+		}
+		mutex.Lock()
+		data.AssignedIssues = items
+		mutex.Unlock()
+	})
+
+	for _, repo := range d.repos {
+		requests++
+		p.Go(func() {
+			build, err := d.provider.GetDefaultBranchBuild(ctx, repo)
+			if err != nil {
+				addErr(fmt.Errorf("could not get build state of %q: %w", repo, err))
+				return
+			}
+			if len(build.Failed) > 0 {
+				mutex.Lock()
+				data.FailingBuilds = append(data.FailingBuilds, build)
+				mutex.Unlock()
+			}
+		})
+	}
+
+	p.Wait()
+
+	if failed == requests {
+		return ForgeData{}, errs
+	}
+
+	slices.SortFunc(data.FailingBuilds, func(a, b Build) int {
+		return strings.Compare(a.Repo, b.Repo)
+	})
+	slices.Sort(data.Errors)
+
+	return data, nil
+}
+
+func (d *ForgeDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	data, err := d.getForgeData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
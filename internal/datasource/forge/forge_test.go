@@ -0,0 +1,281 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const githubSearchPulls = `{
+  "total_count": 2,
+  "incomplete_results": false,
+  "items": [
+    {
+      "url": "https://api.github.com/repos/acme/api/issues/42",
+      "repository_url": "https://api.github.com/repos/acme/api",
+      "html_url": "https://github.com/acme/api/pull/42",
+      "number": 42,
+      "title": "Add rate limiting",
+      "user": {"login": "alice"},
+      "state": "open",
+      "updated_at": "2025-03-02T10:00:00Z",
+      "pull_request": {"url": "https://api.github.com/repos/acme/api/pulls/42"}
+    },
+    {
+      "url": "https://api.github.com/repos/acme/web/issues/7",
+      "repository_url": "https://api.github.com/repos/acme/web",
+      "html_url": "https://github.com/acme/web/pull/7",
+      "number": 7,
+      "title": "Bump dependencies",
+      "user": {"login": "renovate[bot]"},
+      "state": "open",
+      "updated_at": "2025-03-01T08:30:00Z",
+      "pull_request": {"url": "https://api.github.com/repos/acme/web/pulls/7"}
+    }
+  ]
+}`
+
+const githubSearchIssues = `{
+  "total_count": 1,
+  "incomplete_results": false,
+  "items": [
+    {
+      "repository_url": "https://api.github.com/repos/acme/api",
+      "html_url": "https://github.com/acme/api/issues/40",
+      "number": 40,
+      "title": "Timeouts on login",
+      "user": {"login": "bob"},
+      "state": "open",
+      "updated_at": "2025-02-28T12:00:00Z"
+    }
+  ]
+}`
+
+const githubRepo = `{"id": 1, "full_name": "acme/api", "html_url": "https://github.com/acme/api", "default_branch": "main"}`
+
+const githubStatus = `{
+  "state": "failure",
+  "statuses": [
+    {"context": "ci/jenkins", "state": "failure", "target_url": "https://ci.example.com/1"},
+    {"context": "codecov", "state": "success"}
+  ],
+  "total_count": 2
+}`
+
+const githubCheckRuns = `{
+  "total_count": 3,
+  "check_runs": [
+    {"name": "test", "status": "completed", "conclusion": "failure"},
+    {"name": "lint", "status": "completed", "conclusion": "success"},
+    {"name": "build", "status": "in_progress", "conclusion": null}
+  ]
+}`
+
+const giteaSearchPulls = `[
+  {
+    "id": 11,
+    "number": 3,
+    "title": "Fix typo in README",
+    "html_url": "https://codeberg.org/acme/docs/pulls/3",
+    "user": {"login": "carol"},
+    "state": "open",
+    "updated_at": "2025-03-02T09:00:00+01:00",
+    "repository": {"id": 5, "name": "docs", "owner": "acme", "full_name": "acme/docs"},
+    "pull_request": {"merged": false}
+  }
+]`
+
+const giteaRepo = `{"id": 5, "full_name": "acme/docs", "html_url": "https://codeberg.org/acme/docs", "default_branch": "trunk"}`
+
+const giteaStatus = `{
+  "state": "failure",
+  "sha": "abc",
+  "statuses": [
+    {"context": "ci / build (push)", "status": "failure"},
+    {"context": "ci / lint (push)", "status": "success"}
+  ]
+}`
+
+func serveFixtures(t *testing.T, authorization string, fixtures map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		key := r.URL.Path
+		if q := r.URL.Query().Get("q"); q != "" {
+			key += "?" + q
+		} else if typ := r.URL.Query().Get("type"); typ != "" {
+			key += "?" + typ
+		}
+
+		fixture, found := fixtures[key]
+		if !found {
+			t.Logf("no fixture for %s", key)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+}
+
+func TestGithubProvider(t *testing.T) {
+	server := serveFixtures(t, "Bearer secret", map[string]string{
+		"/search/issues?is:open is:pr review-requested:@me archived:false": githubSearchPulls,
+		"/search/issues?is:open is:issue assignee:@me archived:false":      githubSearchIssues,
+		"/repos/acme/api":                         githubRepo,
+		"/repos/acme/api/commits/main/status":     githubStatus,
+		"/repos/acme/api/commits/main/check-runs": githubCheckRuns,
+	})
+	defer server.Close()
+
+	provider, err := NewGithubProvider(server.URL, "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pulls, err := provider.GetReviewRequests(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPulls := []Item{
+		{Repo: "acme/api", Number: 42, Title: "Add rate limiting", Url: "https://github.com/acme/api/pull/42", Author: "alice", Updated: time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC)},
+		{Repo: "acme/web", Number: 7, Title: "Bump dependencies", Url: "https://github.com/acme/web/pull/7", Author: "renovate[bot]", Updated: time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	if !reflect.DeepEqual(pulls, wantPulls) {
+		t.Errorf("GetReviewRequests() = %v, want %v", pulls, wantPulls)
+	}
+
+	issues, err := provider.GetAssignedIssues(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Repo != "acme/api" || issues[0].Number != 40 {
+		t.Errorf("GetAssignedIssues() = %v", issues)
+	}
+
+	build, err := provider.GetDefaultBranchBuild(context.Background(), "acme/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBuild := Build{Repo: "acme/api", Branch: "main", Failed: []string{"ci/jenkins", "test"}, Url: "https://github.com/acme/api/actions"}
+	if !reflect.DeepEqual(build, wantBuild) {
+		t.Errorf("GetDefaultBranchBuild() = %v, want %v", build, wantBuild)
+	}
+
+	unauthorized, _ := NewGithubProvider(server.URL, "wrong", server.Client())
+	if _, err := unauthorized.GetReviewRequests(context.Background()); err == nil {
+		t.Error("expected error for wrong token")
+	}
+}
+
+func TestGiteaProvider(t *testing.T) {
+	server := serveFixtures(t, "token secret", map[string]string{
+		"/api/v1/repos/issues/search?pulls":            giteaSearchPulls,
+		"/api/v1/repos/issues/search?issues":           `[]`,
+		"/api/v1/repos/acme/docs":                      giteaRepo,
+		"/api/v1/repos/acme/docs/commits/trunk/status": giteaStatus,
+	})
+	defer server.Close()
+
+	provider, err := NewGiteaProvider(server.URL, "secret", server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pulls, err := provider.GetReviewRequests(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPulls := []Item{
+		{Repo: "acme/docs", Number: 3, Title: "Fix typo in README", Url: "https://codeberg.org/acme/docs/pulls/3", Author: "carol", Updated: time.Date(2025, 3, 2, 9, 0, 0, 0, time.FixedZone("", 3600))},
+	}
+	if !reflect.DeepEqual(pulls, wantPulls) {
+		t.Errorf("GetReviewRequests() = %v, want %v", pulls, wantPulls)
+	}
+
+	issues, err := provider.GetAssignedIssues(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("GetAssignedIssues() = %v, want none", issues)
+	}
+
+	build, err := provider.GetDefaultBranchBuild(context.Background(), "acme/docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBuild := Build{Repo: "acme/docs", Branch: "trunk", Failed: []string{"ci / build (push)"}, Url: "https://codeberg.org/acme/docs/actions"}
+	if !reflect.DeepEqual(build, wantBuild) {
+		t.Errorf("GetDefaultBranchBuild() = %v, want %v", build, wantBuild)
+	}
+
+	if _, err := provider.GetDefaultBranchBuild(context.Background(), "docs"); err == nil {
+		t.Error("expected error for invalid repo")
+	}
+}
+
+type fakeProvider struct {
+	pulls  []Item
+	issues []Item
+	builds map[string]Build
+	err    error
+}
+
+func (f *fakeProvider) GetReviewRequests(_ context.Context) ([]Item, error) {
+	return f.pulls, f.err
+}
+
+func (f *fakeProvider) GetAssignedIssues(_ context.Context) ([]Item, error) {
+	return f.issues, f.err
+}
+
+func (f *fakeProvider) GetDefaultBranchBuild(_ context.Context, repo string) (Build, error) {
+	build, found := f.builds[repo]
+	if !found {
+		return Build{}, errors.New("not found")
+	}
+	return build, nil
+}
+
+func TestGetForgeData(t *testing.T) {
+	provider := &fakeProvider{
+		pulls:  []Item{{Repo: "acme/api", Number: 1}, {Repo: "acme/api", Number: 2}, {Repo: "acme/web", Number: 3}},
+		issues: []Item{{Repo: "acme/api", Number: 4}},
+		builds: map[string]Build{
+			"acme/web": {Repo: "acme/web", Branch: "main", Failed: []string{"test"}},
+			"acme/api": {Repo: "acme/api", Branch: "main"},
+		},
+	}
+
+	ds := &ForgeDatasource{provider: provider, name: "Forge", repos: []string{"acme/web", "acme/api", "acme/gone"}}
+	data, err := ds.getForgeData(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"👀 3 PRs awaiting your review",
+		"📌 1 issue assigned to you",
+		"❌ CI failing on main of acme/web",
+	}
+	if got := getSummary(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+	if len(data.Errors) != 1 {
+		t.Errorf("expected a single error, got %v", data.Errors)
+	}
+
+	// everything failing is an error
+	ds = &ForgeDatasource{provider: &fakeProvider{err: errors.New("unauthorized")}, name: "Forge"}
+	if _, err := ds.getForgeData(context.Background()); err == nil {
+		t.Error("expected error when all requests fail")
+	}
+}
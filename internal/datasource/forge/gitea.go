@@ -0,0 +1,139 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// GiteaProvider talks to the REST API of Gitea and Forgejo, the endpoint is the base URL of the instance.
+type GiteaProvider struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+func NewGiteaProvider(endpoint, token string, client *http.Client) (*GiteaProvider, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if token == "" {
+		return nil, errors.New("empty token supplied")
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &GiteaProvider{
+		endpoint:   endpoint,
+		token:      token,
+		httpClient: client,
+	}, nil
+}
+
+type giteaIssue struct {
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	HtmlUrl    string    `json:"html_url"`
+	Updated    time.Time `json:"updated_at"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+func (p *GiteaProvider) get(ctx context.Context, path string, params url.Values, target any) error {
+	u, err := url.JoinPath(p.endpoint, "/api/v1", path)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 {
+		u = u + "?" + params.Encode()
+	}
+
+	return getJson(ctx, p.httpClient, u, "token "+p.token, target)
+}
+
+func (p *GiteaProvider) search(ctx context.Context, params url.Values) ([]Item, error) {
+	params.Set("state", "open")
+	params.Set("limit", "50")
+
+	var issues []giteaIssue
+	if err := p.get(ctx, "/repos/issues/search", params, &issues); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, Item{
+			Repo:    issue.Repository.FullName,
+			Number:  issue.Number,
+			Title:   issue.Title,
+			Url:     issue.HtmlUrl,
+			Author:  issue.User.Login,
+			Updated: issue.Updated,
+		})
+	}
+
+	return items, nil
+}
+
+func (p *GiteaProvider) GetReviewRequests(ctx context.Context) ([]Item, error) {
+	params := url.Values{}
+	params.Set("type", "pulls")
+	params.Set("review_requested", "true")
+	return p.search(ctx, params)
+}
+
+func (p *GiteaProvider) GetAssignedIssues(ctx context.Context) ([]Item, error) {
+	params := url.Values{}
+	params.Set("type", "issues")
+	params.Set("assigned", "true")
+	return p.search(ctx, params)
+}
+
+func (p *GiteaProvider) GetDefaultBranchBuild(ctx context.Context, repo string) (Build, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return Build{}, err
+	}
+
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+		HtmlUrl       string `json:"html_url"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("/repos/%s/%s", owner, name), nil, &repository); err != nil {
+		return Build{}, err
+	}
+
+	build := Build{
+		Repo:   repo,
+		Branch: repository.DefaultBranch,
+		Url:    repository.HtmlUrl + "/actions",
+	}
+
+	// Gitea and Forgejo Actions as well as external CI systems report commit statuses
+	var status struct {
+		Statuses []struct {
+			Context string `json:"context"`
+			Status  string `json:"status"`
+		} `json:"statuses"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, name, url.PathEscape(repository.DefaultBranch)), nil, &status); err != nil {
+		return Build{}, err
+	}
+	for _, s := range status.Statuses {
+		if s.Status == "failure" || s.Status == "error" {
+			build.Failed = append(build.Failed, s.Context)
+		}
+	}
+
+	return build, nil
+}
@@ -0,0 +1,157 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const DefaultGithubEndpoint = "https://api.github.com"
+
+// GithubProvider talks to the REST API of GitHub and GitHub Enterprise, which is served below /api/v3.
+type GithubProvider struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+func NewGithubProvider(endpoint, token string, client *http.Client) (*GithubProvider, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if token == "" {
+		return nil, errors.New("empty token supplied")
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &GithubProvider{
+		endpoint:   endpoint,
+		token:      token,
+		httpClient: client,
+	}, nil
+}
+
+type githubIssue struct {
+	Number        int       `json:"number"`
+	Title         string    `json:"title"`
+	HtmlUrl       string    `json:"html_url"`
+	RepositoryUrl string    `json:"repository_url"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	User          struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+func (p *GithubProvider) get(ctx context.Context, path string, params url.Values, target any) error {
+	u, err := url.JoinPath(p.endpoint, path)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 {
+		u = u + "?" + params.Encode()
+	}
+
+	return getJson(ctx, p.httpClient, u, "Bearer "+p.token, target)
+}
+
+func (p *GithubProvider) search(ctx context.Context, query string) ([]Item, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("sort", "updated")
+	params.Set("per_page", "50")
+
+	var resp struct {
+		Items []githubIssue `json:"items"`
+	}
+	if err := p.get(ctx, "/search/issues", params, &resp); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(resp.Items))
+	for _, issue := range resp.Items {
+		// https://api.github.com/repos/owner/name
+		_, repo, _ := strings.Cut(issue.RepositoryUrl, "/repos/")
+		items = append(items, Item{
+			Repo:    repo,
+			Number:  issue.Number,
+			Title:   issue.Title,
+			Url:     issue.HtmlUrl,
+			Author:  issue.User.Login,
+			Updated: issue.UpdatedAt,
+		})
+	}
+
+	return items, nil
+}
+
+func (p *GithubProvider) GetReviewRequests(ctx context.Context) ([]Item, error) {
+	return p.search(ctx, "is:open is:pr review-requested:@me archived:false")
+}
+
+func (p *GithubProvider) GetAssignedIssues(ctx context.Context) ([]Item, error) {
+	return p.search(ctx, "is:open is:issue assignee:@me archived:false")
+}
+
+func (p *GithubProvider) GetDefaultBranchBuild(ctx context.Context, repo string) (Build, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return Build{}, err
+	}
+
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+		HtmlUrl       string `json:"html_url"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("/repos/%s/%s", owner, name), nil, &repository); err != nil {
+		return Build{}, err
+	}
+
+	build := Build{
+		Repo:   repo,
+		Branch: repository.DefaultBranch,
+		Url:    repository.HtmlUrl + "/actions",
+	}
+	ref := url.PathEscape(repository.DefaultBranch)
+
+	// legacy commit statuses, e.g. used by external CI systems
+	var status struct {
+		Statuses []struct {
+			Context string `json:"context"`
+			State   string `json:"state"`
+		} `json:"statuses"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status", owner, name, ref), nil, &status); err != nil {
+		return Build{}, err
+	}
+	for _, s := range status.Statuses {
+		if s.State == "failure" || s.State == "error" {
+			build.Failed = append(build.Failed, s.Context)
+		}
+	}
+
+	// check runs as used by GitHub Actions
+	var checks struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/check-runs", owner, name, ref), nil, &checks); err != nil {
+		return Build{}, err
+	}
+	for _, run := range checks.CheckRuns {
+		if run.Conclusion == "failure" || run.Conclusion == "timed_out" {
+			build.Failed = append(build.Failed, run.Name)
+		}
+	}
+
+	return build, nil
+}
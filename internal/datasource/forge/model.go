@@ -0,0 +1,34 @@
+package forge
+
+import (
+	"time"
+)
+
+// Item is either a pull request or an issue.
+type Item struct {
+	Repo    string
+	Number  int
+	Title   string
+	Url     string
+	Author  string
+	Updated time.Time
+}
+
+// Build is the CI state of the default branch of a repository.
+type Build struct {
+	Repo   string
+	Branch string
+	// Failed contains the names of the failed checks.
+	Failed []string
+	Url    string
+}
+
+type ForgeData struct {
+	HtmlId string
+	Name   string
+
+	ReviewRequests []Item
+	AssignedIssues []Item
+	FailingBuilds  []Build
+	Errors         []string
+}
@@ -0,0 +1,50 @@
+package forge
+
+import (
+	"errors"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *ForgeDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("forge-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithName sets the name of the datasource, which is useful when configuring multiple forges.
+func WithName(name string) Opt {
+	return func(ds *ForgeDatasource) error {
+		if len(name) == 0 {
+			return errors.New("empty name supplied")
+		}
+
+		ds.name = name
+		return nil
+	}
+}
+
+// WithRepos sets the repositories, given as owner/name, whose default branches are checked for failing CI.
+func WithRepos(repos []string) Opt {
+	return func(ds *ForgeDatasource) error {
+		for _, repo := range repos {
+			if _, _, err := splitRepo(repo); err != nil {
+				return err
+			}
+		}
+
+		ds.repos = repos
+		return nil
+	}
+}
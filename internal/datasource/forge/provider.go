@@ -0,0 +1,61 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type Provider interface {
+	// GetReviewRequests returns the open pull requests the user has been requested to review.
+	GetReviewRequests(ctx context.Context) ([]Item, error)
+	// GetAssignedIssues returns the open issues assigned to the user.
+	GetAssignedIssues(ctx context.Context) ([]Item, error)
+	// GetDefaultBranchBuild returns the CI state of the default branch of the repository given as owner/name.
+	GetDefaultBranchBuild(ctx context.Context, repo string) (Build, error)
+}
+
+func getJson(ctx context.Context, client *http.Client, url string, authorization string, target any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", authorization)
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "aether")
+
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("could not parse response of %q: %w", url, err)
+	}
+
+	return nil
+}
+
+func splitRepo(repo string) (string, string, error) {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository %q, expected owner/name", repo)
+	}
+	return owner, name, nil
+}
@@ -0,0 +1,30 @@
+package forge
+
+import (
+	"fmt"
+)
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+func getSummary(data ForgeData) []string {
+	var summary []string
+
+	if count := len(data.ReviewRequests); count > 0 {
+		summary = append(summary, fmt.Sprintf("👀 %d %s awaiting your review", count, pluralize(count, "PR", "PRs")))
+	}
+
+	if count := len(data.AssignedIssues); count > 0 {
+		summary = append(summary, fmt.Sprintf("📌 %d %s assigned to you", count, pluralize(count, "issue", "issues")))
+	}
+
+	for _, build := range data.FailingBuilds {
+		summary = append(summary, fmt.Sprintf("❌ CI failing on %s of %s", build.Branch, build.Repo))
+	}
+
+	return summary
+}
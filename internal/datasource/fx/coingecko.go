@@ -0,0 +1,68 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const coingeckoDefaultEndpoint = "https://api.coingecko.com/api/v3"
+
+// CoingeckoProvider retrieves crypto currency prices using the CoinGecko simple price API. Coins are identified by
+// their CoinGecko id, e.g. "bitcoin".
+type CoingeckoProvider struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewCoingeckoProvider(endpoint string, httpClient *http.Client) (*CoingeckoProvider, error) {
+	if httpClient == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	if endpoint == "" {
+		endpoint = coingeckoDefaultEndpoint
+	}
+
+	return &CoingeckoProvider{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (p *CoingeckoProvider) GetPrices(ctx context.Context, coins []string, currency string) (map[string]float64, error) {
+	u, err := url.Parse(p.endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	currency = strings.ToLower(currency)
+	u = u.JoinPath("simple", "price")
+	q := url.Values{}
+	q.Set("ids", strings.Join(coins, ","))
+	q.Set("vs_currencies", currency)
+	u.RawQuery = q.Encode()
+
+	body, err := get(ctx, p.httpClient, u.String())
+	if err != nil {
+		return nil, fmt.Errorf("could not get crypto prices: %w", err)
+	}
+
+	var resp map[string]map[string]float64
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse crypto prices: %w", err)
+	}
+
+	prices := make(map[string]float64, len(coins))
+	for _, coin := range coins {
+		if price, ok := resp[coin][currency]; ok {
+			prices[coin] = price
+		}
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,83 @@
+package fx
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const ecbDefaultEndpoint = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// EcbProvider retrieves the daily euro foreign exchange reference rates published by the European Central Bank.
+type EcbProvider struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewEcbProvider(endpoint string, httpClient *http.Client) (*EcbProvider, error) {
+	if httpClient == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	if endpoint == "" {
+		endpoint = ecbDefaultEndpoint
+	}
+
+	return &EcbProvider{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}, nil
+}
+
+type ecbEnvelope struct {
+	Cube struct {
+		Cube struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *EcbProvider) GetRates(ctx context.Context) (*Rates, error) {
+	body, err := get(ctx, p.httpClient, p.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not get ecb rates: %w", err)
+	}
+
+	return parseEcbResponse(body)
+}
+
+func parseEcbResponse(body []byte) (*Rates, error) {
+	var envelope ecbEnvelope
+	if err := xml.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("could not parse ecb response: %w", err)
+	}
+
+	date, err := time.Parse(time.DateOnly, envelope.Cube.Cube.Time)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse ecb date: %w", err)
+	}
+
+	rates := &Rates{
+		Date:  date,
+		Base:  "EUR",
+		Rates: make(map[string]float64, len(envelope.Cube.Cube.Rates)),
+	}
+
+	for _, rate := range envelope.Cube.Cube.Rates {
+		val, err := strconv.ParseFloat(rate.Rate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse rate for %q: %w", rate.Currency, err)
+		}
+		rates.Rates[rate.Currency] = val
+	}
+
+	return rates, nil
+}
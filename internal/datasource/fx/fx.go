@@ -0,0 +1,182 @@
+package fx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultBase            = "EUR"
+	defaultThreshold       = 1.
+	defaultCryptoThreshold = 5.
+)
+
+type FxDatasource struct {
+	provider       RatesProvider
+	cryptoProvider CryptoProvider
+	base           string
+	currencies     []string
+	coins          []string
+
+	stateFile string
+	state     *state
+	mutex     sync.Mutex
+
+	threshold          float64
+	cryptoThreshold    float64
+	defaultTemplate    *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *FxDatasource) error
+
+var funcMap = template.FuncMap{
+	"getCssClass": getCssClass,
+}
+
+func New(provider RatesProvider, currencies []string, templateData templates.TemplateData, opts ...Opt) (*FxDatasource, error) {
+	if provider == nil {
+		return nil, errors.New("empty provider supplied")
+	}
+
+	if len(currencies) == 0 {
+		return nil, errors.New("no currencies supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &FxDatasource{
+		provider:        provider,
+		currencies:      currencies,
+		base:            defaultBase,
+		threshold:       defaultThreshold,
+		cryptoThreshold: defaultCryptoThreshold,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("fx-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(ds.coins) > 0 && ds.cryptoProvider == nil {
+		errs = multierr.Append(errs, errors.New("coins supplied without crypto provider"))
+	}
+
+	ds.state, err = loadState(ds.stateFile)
+	if err != nil {
+		errs = multierr.Append(errs, err)
+	}
+
+	return ds, errs
+}
+
+func (d *FxDatasource) Name() string {
+	return "Exchange Rates"
+}
+
+func (d *FxDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	data, err := d.getFxData(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	data.HtmlId = pkg.NameToId(d.Name())
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data, d.threshold, d.cryptoThreshold)
+	}
+
+	return &internal.Data{
+		Summary:                 summary,
+		RenderedDefaultTemplate: defaultTemplateData.Bytes(),
+	}, nil
+}
+
+func (d *FxDatasource) getFxData(ctx context.Context, now time.Time) (*FxData, error) {
+	rates, err := d.provider.GetRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var prices map[string]float64
+	if len(d.coins) > 0 {
+		prices, err = d.cryptoProvider.GetPrices(ctx, d.coins, d.base)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not get crypto prices")
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	data := buildFxData(d.state, rates, prices, d.base, d.currencies, d.coins, now)
+	if err := saveState(d.stateFile, d.state); err != nil {
+		log.Warn().Err(err).Str("file", d.stateFile).Msg("could not persist exchange rates")
+	}
+
+	return data, nil
+}
+
+// buildFxData records the current values in the state and compares them against the values of the previous day.
+func buildFxData(s *state, rates *Rates, prices map[string]float64, base string, currencies, coins []string, now time.Time) *FxData {
+	data := &FxData{
+		Base: base,
+		Date: rates.Date,
+	}
+
+	values := map[string]float64{}
+	for _, currency := range currencies {
+		rate, ok := rates.Convert(base, currency)
+		if !ok {
+			log.Warn().Msgf("no exchange rate available for %s/%s", base, currency)
+			continue
+		}
+		values[fmt.Sprintf("%s/%s", base, currency)] = rate
+	}
+	s.Rates.update(rates.Date.Format(time.DateOnly), values)
+
+	for _, currency := range currencies {
+		name := fmt.Sprintf("%s/%s", base, currency)
+		if val, ok := values[name]; ok {
+			data.Rates = append(data.Rates, newQuote(name, val, &s.Rates))
+		}
+	}
+
+	if len(prices) > 0 {
+		// crypto currencies are traded continuously, their day-over-day change is based on local days
+		s.Crypto.update(now.Format(time.DateOnly), prices)
+		for _, coin := range coins {
+			if price, ok := prices[coin]; ok {
+				data.Crypto = append(data.Crypto, newQuote(coin, price, &s.Crypto))
+			}
+		}
+	}
+
+	return data
+}
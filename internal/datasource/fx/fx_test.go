@@ -0,0 +1,138 @@
+package fx
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_parseEcbResponse(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<gesmes:Sender>
+		<gesmes:name>European Central Bank</gesmes:name>
+	</gesmes:Sender>
+	<Cube>
+		<Cube time="2025-03-14">
+			<Cube currency="USD" rate="1.0882"/>
+			<Cube currency="JPY" rate="161.06"/>
+			<Cube currency="CHF" rate="0.9618"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`)
+
+	got, err := parseEcbResponse(body)
+	if err != nil {
+		t.Fatalf("parseEcbResponse() error = %v", err)
+	}
+
+	want := &Rates{
+		Date:  time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC),
+		Base:  "EUR",
+		Rates: map[string]float64{"USD": 1.0882, "JPY": 161.06, "CHF": 0.9618},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseEcbResponse() = %v, want %v", got, want)
+	}
+}
+
+func TestRates_Convert(t *testing.T) {
+	rates := &Rates{Base: "EUR", Rates: map[string]float64{"USD": 1.25, "CHF": 1}}
+
+	tests := []struct {
+		base     string
+		currency string
+		want     float64
+		wantOk   bool
+	}{
+		{base: "EUR", currency: "USD", want: 1.25, wantOk: true},
+		{base: "USD", currency: "EUR", want: 0.8, wantOk: true},
+		{base: "CHF", currency: "USD", want: 1.25, wantOk: true},
+		{base: "EUR", currency: "GBP", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.base+"/"+tt.currency, func(t *testing.T) {
+			got, ok := rates.Convert(tt.base, tt.currency)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("Convert() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_buildFxData(t *testing.T) {
+	s := &state{}
+	currencies := []string{"USD", "CHF"}
+	coins := []string{"bitcoin"}
+
+	day1 := time.Date(2025, 3, 13, 18, 0, 0, 0, time.UTC)
+	rates1 := &Rates{Date: time.Date(2025, 3, 13, 0, 0, 0, 0, time.UTC), Base: "EUR", Rates: map[string]float64{"USD": 1.0, "CHF": 0.96}}
+	got := buildFxData(s, rates1, map[string]float64{"bitcoin": 80000}, "EUR", currencies, coins, day1)
+	if got.Rates[0].HasChange || got.Crypto[0].HasChange {
+		t.Errorf("expected no changes without previous values")
+	}
+
+	// same date again, the previous values must not change
+	got = buildFxData(s, rates1, map[string]float64{"bitcoin": 81000}, "EUR", currencies, coins, day1.Add(time.Hour))
+	if got.Rates[0].HasChange || got.Crypto[0].HasChange {
+		t.Errorf("expected no changes for the same day")
+	}
+
+	day2 := day1.AddDate(0, 0, 1)
+	rates2 := &Rates{Date: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), Base: "EUR", Rates: map[string]float64{"USD": 1.02, "CHF": 0.96}}
+	got = buildFxData(s, rates2, map[string]float64{"bitcoin": 72900}, "EUR", currencies, coins, day2)
+
+	want := &FxData{
+		Base: "EUR",
+		Date: rates2.Date,
+		Rates: []Quote{
+			{Name: "EUR/USD", Value: 1.02, Change: 2.0000000000000018, HasChange: true},
+			{Name: "EUR/CHF", Value: 0.96, Change: 0, HasChange: true},
+		},
+		Crypto: []Quote{
+			{Name: "bitcoin", Value: 72900, Change: -9.999999999999998, HasChange: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildFxData() = %+v, want %+v", got, want)
+	}
+
+	wantSummary := []string{
+		"💱 EUR/USD +2.0% (1.0200)",
+		"🪙 bitcoin -10.0% (72900.00 EUR)",
+	}
+	if summary := getSummary(got, 1, 5); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+}
+
+func Test_state(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fx.json")
+
+	s, err := loadState(file)
+	if err != nil {
+		t.Fatalf("loadState() error = %v", err)
+	}
+
+	s.Rates.update("2025-03-13", map[string]float64{"EUR/USD": 1.0})
+	s.Rates.update("2025-03-14", map[string]float64{"EUR/USD": 1.1})
+	s.Rates.update("2025-03-12", map[string]float64{"EUR/USD": 0.5})
+	if err := saveState(file, s); err != nil {
+		t.Fatalf("saveState() error = %v", err)
+	}
+
+	loaded, err := loadState(file)
+	if err != nil {
+		t.Fatalf("loadState() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(loaded, s) {
+		t.Errorf("loadState() = %+v, want %+v", loaded, s)
+	}
+
+	if previous, ok := loaded.Rates.getPrevious("EUR/USD"); !ok || previous != 1.0 {
+		t.Errorf("getPrevious() = %v, %v, want 1.0", previous, ok)
+	}
+}
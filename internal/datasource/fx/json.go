@@ -0,0 +1,74 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// JsonProvider retrieves rates from an API returning a JSON object with the fields "base", "date" and "rates", as
+// offered by Frankfurter and many other exchange rate APIs.
+type JsonProvider struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+func NewJsonProvider(endpoint string, httpClient *http.Client) (*JsonProvider, error) {
+	if endpoint == "" {
+		return nil, errors.New("empty endpoint provided")
+	}
+
+	if httpClient == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &JsonProvider{
+		endpoint:   endpoint,
+		httpClient: httpClient,
+	}, nil
+}
+
+type jsonRates struct {
+	Base  string             `json:"base"`
+	Date  string             `json:"date"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+func (p *JsonProvider) GetRates(ctx context.Context) (*Rates, error) {
+	body, err := get(ctx, p.httpClient, p.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not get rates: %w", err)
+	}
+
+	return parseJsonResponse(body, time.Now())
+}
+
+func parseJsonResponse(body []byte, now time.Time) (*Rates, error) {
+	var resp jsonRates
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse rates: %w", err)
+	}
+
+	if resp.Base == "" || len(resp.Rates) == 0 {
+		return nil, errors.New("response contains no rates")
+	}
+
+	// not all APIs supply a date, assume the rates are current in that case
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if resp.Date != "" {
+		parsed, err := time.Parse(time.DateOnly, resp.Date)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse date: %w", err)
+		}
+		date = parsed
+	}
+
+	return &Rates{
+		Date:  date,
+		Base:  resp.Base,
+		Rates: resp.Rates,
+	}, nil
+}
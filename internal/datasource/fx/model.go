@@ -0,0 +1,44 @@
+package fx
+
+import "time"
+
+type FxData struct {
+	HtmlId string
+	Base   string
+	Date   time.Time
+	Rates  []Quote
+	Crypto []Quote
+}
+
+type Quote struct {
+	Name  string
+	Value float64
+	// Change is the change in percent compared to the previous day. It is only set if HasChange is true.
+	Change    float64
+	HasChange bool
+}
+
+func newQuote(name string, value float64, h *history) Quote {
+	quote := Quote{
+		Name:  name,
+		Value: value,
+	}
+
+	if previous, ok := h.getPrevious(name); ok {
+		quote.Change = (value/previous - 1) * 100
+		quote.HasChange = true
+	}
+
+	return quote
+}
+
+func getCssClass(percent float64) string {
+	switch {
+	case percent > 0:
+		return "green"
+	case percent < 0:
+		return "red"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,78 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *FxDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("fx-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+func WithBase(base string) Opt {
+	return func(ds *FxDatasource) error {
+		if len(base) != 3 {
+			return fmt.Errorf("invalid base currency %q", base)
+		}
+
+		ds.base = base
+		return nil
+	}
+}
+
+func WithCrypto(provider CryptoProvider, coins []string) Opt {
+	return func(ds *FxDatasource) error {
+		if provider == nil {
+			return errors.New("empty crypto provider supplied")
+		}
+
+		if len(coins) == 0 {
+			return errors.New("no coins supplied")
+		}
+
+		ds.cryptoProvider = provider
+		ds.coins = coins
+		return nil
+	}
+}
+
+// WithStateFile sets the file the values are persisted to, so day-over-day changes survive restarts.
+func WithStateFile(file string) Opt {
+	return func(ds *FxDatasource) error {
+		if file == "" {
+			return errors.New("empty state file supplied")
+		}
+
+		ds.stateFile = file
+		return nil
+	}
+}
+
+// WithThresholds sets the changes in percent that are required for a rate or a crypto currency to be mentioned in the
+// summary.
+func WithThresholds(threshold, cryptoThreshold float64) Opt {
+	return func(ds *FxDatasource) error {
+		if threshold < 0 || cryptoThreshold < 0 {
+			return errors.New("thresholds must not be negative")
+		}
+
+		ds.threshold = threshold
+		ds.cryptoThreshold = cryptoThreshold
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Rates holds the exchange rates for a single date. A rate describes how many units of a currency equal one unit of
+// the base currency.
+type Rates struct {
+	Date  time.Time
+	Base  string
+	Rates map[string]float64
+}
+
+// Convert returns the rate of the currency relative to the given base.
+func (r *Rates) Convert(base, currency string) (float64, bool) {
+	rate := func(c string) (float64, bool) {
+		if c == r.Base {
+			return 1, true
+		}
+		val, ok := r.Rates[c]
+		return val, ok && val > 0
+	}
+
+	baseRate, ok := rate(base)
+	if !ok {
+		return 0, false
+	}
+
+	currencyRate, ok := rate(currency)
+	if !ok {
+		return 0, false
+	}
+
+	return currencyRate / baseRate, true
+}
+
+type RatesProvider interface {
+	GetRates(ctx context.Context) (*Rates, error)
+}
+
+type CryptoProvider interface {
+	// GetPrices returns the price of each coin in the given currency.
+	GetPrices(ctx context.Context, coins []string, currency string) (map[string]float64, error)
+}
+
+func get(ctx context.Context, client *http.Client, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response: %s", resp.Status)
+	}
+
+	return body, nil
+}
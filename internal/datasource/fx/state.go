@@ -0,0 +1,89 @@
+package fx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+// snapshot holds the values of a single day.
+type snapshot struct {
+	Date   string             `json:"date"`
+	Values map[string]float64 `json:"values"`
+}
+
+// history keeps the values of the current and the previous day to calculate day-over-day changes.
+type history struct {
+	Current  snapshot `json:"current"`
+	Previous snapshot `json:"previous"`
+}
+
+// update records the values for the given date. Values of a newer date replace the current snapshot, which becomes
+// the previous snapshot. Values of older dates are ignored.
+func (h *history) update(date string, values map[string]float64) {
+	switch {
+	case date > h.Current.Date:
+		h.Previous = h.Current
+		h.Current = snapshot{Date: date, Values: copyValues(values)}
+	case date == h.Current.Date:
+		if h.Current.Values == nil {
+			h.Current.Values = map[string]float64{}
+		}
+		for key, val := range values {
+			h.Current.Values[key] = val
+		}
+	}
+}
+
+func (h *history) getPrevious(key string) (float64, bool) {
+	val, ok := h.Previous.Values[key]
+	return val, ok && val > 0
+}
+
+func copyValues(values map[string]float64) map[string]float64 {
+	ret := make(map[string]float64, len(values))
+	for key, val := range values {
+		ret[key] = val
+	}
+	return ret
+}
+
+type state struct {
+	Rates  history `json:"rates"`
+	Crypto history `json:"crypto"`
+}
+
+// loadState reads the state from the given file. A missing file yields an empty state.
+func loadState(file string) (*state, error) {
+	s := &state{}
+	if file == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("could not parse state file %q: %w", file, err)
+	}
+
+	return s, nil
+}
+
+// saveState atomically writes the state to the given file.
+func saveState(file string, s *state) error {
+	if file == "" {
+		return nil
+	}
+
+	return pkg.WriteJSONAtomic(file, s)
+}
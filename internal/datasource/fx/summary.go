@@ -0,0 +1,23 @@
+package fx
+
+import (
+	"fmt"
+	"math"
+)
+
+func getSummary(data *FxData, threshold, cryptoThreshold float64) []string {
+	var summary []string
+	for _, quote := range data.Rates {
+		if quote.HasChange && math.Abs(quote.Change) >= threshold {
+			summary = append(summary, fmt.Sprintf("💱 %s %+.1f%% (%.4f)", quote.Name, quote.Change, quote.Value))
+		}
+	}
+
+	for _, quote := range data.Crypto {
+		if quote.HasChange && math.Abs(quote.Change) >= cryptoThreshold {
+			summary = append(summary, fmt.Sprintf("🪙 %s %+.1f%% (%.2f %s)", quote.Name, quote.Change, quote.Value, data.Base))
+		}
+	}
+
+	return summary
+}
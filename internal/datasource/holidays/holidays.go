@@ -0,0 +1,135 @@
+package holidays
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/calendar"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const defaultHorizonDays = 60
+
+type VacationSource interface {
+	GetVacations(ctx context.Context) ([]Vacation, error)
+}
+
+type HolidaysDatasource struct {
+	calendar       *calendar.Calendar
+	vacations      []Vacation
+	vacationSource VacationSource
+	horizonDays    int
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *HolidaysDatasource) error
+
+func New(cal *calendar.Calendar, templateData templates.TemplateData, opts ...Opt) (*HolidaysDatasource, error) {
+	if cal == nil {
+		return nil, errors.New("no calendar supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &HolidaysDatasource{
+		calendar:    cal,
+		horizonDays: defaultHorizonDays,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("holidays-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("holidays-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, vacation := range ds.vacations {
+		if vacation.Name == "" || vacation.Start.IsZero() || vacation.End.IsZero() {
+			errs = multierr.Append(errs, errors.New("name, start and end must be set for vacations"))
+		} else if vacation.End.Before(vacation.Start) {
+			errs = multierr.Append(errs, fmt.Errorf("end of vacation %q is before its start", vacation.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *HolidaysDatasource) Name() string {
+	return "Holidays"
+}
+
+func (d *HolidaysDatasource) getVacations(ctx context.Context) []Vacation {
+	if d.vacationSource == nil {
+		return d.vacations
+	}
+
+	vacations, err := d.vacationSource.GetVacations(ctx)
+	if err != nil {
+		// public holidays are still useful without the vacations
+		log.Warn().Err(err).Str("datasource", d.Name()).Msg("could not get vacations")
+		return d.vacations
+	}
+
+	return append(vacations, d.vacations...)
+}
+
+func (d *HolidaysDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	holidays := d.calendar.Between(today, today.AddDate(0, 0, d.horizonDays))
+	entries := buildEntries(holidays, d.getVacations(ctx), today, d.horizonDays)
+	data := HolidaysData{
+		HtmlId:  pkg.NameToId(d.Name()),
+		Entries: entries,
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(entries, today)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
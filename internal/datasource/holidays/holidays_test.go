@@ -0,0 +1,167 @@
+package holidays
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/soerenschneider/aether/internal/calendar"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseIcs(t *testing.T) {
+	data := []byte("BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//test//test//EN\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:1\r\n" +
+		"DTSTAMP:20250101T000000Z\r\n" +
+		"SUMMARY:Sommerferien\r\n" +
+		"DTSTART;VALUE=DATE:20250801\r\n" +
+		"DTEND;VALUE=DATE:20250916\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:2\r\n" +
+		"DTSTAMP:20250101T000000Z\r\n" +
+		"SUMMARY:Buß- und Bettag\r\n" +
+		"DTSTART;VALUE=DATE:20251119\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n")
+
+	local := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	}
+
+	want := []Vacation{
+		{Name: "Sommerferien", Start: local(2025, 8, 1), End: local(2025, 9, 15)},
+		{Name: "Buß- und Bettag", Start: local(2025, 11, 19), End: local(2025, 11, 19)},
+	}
+
+	got, err := parseIcs(data)
+	if err != nil {
+		t.Fatalf("parseIcs() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIcs() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildEntries(t *testing.T) {
+	today := date(2025, 4, 17)
+	holidays := []calendar.Holiday{
+		{Name: "Karfreitag", Date: date(2025, 4, 18)},
+		{Name: "Ostermontag", Date: date(2025, 4, 21)},
+	}
+	vacations := []Vacation{
+		{Name: "Osterferien", Start: date(2025, 4, 14), End: date(2025, 4, 25)},
+		{Name: "Pfingstferien", Start: date(2025, 6, 10), End: date(2025, 6, 20)},
+		{Name: "Brückentag", Start: date(2025, 5, 2), End: date(2025, 5, 2)},
+		{Name: "Faschingsferien", Start: date(2025, 3, 3), End: date(2025, 3, 7)},
+		{Name: "Sommerferien", Start: date(2025, 8, 1), End: date(2025, 9, 15)},
+	}
+
+	want := []Entry{
+		{
+			Name:          "Osterferien",
+			Kind:          KindVacation,
+			Start:         date(2025, 4, 14),
+			End:           date(2025, 4, 25),
+			Ongoing:       true,
+			Humanized:     "until Fri, 25.04.",
+			DateFormatted: "Mon, 14.04. – Fri, 25.04. (12 days)",
+			CssClass:      "green",
+		},
+		{
+			Name:          "Karfreitag",
+			Kind:          KindHoliday,
+			Start:         date(2025, 4, 18),
+			End:           date(2025, 4, 18),
+			Days:          1,
+			Humanized:     "tomorrow",
+			DateFormatted: "Fri, 18.04.",
+			CssClass:      "lightblue",
+		},
+		{
+			Name:          "Ostermontag",
+			Kind:          KindHoliday,
+			Start:         date(2025, 4, 21),
+			End:           date(2025, 4, 21),
+			Days:          4,
+			Humanized:     "in 4 days",
+			DateFormatted: "Mon, 21.04.",
+		},
+		{
+			Name:          "Brückentag",
+			Kind:          KindVacation,
+			Start:         date(2025, 5, 2),
+			End:           date(2025, 5, 2),
+			Days:          15,
+			Humanized:     "in 15 days",
+			DateFormatted: "Fri, 02.05.",
+		},
+		{
+			Name:          "Pfingstferien",
+			Kind:          KindVacation,
+			Start:         date(2025, 6, 10),
+			End:           date(2025, 6, 20),
+			Days:          54,
+			Humanized:     "in 1 month, 24 days",
+			DateFormatted: "Tue, 10.06. – Fri, 20.06. (11 days)",
+		},
+	}
+
+	got := buildEntries(holidays, vacations, today, 60)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildEntries() = %v, want %v", got, want)
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	today := date(2025, 12, 24)
+	tests := []struct {
+		name      string
+		holidays  []calendar.Holiday
+		vacations []Vacation
+		want      []string
+	}{
+		{
+			name:     "holiday tomorrow",
+			holidays: []calendar.Holiday{{Name: "1. Weihnachtstag", Date: date(2025, 12, 25)}},
+			want:     []string{"🎉 Tomorrow is a public holiday: 1. Weihnachtstag"},
+		},
+		{
+			name:     "holiday today",
+			holidays: []calendar.Holiday{{Name: "Heiligabend", Date: date(2025, 12, 24)}},
+			want:     []string{"🎉 Today is a public holiday: Heiligabend"},
+		},
+		{
+			name:     "holiday later",
+			holidays: []calendar.Holiday{{Name: "Neujahr", Date: date(2026, 1, 1)}},
+		},
+		{
+			name: "vacations",
+			vacations: []Vacation{
+				{Name: "Weihnachtsferien", Start: date(2025, 12, 24), End: date(2026, 1, 5)},
+				{Name: "Skifreizeit", Start: date(2025, 12, 25), End: date(2025, 12, 28)},
+				{Name: "Herbstferien", Start: date(2025, 12, 20), End: date(2025, 12, 24)},
+				{Name: "Winterferien", Start: date(2025, 12, 20), End: date(2025, 12, 31)},
+			},
+			want: []string{
+				"🏫 Herbstferien end today",
+				"🏖️ Weihnachtsferien start today",
+				"🏖️ Skifreizeit start tomorrow",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := buildEntries(tt.holidays, tt.vacations, today, 30)
+			if got := getSummary(entries, today); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
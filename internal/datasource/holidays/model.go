@@ -0,0 +1,35 @@
+package holidays
+
+import (
+	"time"
+)
+
+const (
+	KindHoliday  = "holiday"
+	KindVacation = "vacation"
+)
+
+// Vacation is a range of days, both start and end are inclusive.
+type Vacation struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+type Entry struct {
+	Name  string
+	Kind  string
+	Start time.Time
+	End   time.Time
+	// Days until the start, 0 for entries that are ongoing.
+	Days          int
+	Ongoing       bool
+	Humanized     string
+	DateFormatted string
+	CssClass      string
+}
+
+type HolidaysData struct {
+	HtmlId  string
+	Entries []Entry
+}
@@ -0,0 +1,56 @@
+package holidays
+
+import (
+	"errors"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *HolidaysDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("holidays-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithVacations adds statically configured vacations.
+func WithVacations(vacations []Vacation) Opt {
+	return func(ds *HolidaysDatasource) error {
+		ds.vacations = vacations
+		return nil
+	}
+}
+
+// WithVacationSource reads vacations from the given source on each refresh, in addition to the static vacations.
+func WithVacationSource(source VacationSource) Opt {
+	return func(ds *HolidaysDatasource) error {
+		if source == nil {
+			return errors.New("empty vacation source supplied")
+		}
+
+		ds.vacationSource = source
+		return nil
+	}
+}
+
+// WithHorizonDays sets how many days ahead holidays and vacations are shown.
+func WithHorizonDays(days int) Opt {
+	return func(ds *HolidaysDatasource) error {
+		if days < 1 || days > 366 {
+			return errors.New("horizon must be between 1 and 366 days")
+		}
+
+		ds.horizonDays = days
+		return nil
+	}
+}
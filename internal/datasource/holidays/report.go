@@ -0,0 +1,122 @@
+package holidays
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/soerenschneider/aether/internal/calendar"
+	"github.com/soerenschneider/aether/pkg"
+)
+
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func buildEntries(holidays []calendar.Holiday, vacations []Vacation, today time.Time, horizon int) []Entry {
+	until := today.AddDate(0, 0, horizon)
+
+	var entries []Entry
+	for _, holiday := range holidays {
+		// holidays are at midnight UTC, move them to the same location as today
+		day := time.Date(holiday.Date.Year(), holiday.Date.Month(), holiday.Date.Day(), 0, 0, 0, 0, today.Location())
+		days := daysBetween(today, day)
+		entries = append(entries, Entry{
+			Name:          holiday.Name,
+			Kind:          KindHoliday,
+			Start:         day,
+			End:           day,
+			Days:          days,
+			Ongoing:       days == 0,
+			Humanized:     humanize(days),
+			DateFormatted: formatDate(day, today),
+			CssClass:      getCssClass(days),
+		})
+	}
+
+	for _, vacation := range vacations {
+		if vacation.End.Before(today) || vacation.Start.After(until) {
+			continue
+		}
+
+		entry := Entry{
+			Name:          vacation.Name,
+			Kind:          KindVacation,
+			Start:         vacation.Start,
+			End:           vacation.End,
+			DateFormatted: formatDate(vacation.Start, today),
+		}
+		if length := daysBetween(vacation.Start, vacation.End) + 1; length > 1 {
+			entry.DateFormatted = fmt.Sprintf("%s – %s (%d days)", entry.DateFormatted, formatDate(vacation.End, today), length)
+		}
+
+		if vacation.Start.After(today) {
+			entry.Days = daysBetween(today, vacation.Start)
+			entry.Humanized = humanize(entry.Days)
+		} else {
+			entry.Ongoing = true
+			entry.Humanized = "until " + formatDate(vacation.End, today)
+		}
+		entry.CssClass = getCssClass(entry.Days)
+		entries = append(entries, entry)
+	}
+
+	slices.SortStableFunc(entries, func(a, b Entry) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return entries
+}
+
+func humanize(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	default:
+		return "in " + pkg.DurationToString(time.Duration(days)*24*time.Hour)
+	}
+}
+
+func formatDate(date, today time.Time) string {
+	if date.Year() == today.Year() {
+		return date.Format("Mon, 02.01.")
+	}
+	return date.Format("Mon, 02.01.2006")
+}
+
+func getCssClass(days int) string {
+	switch days {
+	case 0:
+		return "green"
+	case 1:
+		return "lightblue"
+	default:
+		return ""
+	}
+}
+
+func getSummary(entries []Entry, today time.Time) []string {
+	var summary []string
+	for _, entry := range entries {
+		switch entry.Kind {
+		case KindHoliday:
+			if entry.Days == 0 {
+				summary = append(summary, fmt.Sprintf("🎉 Today is a public holiday: %s", entry.Name))
+			} else if entry.Days == 1 {
+				summary = append(summary, fmt.Sprintf("🎉 Tomorrow is a public holiday: %s", entry.Name))
+			}
+		case KindVacation:
+			if entry.Days == 1 {
+				summary = append(summary, fmt.Sprintf("🏖️ %s start tomorrow", entry.Name))
+			} else if entry.Start.Equal(today) {
+				summary = append(summary, fmt.Sprintf("🏖️ %s start today", entry.Name))
+			} else if entry.End.Equal(today) {
+				summary = append(summary, fmt.Sprintf("🏫 %s end today", entry.Name))
+			}
+		}
+	}
+	return summary
+}
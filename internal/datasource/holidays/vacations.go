@@ -0,0 +1,112 @@
+package holidays
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// IcsVacations reads school vacations from an iCalendar file or URL, as offered by many ministries of education.
+type IcsVacations struct {
+	location   string
+	httpClient *http.Client
+}
+
+func NewIcsVacations(location string, client *http.Client) (*IcsVacations, error) {
+	if location == "" {
+		return nil, errors.New("empty location supplied")
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &IcsVacations{
+		location:   location,
+		httpClient: client,
+	}, nil
+}
+
+func (s *IcsVacations) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		return os.ReadFile(s.location)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (s *IcsVacations) GetVacations(ctx context.Context) ([]Vacation, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ics from %q: %w", s.location, err)
+	}
+
+	return parseIcs(data)
+}
+
+func parseIcs(data []byte) ([]Vacation, error) {
+	cal, err := ical.NewDecoder(bytes.NewReader(data)).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse ics: %w", err)
+	}
+
+	var ret []Vacation
+	for _, event := range cal.Events() {
+		summary, err := event.Props.Text(ical.PropSummary)
+		if err != nil || summary == "" {
+			continue
+		}
+
+		start, err := event.DateTimeStart(time.Local)
+		if err != nil {
+			continue
+		}
+
+		end, err := event.DateTimeEnd(time.Local)
+		if err != nil || end.IsZero() {
+			end = start
+		} else if end.After(start) {
+			// the end of all-day events is exclusive
+			end = end.Add(-time.Nanosecond)
+		}
+
+		ret = append(ret, Vacation{
+			Name:  summary,
+			Start: toDay(start),
+			End:   toDay(end),
+		})
+	}
+
+	return ret, nil
+}
+
+func toDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,97 @@
+package homeassistant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/multierr"
+)
+
+type HomeAssistantClient struct {
+	httpClient *http.Client
+	endpoint   string
+	token      string
+}
+
+type ClientOpt func(client *HomeAssistantClient) error
+
+func NewClient(endpoint string, token string, opts ...ClientOpt) (*HomeAssistantClient, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	if token == "" {
+		return nil, errors.New("empty token supplied")
+	}
+
+	c := &HomeAssistantClient{
+		httpClient: http.DefaultClient,
+		endpoint:   endpoint,
+		token:      token,
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return c, errs
+}
+
+func WithHttpClient(client *http.Client) ClientOpt {
+	return func(c *HomeAssistantClient) error {
+		if client == nil {
+			return errors.New("empty http client provided")
+		}
+
+		c.httpClient = client
+		return nil
+	}
+}
+
+// GetStates returns the states of all entities known to Home Assistant.
+func (c *HomeAssistantClient) GetStates(ctx context.Context) ([]EntityState, error) {
+	u, err := url.JoinPath(c.endpoint, "/api/states")
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	request.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var states []EntityState
+	if err := json.Unmarshal(body, &states); err != nil {
+		return nil, fmt.Errorf("could not parse states: %w", err)
+	}
+
+	return states, nil
+}
@@ -0,0 +1,81 @@
+package homeassistant
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/rs/zerolog/log"
+)
+
+type compiledCondition struct {
+	program *vm.Program
+	message string
+}
+
+// buildEnv returns the functions that can be used in condition expressions, e.g.
+// `state("binary_sensor.window") == "on" && state("weather.home") == "rainy"` or `num("sensor.phone_battery") < 20`.
+func buildEnv(states map[string]EntityState) map[string]any {
+	return map[string]any{
+		"state": func(id string) string {
+			return states[id].State
+		},
+		// num returns NaN for unknown or non-numeric states, which lets every comparison evaluate to false
+		"num": func(id string) float64 {
+			val, ok := states[id].Number()
+			if !ok {
+				return math.NaN()
+			}
+			return val
+		},
+		"attr": func(id string, name string) any {
+			return states[id].Attributes[name]
+		},
+		"available": func(id string) bool {
+			return states[id].IsAvailable()
+		},
+	}
+}
+
+func compileConditions(conditions []Condition) ([]compiledCondition, error) {
+	env := buildEnv(nil)
+
+	ret := make([]compiledCondition, 0, len(conditions))
+	for _, condition := range conditions {
+		if condition.Message == "" {
+			return nil, fmt.Errorf("empty message for condition %q", condition.Expression)
+		}
+
+		program, err := expr.Compile(condition.Expression, expr.Env(env), expr.AsBool())
+		if err != nil {
+			return nil, fmt.Errorf("could not compile condition %q: %w", condition.Expression, err)
+		}
+
+		ret = append(ret, compiledCondition{
+			program: program,
+			message: condition.Message,
+		})
+	}
+
+	return ret, nil
+}
+
+func evaluateConditions(conditions []compiledCondition, states map[string]EntityState) []string {
+	env := buildEnv(states)
+
+	var summary []string
+	for _, condition := range conditions {
+		result, err := expr.Run(condition.program, env)
+		if err != nil {
+			log.Warn().Err(err).Str("condition", condition.message).Msg("could not evaluate condition")
+			continue
+		}
+
+		if matched, ok := result.(bool); ok && matched {
+			summary = append(summary, condition.message)
+		}
+	}
+
+	return summary
+}
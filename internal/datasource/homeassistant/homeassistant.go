@@ -0,0 +1,117 @@
+package homeassistant
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+type Client interface {
+	GetStates(ctx context.Context) ([]EntityState, error)
+}
+
+type HomeAssistantDatasource struct {
+	client     Client
+	entities   []Entity
+	conditions []compiledCondition
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *HomeAssistantDatasource) error
+
+func New(client Client, entities []Entity, templateData templates.TemplateData, opts ...Opt) (*HomeAssistantDatasource, error) {
+	if client == nil {
+		return nil, errors.New("nil client passed")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &HomeAssistantDatasource{
+		client:   client,
+		entities: entities,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("homeassistant-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("homeassistant-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if len(ds.entities) == 0 && len(ds.conditions) == 0 {
+		errs = multierr.Append(errs, errors.New("neither entities nor conditions supplied"))
+	}
+
+	return ds, errs
+}
+
+func (d *HomeAssistantDatasource) Name() string {
+	return "Home Assistant"
+}
+
+func (d *HomeAssistantDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	fetched, err := d.client.GetStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]EntityState, len(fetched))
+	for _, state := range fetched {
+		states[state.EntityId] = state
+	}
+
+	data := HomeAssistantData{
+		HtmlId:   pkg.NameToId(d.Name()),
+		Entities: buildEntityRows(d.entities, states),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if len(data.Entities) > 0 {
+		if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil && len(data.Entities) > 0 {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = evaluateConditions(d.conditions, states)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,143 @@
+package homeassistant
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const statesFixture = `[
+	{"entity_id": "binary_sensor.window", "state": "on", "attributes": {"friendly_name": "Kitchen Window", "device_class": "window"}, "last_changed": "2025-03-14T08:00:00+00:00"},
+	{"entity_id": "weather.home", "state": "rainy", "attributes": {"friendly_name": "Home", "temperature": 7.5}, "last_changed": "2025-03-14T07:00:00+00:00"},
+	{"entity_id": "sensor.phone_battery", "state": "15", "attributes": {"friendly_name": "Phone Battery", "unit_of_measurement": "%"}, "last_changed": "2025-03-14T09:00:00+00:00"},
+	{"entity_id": "sensor.co2", "state": "unavailable", "attributes": {"friendly_name": "CO2", "unit_of_measurement": "ppm"}, "last_changed": "2025-03-14T09:30:00+00:00"}
+]`
+
+func getStates(t *testing.T) map[string]EntityState {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/states" || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(statesFixture))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	fetched, err := client.GetStates(context.Background())
+	if err != nil {
+		t.Fatalf("GetStates() error = %v", err)
+	}
+
+	states := map[string]EntityState{}
+	for _, state := range fetched {
+		states[state.EntityId] = state
+	}
+	return states
+}
+
+func ptr(val float64) *float64 {
+	return &val
+}
+
+func TestBuildEntityRows(t *testing.T) {
+	states := getStates(t)
+
+	entities := []Entity{
+		{Id: "binary_sensor.window"},
+		{Id: "sensor.phone_battery", Name: "Phone", Warning: ptr(30), Critical: ptr(20), Below: true},
+		{Id: "sensor.co2", Warning: ptr(1000)},
+		{Id: "sensor.missing"},
+	}
+
+	want := []EntityRow{
+		{Id: "binary_sensor.window", Name: "Kitchen Window", State: "on", LastChanged: time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC)},
+		{Id: "sensor.phone_battery", Name: "Phone", State: "15", Unit: "%", LastChanged: time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC), CssClass: "red"},
+		{Id: "sensor.co2", Name: "CO2", State: "unavailable", Unit: "ppm", LastChanged: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC), CssClass: "yellow"},
+		{Id: "sensor.missing", Name: "sensor.missing", State: "unknown", CssClass: "yellow"},
+	}
+
+	got := buildEntityRows(entities, states)
+	for i := range got {
+		got[i].LastChanged = got[i].LastChanged.UTC()
+	}
+	for i := range want {
+		want[i].LastChanged = want[i].LastChanged.UTC()
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildEntityRows() = %+v, want %+v", got, want)
+	}
+}
+
+func TestGetCssClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		entity Entity
+		state  string
+		want   string
+	}{
+		{name: "no thresholds", entity: Entity{}, state: "21.5", want: ""},
+		{name: "non-numeric", entity: Entity{Warning: ptr(1)}, state: "on", want: ""},
+		{name: "below warning", entity: Entity{Warning: ptr(25), Critical: ptr(30)}, state: "21.5", want: "green"},
+		{name: "above warning", entity: Entity{Warning: ptr(25), Critical: ptr(30)}, state: "26", want: "orange"},
+		{name: "above critical", entity: Entity{Warning: ptr(25), Critical: ptr(30)}, state: "31", want: "red"},
+		{name: "inverted warning", entity: Entity{Warning: ptr(30), Critical: ptr(20), Below: true}, state: "25", want: "orange"},
+		{name: "inverted ok", entity: Entity{Warning: ptr(30), Critical: ptr(20), Below: true}, state: "80", want: "green"},
+		{name: "unavailable", entity: Entity{Warning: ptr(30)}, state: "unavailable", want: "yellow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getCssClass(tt.entity, EntityState{State: tt.state}); got != tt.want {
+				t.Errorf("getCssClass() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditions(t *testing.T) {
+	states := getStates(t)
+
+	conditions, err := compileConditions([]Condition{
+		{Expression: `state("binary_sensor.window") == "on" && state("weather.home") in ["rainy", "pouring"]`, Message: "🌧️ Window open while raining"},
+		{Expression: `num("sensor.phone_battery") < 20`, Message: "🪫 Battery below 20%"},
+		{Expression: `attr("weather.home", "temperature") < 0`, Message: "🥶 Freezing"},
+		{Expression: `num("sensor.co2") > 1000`, Message: "💨 Ventilate"},
+		{Expression: `!available("sensor.co2")`, Message: "CO2 sensor unavailable"},
+	})
+	if err != nil {
+		t.Fatalf("compileConditions() error = %v", err)
+	}
+
+	want := []string{
+		"🌧️ Window open while raining",
+		"🪫 Battery below 20%",
+		"CO2 sensor unavailable",
+	}
+	if got := evaluateConditions(conditions, states); !reflect.DeepEqual(got, want) {
+		t.Errorf("evaluateConditions() = %v, want %v", got, want)
+	}
+}
+
+func TestCompileConditions_Invalid(t *testing.T) {
+	invalid := []Condition{
+		{Expression: `state("x") ==`, Message: "syntax"},
+		{Expression: `num("x")`, Message: "not a bool"},
+		{Expression: `unknown("x")`, Message: "unknown function"},
+		{Expression: `state("x") == "on"`},
+	}
+	for _, condition := range invalid {
+		if _, err := compileConditions([]Condition{condition}); err == nil {
+			t.Errorf("expected error for condition %q", condition.Expression)
+		}
+	}
+}
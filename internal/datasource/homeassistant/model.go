@@ -0,0 +1,73 @@
+package homeassistant
+
+import (
+	"strconv"
+	"time"
+)
+
+// Entity is an entity that is configured to be displayed.
+type Entity struct {
+	Id string
+	// Name overrides the friendly name reported by Home Assistant.
+	Name string
+	// Warning and Critical are optional thresholds for numeric states.
+	Warning  *float64
+	Critical *float64
+	// Below inverts the thresholds, e.g. for battery levels.
+	Below bool
+}
+
+// Condition is an expression that adds Message to the summary if it evaluates to true.
+type Condition struct {
+	Expression string
+	Message    string
+}
+
+// EntityState is the relevant subset of a state object returned by Home Assistant's REST API.
+type EntityState struct {
+	EntityId    string         `json:"entity_id"`
+	State       string         `json:"state"`
+	Attributes  map[string]any `json:"attributes"`
+	LastChanged time.Time      `json:"last_changed"`
+}
+
+func (s EntityState) FriendlyName() string {
+	if name, ok := s.Attributes["friendly_name"].(string); ok {
+		return name
+	}
+	return s.EntityId
+}
+
+func (s EntityState) Unit() string {
+	if unit, ok := s.Attributes["unit_of_measurement"].(string); ok {
+		return unit
+	}
+	return ""
+}
+
+func (s EntityState) IsAvailable() bool {
+	return s.State != "" && s.State != "unknown" && s.State != "unavailable"
+}
+
+// Number returns the state as a number and whether the state is numeric at all.
+func (s EntityState) Number() (float64, bool) {
+	val, err := strconv.ParseFloat(s.State, 64)
+	if err != nil {
+		return 0, false
+	}
+	return val, true
+}
+
+type EntityRow struct {
+	Id          string
+	Name        string
+	State       string
+	Unit        string
+	LastChanged time.Time
+	CssClass    string
+}
+
+type HomeAssistantData struct {
+	HtmlId   string
+	Entities []EntityRow
+}
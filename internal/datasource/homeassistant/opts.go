@@ -0,0 +1,36 @@
+package homeassistant
+
+import (
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *HomeAssistantDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("homeassistant-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithConditions sets the expressions that are evaluated to generate summary lines.
+func WithConditions(conditions []Condition) Opt {
+	return func(ds *HomeAssistantDatasource) error {
+		compiled, err := compileConditions(conditions)
+		if err != nil {
+			return err
+		}
+
+		ds.conditions = compiled
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package homeassistant
+
+import (
+	"github.com/rs/zerolog/log"
+)
+
+func buildEntityRows(entities []Entity, states map[string]EntityState) []EntityRow {
+	rows := make([]EntityRow, 0, len(entities))
+	for _, entity := range entities {
+		state, found := states[entity.Id]
+		if !found {
+			log.Warn().Str("entity", entity.Id).Msg("entity not found in home assistant")
+			state = EntityState{EntityId: entity.Id, State: "unknown"}
+		}
+
+		name := entity.Name
+		if name == "" {
+			name = state.FriendlyName()
+		}
+
+		rows = append(rows, EntityRow{
+			Id:          entity.Id,
+			Name:        name,
+			State:       state.State,
+			Unit:        state.Unit(),
+			LastChanged: state.LastChanged,
+			CssClass:    getCssClass(entity, state),
+		})
+	}
+
+	return rows
+}
+
+func getCssClass(entity Entity, state EntityState) string {
+	if !state.IsAvailable() {
+		return "yellow"
+	}
+
+	if entity.Warning == nil && entity.Critical == nil {
+		return ""
+	}
+
+	val, ok := state.Number()
+	if !ok {
+		return ""
+	}
+
+	exceeds := func(threshold *float64) bool {
+		if threshold == nil {
+			return false
+		}
+		if entity.Below {
+			return val < *threshold
+		}
+		return val > *threshold
+	}
+
+	if exceeds(entity.Critical) {
+		return "red"
+	}
+
+	if exceeds(entity.Warning) {
+		return "orange"
+	}
+
+	return "green"
+}
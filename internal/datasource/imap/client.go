@@ -0,0 +1,170 @@
+package imap
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+)
+
+func (d *ImapDatasource) dial(account Account) (*client.Client, error) {
+	dialer := &net.Dialer{Timeout: d.timeout}
+
+	host, _, err := net.SplitHostPort(account.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		RootCAs:    d.rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if account.Security == SecurityStartTls {
+		c, err := client.DialWithDialer(dialer, account.Address)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.StartTLS(tlsConfig); err != nil {
+			_ = c.Logout()
+			return nil, fmt.Errorf("starttls failed: %w", err)
+		}
+		return c, nil
+	}
+
+	return client.DialWithDialerTLS(dialer, account.Address, tlsConfig)
+}
+
+func (d *ImapDatasource) fetchAccount(account Account) (AccountData, error) {
+	data := AccountData{Name: account.Name}
+	if data.Name == "" {
+		data.Name = account.Username
+	}
+
+	c, err := d.dial(account)
+	if err != nil {
+		return data, fmt.Errorf("could not connect to %q: %w", account.Address, err)
+	}
+	c.Timeout = d.timeout
+
+	defer func() {
+		_ = c.Logout()
+	}()
+
+	if err := c.Login(account.Username, account.Password); err != nil {
+		return data, fmt.Errorf("could not login to %q: %w", account.Address, err)
+	}
+
+	folders := account.Folders
+	if len(folders) == 0 {
+		folders = []string{"INBOX"}
+	}
+
+	for _, folder := range folders {
+		status, messages, err := d.fetchFolder(c, folder)
+		if err != nil {
+			return data, fmt.Errorf("could not read folder %q: %w", folder, err)
+		}
+
+		data.Folders = append(data.Folders, status)
+		data.Unread += status.Unread
+		data.Flagged += status.Flagged
+		data.Latest = append(data.Latest, messages...)
+	}
+
+	slices.SortStableFunc(data.Latest, func(a, b Message) int {
+		return b.Date.Compare(a.Date)
+	})
+	if len(data.Latest) > d.latest {
+		data.Latest = data.Latest[:d.latest]
+	}
+
+	return data, nil
+}
+
+// fetchFolder returns the status of the folder and the envelopes of its newest unread messages.
+func (d *ImapDatasource) fetchFolder(c *client.Client, folder string) (FolderStatus, []Message, error) {
+	mailbox, err := c.Select(folder, true)
+	if err != nil {
+		return FolderStatus{}, nil, err
+	}
+
+	status := FolderStatus{
+		Name:  folder,
+		Total: mailbox.Messages,
+	}
+	if mailbox.Messages == 0 {
+		return status, nil, nil
+	}
+
+	unseen, err := c.Search(&imap.SearchCriteria{WithoutFlags: []string{imap.SeenFlag}})
+	if err != nil {
+		return FolderStatus{}, nil, err
+	}
+	status.Unread = len(unseen)
+
+	flagged, err := c.Search(&imap.SearchCriteria{WithFlags: []string{imap.FlaggedFlag}})
+	if err != nil {
+		return FolderStatus{}, nil, err
+	}
+	status.Flagged = len(flagged)
+
+	if len(unseen) == 0 || d.latest == 0 {
+		return status, nil, nil
+	}
+
+	// sequence numbers are ascending by arrival, the newest messages are at the end
+	slices.Sort(unseen)
+	if len(unseen) > d.latest {
+		unseen = unseen[len(unseen)-d.latest:]
+	}
+
+	seqSet := new(imap.SeqSet)
+	seqSet.AddNum(unseen...)
+
+	ch := make(chan *imap.Message, len(unseen))
+	if err := c.Fetch(seqSet, []imap.FetchItem{imap.FetchEnvelope, imap.FetchInternalDate}, ch); err != nil {
+		return FolderStatus{}, nil, err
+	}
+
+	var messages []Message
+	for msg := range ch {
+		messages = append(messages, toMessage(folder, msg))
+	}
+
+	return status, messages, nil
+}
+
+func toMessage(folder string, msg *imap.Message) Message {
+	ret := Message{
+		Folder: folder,
+		Date:   msg.InternalDate,
+	}
+
+	if msg.Envelope != nil {
+		ret.Subject = msg.Envelope.Subject
+		if !msg.Envelope.Date.IsZero() {
+			ret.Date = msg.Envelope.Date
+		}
+		if len(msg.Envelope.From) > 0 {
+			ret.From = formatAddress(msg.Envelope.From[0])
+		}
+	}
+
+	if ret.Subject == "" {
+		ret.Subject = "(no subject)"
+	}
+
+	return ret
+}
+
+func formatAddress(address *imap.Address) string {
+	if address.PersonalName != "" {
+		return address.PersonalName
+	}
+	return address.Address()
+}
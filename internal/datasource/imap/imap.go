@@ -0,0 +1,161 @@
+package imap
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultTimeout = 15 * time.Second
+	defaultLatest  = 5
+)
+
+type ImapDatasource struct {
+	accounts []Account
+	timeout  time.Duration
+	latest   int
+	rootCAs  *x509.CertPool
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *ImapDatasource) error
+
+var validSecurity = []string{SecurityTls, SecurityStartTls}
+
+func New(accounts []Account, templateData templates.TemplateData, opts ...Opt) (*ImapDatasource, error) {
+	if len(accounts) == 0 {
+		return nil, errors.New("no accounts supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &ImapDatasource{
+		accounts: accounts,
+		timeout:  defaultTimeout,
+		latest:   defaultLatest,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("imap-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("imap-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for idx, account := range ds.accounts {
+		if account.Address == "" || account.Username == "" {
+			errs = multierr.Append(errs, fmt.Errorf("address and username must be set for account %q", account.Name))
+		}
+		if account.Security == "" {
+			ds.accounts[idx].Security = SecurityTls
+		} else if !slices.Contains(validSecurity, account.Security) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid security %q for account %q", account.Security, account.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *ImapDatasource) Name() string {
+	return "Mail"
+}
+
+func (d *ImapDatasource) fetchAll() ([]AccountData, error) {
+	accounts := make([]AccountData, len(d.accounts))
+	var mutex sync.Mutex
+	var errs error
+	var failed int
+
+	p := pool.New().WithMaxGoroutines(4)
+	for idx, account := range d.accounts {
+		p.Go(func() {
+			data, err := d.fetchAccount(account)
+			if err != nil {
+				log.Warn().Err(err).Str("account", data.Name).Msg("could not fetch mailbox")
+				data.Error = err.Error()
+			}
+			// every goroutine writes to its own index, only the error handling requires locking
+			accounts[idx] = data
+			if err != nil {
+				mutex.Lock()
+				failed++
+				errs = multierr.Append(errs, err)
+				mutex.Unlock()
+			}
+		})
+	}
+	p.Wait()
+
+	if failed == len(d.accounts) {
+		return nil, errs
+	}
+
+	return accounts, nil
+}
+
+func (d *ImapDatasource) GetData(_ context.Context) (*internal.Data, error) {
+	accounts, err := d.fetchAll()
+	if err != nil {
+		return nil, err
+	}
+
+	data := ImapData{
+		HtmlId:   pkg.NameToId(d.Name()),
+		Accounts: accounts,
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Accounts)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,243 @@
+package imap
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	goimap "github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/backend/memory"
+	"github.com/emersion/go-imap/server"
+)
+
+// generateCert returns a self-signed certificate for 127.0.0.1 and the path to its PEM encoded form.
+func generateCert(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, path
+}
+
+func newBackend(t *testing.T) *memory.Backend {
+	t.Helper()
+
+	be := memory.New()
+	user, err := be.Login(nil, "username", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := user.CreateMailbox("Work"); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := []struct {
+		folder  string
+		from    string
+		subject string
+		date    time.Time
+		flags   []string
+	}{
+		{folder: "INBOX", from: "Alice <alice@example.org>", subject: "Lunch?", date: time.Date(2025, 3, 2, 12, 0, 0, 0, time.UTC)},
+		{folder: "INBOX", from: "bob@example.org", subject: "Invoice", date: time.Date(2025, 3, 2, 14, 0, 0, 0, time.UTC), flags: []string{goimap.FlaggedFlag}},
+		{folder: "INBOX", from: "carol@example.org", subject: "Old news", date: time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC), flags: []string{goimap.SeenFlag, goimap.FlaggedFlag}},
+		{folder: "Work", from: "Jenkins <ci@example.com>", subject: "Build failed", date: time.Date(2025, 3, 2, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, msg := range messages {
+		mailbox, err := user.GetMailbox(msg.folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body := fmt.Sprintf("From: %s\r\nTo: username@example.org\r\nSubject: %s\r\nDate: %s\r\n\r\nHello", msg.from, msg.subject, msg.date.Format(time.RFC1123Z))
+		if err := mailbox.CreateMessage(msg.flags, msg.date, bytes.NewBufferString(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return be
+}
+
+// startServer starts an in-process IMAP server, either with implicit TLS or offering STARTTLS.
+func startServer(t *testing.T, implicitTls bool) (string, string) {
+	t.Helper()
+
+	cert, caFile := generateCert(t)
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	s := server.New(newBackend(t))
+	s.TLSConfig = tlsConfig
+
+	var listener net.Listener
+	var err error
+	if implicitTls {
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	} else {
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+
+	return listener.Addr().String(), caFile
+}
+
+func TestFetchAccount(t *testing.T) {
+	for _, security := range []string{SecurityTls, SecurityStartTls} {
+		t.Run(security, func(t *testing.T) {
+			addr, caFile := startServer(t, security == SecurityTls)
+
+			ds := &ImapDatasource{timeout: 5 * time.Second, latest: 2}
+			if err := WithTlsCaFile(caFile)(ds); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ds.fetchAccount(Account{
+				Name:     "Personal",
+				Address:  addr,
+				Username: "username",
+				Password: "password",
+				Security: security,
+				Folders:  []string{"INBOX", "Work"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := AccountData{
+				Name: "Personal",
+				Folders: []FolderStatus{
+					// the memory backend contains a seen message in INBOX by default
+					{Name: "INBOX", Total: 4, Unread: 2, Flagged: 2},
+					{Name: "Work", Total: 1, Unread: 1},
+				},
+				Unread:  3,
+				Flagged: 2,
+				Latest: []Message{
+					{Folder: "INBOX", Subject: "Invoice", From: "bob@example.org", Date: time.Date(2025, 3, 2, 14, 0, 0, 0, time.UTC)},
+					{Folder: "Work", Subject: "Build failed", From: "Jenkins", Date: time.Date(2025, 3, 2, 13, 0, 0, 0, time.UTC)},
+				},
+			}
+
+			for idx := range got.Latest {
+				if !got.Latest[idx].Date.Equal(want.Latest[idx].Date) {
+					t.Errorf("date of message %d = %v, want %v", idx, got.Latest[idx].Date, want.Latest[idx].Date)
+				}
+				got.Latest[idx].Date = want.Latest[idx].Date
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("fetchAccount() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	addr, caFile := startServer(t, true)
+
+	ds := &ImapDatasource{
+		timeout: 5 * time.Second,
+		latest:  5,
+		accounts: []Account{
+			{Name: "Personal", Address: addr, Username: "username", Password: "password", Security: SecurityTls},
+			{Name: "Wrong password", Address: addr, Username: "username", Password: "wrong", Security: SecurityTls},
+		},
+	}
+	if err := WithTlsCaFile(caFile)(ds); err != nil {
+		t.Fatal(err)
+	}
+
+	accounts, err := ds.fetchAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts[1].Error == "" {
+		t.Error("expected error for wrong password")
+	}
+
+	want := []string{"📬 2 unread mails in Personal, 2 flagged"}
+	if got := getSummary(accounts); !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+
+	// untrusted certificate
+	ds.rootCAs = nil
+	ds.accounts = ds.accounts[:1]
+	if _, err := ds.fetchAll(); err == nil {
+		t.Error("expected error for untrusted certificate")
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		accounts []AccountData
+		want     []string
+	}{
+		{
+			name:     "nothing unread",
+			accounts: []AccountData{{Name: "Personal", Flagged: 3}},
+		},
+		{
+			name: "single unread",
+			accounts: []AccountData{
+				{Name: "Personal", Unread: 1},
+				{Name: "Work", Error: "could not login"},
+			},
+			want: []string{"📬 1 unread mail in Personal"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.accounts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
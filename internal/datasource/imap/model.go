@@ -0,0 +1,52 @@
+package imap
+
+import (
+	"time"
+)
+
+const (
+	SecurityTls      = "tls"
+	SecurityStartTls = "starttls"
+)
+
+// Account describes a mailbox on an IMAP server.
+type Account struct {
+	Name string
+	// Address is the host:port of the IMAP server.
+	Address  string
+	Username string
+	Password string
+	// Security is either tls for implicit TLS or starttls.
+	Security string
+	// Folders are the folders that are checked, defaults to INBOX.
+	Folders []string
+}
+
+type Message struct {
+	Folder  string
+	Subject string
+	From    string
+	Date    time.Time
+}
+
+type FolderStatus struct {
+	Name    string
+	Total   uint32
+	Unread  int
+	Flagged int
+}
+
+type AccountData struct {
+	Name    string
+	Folders []FolderStatus
+	Unread  int
+	Flagged int
+	// Latest are the newest unread messages of all folders.
+	Latest []Message
+	Error  string
+}
+
+type ImapData struct {
+	HtmlId   string
+	Accounts []AccountData
+}
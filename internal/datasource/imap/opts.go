@@ -0,0 +1,68 @@
+package imap
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *ImapDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("imap-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithLatest sets the number of newest unread messages that are listed per account.
+func WithLatest(latest int) Opt {
+	return func(ds *ImapDatasource) error {
+		if latest < 0 || latest > 50 {
+			return errors.New("latest must be [0, 50]")
+		}
+
+		ds.latest = latest
+		return nil
+	}
+}
+
+func WithTimeout(timeout time.Duration) Opt {
+	return func(ds *ImapDatasource) error {
+		if timeout < time.Second || timeout > 2*time.Minute {
+			return errors.New("timeout must be [1s, 2m]")
+		}
+
+		ds.timeout = timeout
+		return nil
+	}
+}
+
+// WithTlsCaFile sets the CA certificates used to verify the servers, e.g. when using a private CA.
+func WithTlsCaFile(file string) Opt {
+	return func(ds *ImapDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("could not read ca file %q: %w", file, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in %q", file)
+		}
+
+		ds.rootCAs = pool
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package imap
+
+import (
+	"fmt"
+)
+
+func getSummary(accounts []AccountData) []string {
+	var summary []string
+	for _, account := range accounts {
+		if account.Error != "" || account.Unread == 0 {
+			continue
+		}
+
+		noun := "mails"
+		if account.Unread == 1 {
+			noun = "mail"
+		}
+
+		line := fmt.Sprintf("📬 %d unread %s in %s", account.Unread, noun, account.Name)
+		if account.Flagged > 0 {
+			line = fmt.Sprintf("%s, %d flagged", line, account.Flagged)
+		}
+		summary = append(summary, line)
+	}
+
+	return summary
+}
@@ -0,0 +1,157 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultLookback = 45 * time.Minute
+	timestampFormat = "01-02 15:04:05"
+)
+
+// Query describes a backend-agnostic log query.
+type Query struct {
+	Query string
+	Limit int
+	// Lookback defines the time window to search.
+	Lookback time.Duration
+	// Fields are additional fields or labels that are extracted from each log entry.
+	Fields []string
+}
+
+func (q *Query) GetLimit() int {
+	if q.Limit <= 0 || q.Limit > 500 {
+		return 25
+	}
+
+	return q.Limit
+}
+
+func (q *Query) GetLookback() time.Duration {
+	if q.Lookback <= 0 {
+		return defaultLookback
+	}
+
+	return q.Lookback
+}
+
+// LogsBackend queries a log storage and maps the results into LogEntry structs.
+type LogsBackend interface {
+	Query(ctx context.Context, query Query) ([]LogEntry, error)
+}
+
+type BackendOpt func(backend *httpBackend) error
+
+func WithHttpClient(client *http.Client) BackendOpt {
+	return func(backend *httpBackend) error {
+		if client == nil {
+			return errors.New("empty http client provided")
+		}
+
+		backend.httpClient = client
+		return nil
+	}
+}
+
+func WithBasicAuth(username, password string) BackendOpt {
+	return func(backend *httpBackend) error {
+		if username == "" {
+			return errors.New("empty username provided")
+		}
+
+		backend.username = username
+		backend.password = password
+		return nil
+	}
+}
+
+// httpBackend contains the functionality shared by all HTTP based backends.
+type httpBackend struct {
+	address    string
+	httpClient *http.Client
+	username   string
+	password   string
+}
+
+func newHttpBackend(address string, opts ...BackendOpt) (httpBackend, error) {
+	backend := httpBackend{
+		address:    address,
+		httpClient: http.DefaultClient,
+	}
+
+	if address == "" {
+		return backend, errors.New("empty address provided")
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(&backend); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return backend, errs
+}
+
+func (b *httpBackend) do(req *http.Request) ([]byte, error) {
+	if b.username != "" {
+		req.SetBasicAuth(b.username, b.password)
+	}
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response: %s - %s", resp.Status, body)
+	}
+
+	return body, nil
+}
+
+func newLogEntry(t time.Time, message string, fields map[string]string) LogEntry {
+	return LogEntry{
+		unix:      t.Unix(),
+		Timestamp: t.Local().Format(timestampFormat),
+		Message:   message,
+		Fields:    fields,
+		Count:     1,
+	}
+}
+
+// extractFields returns the values of the wanted fields using the supplied lookup function. Fields that are not
+// present are omitted.
+func extractFields(fields []string, lookup func(field string) (string, bool)) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	ret := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if val, ok := lookup(field); ok {
+			ret[field] = val
+		}
+	}
+	return ret
+}
+
+func sortLogEntries(logs []LogEntry) {
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].unix < logs[j].unix
+	})
+}
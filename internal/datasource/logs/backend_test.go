@@ -0,0 +1,218 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVictorialogsClient_Query(t *testing.T) {
+	fixture := `{"_time":"2025-03-01T12:00:05Z","_msg":"second","_stream":"{}","host":"db1","level":"error"}
+{"_time":"2025-03-01T12:00:00Z","_msg":"first","_stream":"{}","host":"web1"}
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/query" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("query"); got != "error AND _time:5m" {
+			t.Errorf("unexpected query %q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "10" {
+			t.Errorf("unexpected limit %q", got)
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewVictorialogsClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.Query(context.Background(), Query{Query: "error AND _time:5m", Limit: 10, Fields: []string{"host", "level"}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	want := []LogEntry{
+		newLogEntry(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), "first", map[string]string{"host": "web1"}),
+		newLogEntry(time.Date(2025, 3, 1, 12, 0, 5, 0, time.UTC), "second", map[string]string{"host": "db1", "level": "error"}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestLokiClient_Query(t *testing.T) {
+	fixture := `{
+		"status": "success",
+		"data": {
+			"resultType": "streams",
+			"result": [
+				{
+					"stream": {"job": "nginx"},
+					"values": [["1740830410000000000", "nginx error"], ["1740830400000000000", "nginx warning"]]
+				},
+				{
+					"stream": {"job": "sshd"},
+					"values": [["1740830405000000000", "sshd failure"]]
+				}
+			]
+		}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query_range" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Scope-OrgID"); got != "tenant" {
+			t.Errorf("unexpected tenant %q", got)
+		}
+		if got := r.URL.Query().Get("query"); got != `{job=~".+"} |= "error"` {
+			t.Errorf("unexpected query %q", got)
+		}
+		if r.URL.Query().Get("start") == "" || r.URL.Query().Get("end") == "" {
+			t.Error("expected start and end parameters")
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewLokiClient(server.URL, "tenant")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.Query(context.Background(), Query{Query: `{job=~".+"} |= "error"`, Fields: []string{"job"}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	want := []LogEntry{
+		newLogEntry(time.Unix(1740830400, 0), "nginx warning", map[string]string{"job": "nginx"}),
+		newLogEntry(time.Unix(1740830405, 0), "sshd failure", map[string]string{"job": "sshd"}),
+		newLogEntry(time.Unix(1740830410, 0), "nginx error", map[string]string{"job": "nginx"}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestLokiClient_QueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("parse error"))
+	}))
+	defer server.Close()
+
+	client, err := NewLokiClient(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Query(context.Background(), Query{Query: "invalid"}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestOpensearchClient_Query(t *testing.T) {
+	fixture := `{
+		"took": 3,
+		"timed_out": false,
+		"hits": {
+			"total": {"value": 2, "relation": "eq"},
+			"hits": [
+				{"_index": "logs", "_id": "2", "_source": {"@timestamp": "2025-03-01T12:00:05.123Z", "log": {"message": "nested message", "level": "error"}, "status": 500}},
+				{"_index": "logs", "_id": "1", "_source": {"@timestamp": "2025-03-01T12:00:00Z", "log.message": "flat message"}}
+			]
+		}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/logs-*/_search" {
+			t.Errorf("unexpected request %s %q", r.Method, r.URL.Path)
+		}
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "user" || pass != "pass" {
+			t.Error("expected basic auth")
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("could not decode request body: %v", err)
+		}
+		if body["size"] != float64(5) {
+			t.Errorf("unexpected size %v", body["size"])
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewOpensearchClient(server.URL, "logs-*", "", "log.message", WithBasicAuth("user", "pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := client.Query(context.Background(), Query{Query: "level:error", Limit: 5, Fields: []string{"log.level", "status"}})
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	want := []LogEntry{
+		newLogEntry(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), "flat message", map[string]string{}),
+		newLogEntry(time.Date(2025, 3, 1, 12, 0, 5, 123000000, time.UTC), "nested message", map[string]string{"log.level": "error", "status": "500"}),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Query() = %v, want %v", got, want)
+	}
+}
+
+func TestVictorialogsClient_QueryStats(t *testing.T) {
+	fixture := `{
+		"status": "success",
+		"data": {
+			"resultType": "matrix",
+			"result": [
+				{"metric": {"__name__": "hits", "service": "nginx"}, "values": [[1740830400, "3"], [1740834000, "12"]]},
+				{"metric": {"__name__": "hits", "service": "sshd"}, "values": [[1740834000, "1"]]}
+			]
+		}
+	}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/stats_query_range" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("query"); got != "error | stats by (service) count() hits" {
+			t.Errorf("unexpected query %q", got)
+		}
+		if got := r.URL.Query().Get("step"); got != "1h0m0s" {
+			t.Errorf("unexpected step %q", got)
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	client, err := NewVictorialogsClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1740835000, 0)
+	got, err := client.QueryStats(context.Background(), StatsQuery{Query: "error", GroupBy: "service", Step: time.Hour}, now.Add(-2*time.Hour), now)
+	if err != nil {
+		t.Fatalf("QueryStats() error = %v", err)
+	}
+
+	want := []Series{
+		{Labels: map[string]string{"service": "nginx"}, Timestamps: []int64{1740830400, 1740834000}, Values: []float64{3, 12}},
+		{Labels: map[string]string{"service": "sshd"}, Timestamps: []int64{1740834000}, Values: []float64{1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("QueryStats() = %v, want %v", got, want)
+	}
+}
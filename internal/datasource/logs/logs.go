@@ -0,0 +1,176 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultQueryName = "errors"
+	// DefaultQuery does not restrict the time, the window is given by the lookback of the query
+	DefaultQuery = "error"
+	defaultLimit = 20
+)
+
+type Opt func(datasource *LogsDatasource) error
+
+type LogsDatasource struct {
+	backend      LogsBackend
+	queries      []NamedQuery
+	statsQueries []StatsQuery
+
+	regularTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+func New(backend LogsBackend, templateData templates.TemplateData, opts ...Opt) (*LogsDatasource, error) {
+	if backend == nil {
+		return nil, errors.New("empty backend provided")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid template data: %w", err)
+	}
+
+	ds := &LogsDatasource{
+		backend: backend,
+		queries: []NamedQuery{
+			{
+				Name:     defaultQueryName,
+				Query:    DefaultQuery,
+				Limit:    defaultLimit,
+				Lookback: defaultLookback,
+			},
+		},
+	}
+
+	var err error
+	ds.regularTemplate, err = template.New("logs-regular").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("logs-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
+}
+
+func (c *LogsDatasource) Name() string {
+	return "Logs"
+}
+
+func (c *LogsDatasource) runQueries(ctx context.Context) ([]QueryResult, error) {
+	var errs error
+	results := make([]QueryResult, 0, len(c.queries))
+	for _, namedQuery := range c.queries {
+		query := Query{
+			Query:    namedQuery.Query,
+			Limit:    namedQuery.Limit,
+			Lookback: namedQuery.Lookback,
+			Fields:   namedQuery.Fields,
+		}
+
+		entries, err := c.backend.Query(ctx, query)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("query %q failed: %w", namedQuery.Name, err))
+			continue
+		}
+
+		results = append(results, buildQueryResult(namedQuery, query.GetLimit(), entries))
+	}
+
+	if len(results) == 0 {
+		return nil, errs
+	}
+
+	if errs != nil {
+		log.Warn().Err(errs).Msg("not all log queries succeeded")
+	}
+
+	return results, nil
+}
+
+func (c *LogsDatasource) runStatsQueries(ctx context.Context) []StatsResult {
+	if len(c.statsQueries) == 0 {
+		return nil
+	}
+
+	// existence is checked by WithStatsQueries
+	backend := c.backend.(StatsBackend)
+
+	now := time.Now()
+	ret := make([]StatsResult, 0, len(c.statsQueries))
+	for _, query := range c.statsQueries {
+		start, _ := getStatsBuckets(now, query.Window, query.Step)
+		series, err := backend.QueryStats(ctx, query, start, now)
+		if err != nil {
+			log.Warn().Err(err).Str("query", query.Name).Msg("could not run stats query")
+			continue
+		}
+
+		ret = append(ret, buildStatsResult(query, series, now))
+	}
+
+	return ret
+}
+
+func (c *LogsDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	results, err := c.runQueries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := c.runStatsQueries(ctx)
+
+	templateData := LogsData{
+		HtmlId:  pkg.NameToId(c.Name()),
+		Results: results,
+		Stats:   stats,
+	}
+
+	var regularTemplateData bytes.Buffer
+	if err := c.regularTemplate.Execute(&regularTemplateData, templateData); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", c.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if c.simpleTemplate != nil {
+		if err := c.simpleTemplate.Execute(&simpleTemplateData, templateData); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", c.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !c.excludeFromSummary {
+		summary = getSummary(results, stats)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    regularTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
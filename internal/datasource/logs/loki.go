@@ -0,0 +1,106 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LokiClient queries Grafana Loki using LogQL via the query_range API.
+type LokiClient struct {
+	httpBackend
+	tenantId string
+}
+
+func NewLokiClient(address string, tenantId string, opts ...BackendOpt) (*LokiClient, error) {
+	backend, err := newHttpBackend(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LokiClient{
+		httpBackend: backend,
+		tenantId:    tenantId,
+	}, nil
+}
+
+type lokiResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (c *LokiClient) Query(ctx context.Context, args Query) ([]LogEntry, error) {
+	endpoint, err := buildURL(c.address, "loki/api/v1/query_range")
+	if err != nil {
+		return nil, fmt.Errorf("could not build url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	q := url.Values{}
+	q.Set("query", args.Query)
+	q.Set("limit", strconv.Itoa(args.GetLimit()))
+	q.Set("start", strconv.FormatInt(now.Add(-args.GetLookback()).UnixNano(), 10))
+	q.Set("end", strconv.FormatInt(now.UnixNano(), 10))
+	q.Set("direction", "backward")
+	req.URL.RawQuery = q.Encode()
+	if c.tenantId != "" {
+		req.Header.Set("X-Scope-OrgID", c.tenantId)
+	}
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLokiResponse(body, args.Fields)
+}
+
+func parseLokiResponse(body []byte, fields []string) ([]LogEntry, error) {
+	var resp lokiResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse loki response: %w", err)
+	}
+
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("loki query failed with status %q", resp.Status)
+	}
+
+	if resp.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("unsupported loki result type %q, expected a log query", resp.Data.ResultType)
+	}
+
+	var logs []LogEntry
+	for _, stream := range resp.Data.Result {
+		// all entries of a stream share the same labels
+		extracted := extractFields(fields, func(field string) (string, bool) {
+			val, ok := stream.Stream[field]
+			return val, ok
+		})
+
+		for _, value := range stream.Values {
+			nanos, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			logs = append(logs, newLogEntry(time.Unix(0, nanos), value[1], extracted))
+		}
+	}
+
+	sortLogEntries(logs)
+	return logs, nil
+}
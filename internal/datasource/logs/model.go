@@ -1,28 +1,91 @@
 package logs
 
 import (
+	"html/template"
 	"net/url"
 	"path"
+	"time"
 )
 
 type LogEntry struct {
 	unix      int64
-	Timestamp string `json:"_time"`
-	Message   string `json:"_msg"`
+	Timestamp string
+	Message   string
+	Fields    map[string]string
+	// Count is the number of identical log entries this entry represents if grouping is enabled.
+	Count int
 }
 
-type VictorialogsQuery struct {
-	Address string
-	Query   string
-	Limit   int
+// NamedQuery is a query that is displayed in its own section.
+type NamedQuery struct {
+	Name     string
+	Query    string
+	Limit    int
+	Lookback time.Duration
+	// Fields are displayed as additional columns.
+	Fields []string
+	// SourceField is the field that identifies the source of a log entry. It defaults to the first entry of Fields.
+	SourceField string
+	// Group de-duplicates identical log entries and displays their count instead.
+	Group bool
 }
 
-func (q *VictorialogsQuery) GetLimit() int {
-	if q.Limit <= 0 || q.Limit > 500 {
-		return 25
+func (q *NamedQuery) GetSourceField() string {
+	if q.SourceField != "" {
+		return q.SourceField
 	}
 
-	return q.Limit
+	if len(q.Fields) > 0 {
+		return q.Fields[0]
+	}
+
+	return ""
+}
+
+type LogsData struct {
+	HtmlId  string
+	Results []QueryResult
+	Stats   []StatsResult
+}
+
+type StatsResult struct {
+	Name     string
+	GroupBy  string
+	Window   time.Duration
+	Baseline float64
+	Series   []SeriesResult
+}
+
+type SeriesResult struct {
+	// Name is the value of the field the stats are grouped by.
+	Name  string
+	Total float64
+	// Rate is the number of log entries per hour within the latest complete bucket.
+	Rate          float64
+	AboveBaseline bool
+	Sparkline     template.HTML
+}
+
+type QueryResult struct {
+	Name     string
+	Fields   []string
+	Grouped  bool
+	Lookback time.Duration
+	Entries  []LogEntry
+	// Total is the number of log entries before grouping. If Truncated is true, there are more log entries than
+	// the limit of the query.
+	Total     int
+	Truncated bool
+	TopSource string
+}
+
+// Columns returns the number of columns needed to display the result.
+func (r QueryResult) Columns() int {
+	columns := 2 + len(r.Fields)
+	if r.Grouped {
+		columns++
+	}
+	return columns
 }
 
 func buildURL(baseAddr, endpointPath string) (string, error) {
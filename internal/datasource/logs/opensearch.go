@@ -0,0 +1,153 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTimestampField = "@timestamp"
+	defaultMessageField   = "message"
+)
+
+// OpensearchClient queries OpenSearch or Elasticsearch using the _search API and a query_string query.
+type OpensearchClient struct {
+	httpBackend
+	index          string
+	timestampField string
+	messageField   string
+}
+
+func NewOpensearchClient(address, index, timestampField, messageField string, opts ...BackendOpt) (*OpensearchClient, error) {
+	backend, err := newHttpBackend(address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if index == "" {
+		return nil, fmt.Errorf("empty index provided")
+	}
+
+	if timestampField == "" {
+		timestampField = defaultTimestampField
+	}
+
+	if messageField == "" {
+		messageField = defaultMessageField
+	}
+
+	return &OpensearchClient{
+		httpBackend:    backend,
+		index:          index,
+		timestampField: timestampField,
+		messageField:   messageField,
+	}, nil
+}
+
+type opensearchResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source map[string]any `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (c *OpensearchClient) buildRequestBody(args Query) map[string]any {
+	return map[string]any{
+		"size": args.GetLimit(),
+		"sort": []any{
+			map[string]any{c.timestampField: map[string]string{"order": "desc"}},
+		},
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{"query_string": map[string]string{"query": args.Query}},
+				},
+				"filter": []any{
+					map[string]any{"range": map[string]any{
+						c.timestampField: map[string]string{"gte": fmt.Sprintf("now-%ds", int(args.GetLookback().Seconds()))},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (c *OpensearchClient) Query(ctx context.Context, args Query) ([]LogEntry, error) {
+	endpoint, err := buildURL(c.address, c.index+"/_search")
+	if err != nil {
+		return nil, fmt.Errorf("could not build url: %w", err)
+	}
+
+	data, err := json.Marshal(c.buildRequestBody(args))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.parseResponse(body, args.Fields)
+}
+
+func (c *OpensearchClient) parseResponse(body []byte, fields []string) ([]LogEntry, error) {
+	var resp opensearchResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse opensearch response: %w", err)
+	}
+
+	logs := make([]LogEntry, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		message, _ := lookupField(hit.Source, c.messageField).(string)
+		timestamp, _ := lookupField(hit.Source, c.timestampField).(string)
+		extracted := extractFields(fields, func(field string) (string, bool) {
+			val := lookupField(hit.Source, field)
+			if val == nil {
+				return "", false
+			}
+			return fmt.Sprint(val), true
+		})
+
+		t, err := time.Parse(time.RFC3339Nano, timestamp)
+		if err != nil {
+			logs = append(logs, LogEntry{Timestamp: timestamp, Message: message, Fields: extracted, Count: 1})
+			continue
+		}
+
+		logs = append(logs, newLogEntry(t, message, extracted))
+	}
+
+	sortLogEntries(logs)
+	return logs, nil
+}
+
+// lookupField returns the value of a field, supporting both flattened ("log.level") and nested documents.
+func lookupField(source map[string]any, field string) any {
+	if val, ok := source[field]; ok {
+		return val
+	}
+
+	var current any = source
+	for _, part := range strings.Split(field, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+
+	return current
+}
@@ -2,19 +2,22 @@ package logs
 
 import (
 	"errors"
+	"fmt"
 	"html/template"
-	"net/http"
 	"os"
+	"time"
+
+	"go.uber.org/multierr"
 )
 
 func WithTemplateFile(file string) Opt {
-	return func(ds *VictorialogsClient) error {
+	return func(ds *LogsDatasource) error {
 		data, err := os.ReadFile(file)
 		if err != nil {
 			return err
 		}
 
-		temp, err := template.New("anniversaries").Parse(string(data))
+		temp, err := template.New("logs-regular").Parse(string(data))
 		if err != nil {
 			return err
 		}
@@ -24,35 +27,98 @@ func WithTemplateFile(file string) Opt {
 	}
 }
 
-func WithLimit(limit int) Opt {
-	return func(ds *VictorialogsClient) error {
-		if limit < 1 || limit > 50 {
-			return errors.New("limit must be within range [1, 50]")
+// WithQueries replaces the default query. Queries without a limit or lookback use the default values.
+func WithQueries(queries []NamedQuery) Opt {
+	return func(ds *LogsDatasource) error {
+		if len(queries) == 0 {
+			return errors.New("no queries supplied")
 		}
 
-		ds.limit = limit
-		return nil
-	}
-}
+		var errs error
+		names := map[string]struct{}{}
+		ret := make([]NamedQuery, 0, len(queries))
+		for _, query := range queries {
+			if query.Name == "" {
+				errs = multierr.Append(errs, errors.New("empty query name supplied"))
+			}
+			if _, found := names[query.Name]; found {
+				errs = multierr.Append(errs, fmt.Errorf("duplicate query name %q", query.Name))
+			}
+			names[query.Name] = struct{}{}
+
+			if query.Query == "" {
+				errs = multierr.Append(errs, fmt.Errorf("empty query supplied for %q", query.Name))
+			}
 
-func WithQuery(query string) Opt {
-	return func(ds *VictorialogsClient) error {
-		if query == "" {
-			return errors.New("empty query supplied")
+			if query.Limit == 0 {
+				query.Limit = defaultLimit
+			} else if query.Limit < 1 || query.Limit > 50 {
+				errs = multierr.Append(errs, fmt.Errorf("limit of query %q must be within range [1, 50]", query.Name))
+			}
+
+			if query.Lookback == 0 {
+				query.Lookback = defaultLookback
+			} else if query.Lookback < time.Minute {
+				errs = multierr.Append(errs, fmt.Errorf("lookback of query %q must be at least 1m", query.Name))
+			}
+
+			ret = append(ret, query)
 		}
 
-		ds.query = query
+		if errs != nil {
+			return errs
+		}
+
+		ds.queries = ret
 		return nil
 	}
 }
 
-func WithHttpClient(client *http.Client) Opt {
-	return func(ds *VictorialogsClient) error {
-		if client == nil {
-			return errors.New("empty http client provided")
+// WithStatsQueries adds queries that display the count of log entries over time. It requires a backend that
+// implements StatsBackend.
+func WithStatsQueries(queries []StatsQuery) Opt {
+	return func(ds *LogsDatasource) error {
+		if _, ok := ds.backend.(StatsBackend); !ok {
+			return errors.New("backend does not support stats queries")
+		}
+
+		var errs error
+		ret := make([]StatsQuery, 0, len(queries))
+		for _, query := range queries {
+			if query.Name == "" {
+				errs = multierr.Append(errs, errors.New("empty stats query name supplied"))
+			}
+
+			if query.Query == "" {
+				errs = multierr.Append(errs, fmt.Errorf("empty stats query supplied for %q", query.Name))
+			}
+
+			if query.Window == 0 {
+				query.Window = defaultStatsWindow
+			}
+
+			if query.Step == 0 {
+				query.Step = defaultStatsStep
+			}
+
+			if query.Step < time.Minute || query.Step > query.Window {
+				errs = multierr.Append(errs, fmt.Errorf("step of stats query %q must be within range [1m, window]", query.Name))
+			} else if query.Window/query.Step > maxStatsBuckets {
+				errs = multierr.Append(errs, fmt.Errorf("stats query %q would yield more than %d data points", query.Name, maxStatsBuckets))
+			}
+
+			if query.Baseline < 0 {
+				errs = multierr.Append(errs, fmt.Errorf("baseline of stats query %q must not be negative", query.Name))
+			}
+
+			ret = append(ret, query)
+		}
+
+		if errs != nil {
+			return errs
 		}
 
-		ds.httpClient = client
+		ds.statsQueries = ret
 		return nil
 	}
 }
@@ -0,0 +1,187 @@
+package logs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+func buildQueryResult(query NamedQuery, limit int, entries []LogEntry) QueryResult {
+	result := QueryResult{
+		Name:      query.Name,
+		Fields:    query.Fields,
+		Grouped:   query.Group,
+		Lookback:  query.Lookback,
+		Entries:   entries,
+		Total:     len(entries),
+		Truncated: len(entries) >= limit,
+		TopSource: getTopSource(entries, query.GetSourceField()),
+	}
+
+	if query.Group {
+		result.Entries = groupEntries(entries, query.Fields)
+	}
+
+	return result
+}
+
+// groupEntries de-duplicates log entries with identical messages and fields. The grouped entry carries the timestamp
+// of its latest occurrence. Groups are sorted by their count in descending order.
+func groupEntries(entries []LogEntry, fields []string) []LogEntry {
+	var groups []LogEntry
+	index := map[string]int{}
+
+	for _, entry := range entries {
+		keyParts := make([]string, 0, len(fields)+1)
+		keyParts = append(keyParts, entry.Message)
+		for _, field := range fields {
+			keyParts = append(keyParts, entry.Fields[field])
+		}
+		key := strings.Join(keyParts, "\x00")
+
+		i, found := index[key]
+		if !found {
+			index[key] = len(groups)
+			entry.Count = 1
+			groups = append(groups, entry)
+			continue
+		}
+
+		groups[i].Count++
+		if entry.unix >= groups[i].unix {
+			groups[i].unix = entry.unix
+			groups[i].Timestamp = entry.Timestamp
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].unix > groups[j].unix
+	})
+
+	return groups
+}
+
+// getTopSource returns the most frequent value of the given field. Ties are broken alphabetically.
+func getTopSource(entries []LogEntry, field string) string {
+	if field == "" {
+		return ""
+	}
+
+	counts := map[string]int{}
+	for _, entry := range entries {
+		if val := entry.Fields[field]; val != "" {
+			counts[val]++
+		}
+	}
+
+	var top string
+	for source, count := range counts {
+		if count > counts[top] || (count == counts[top] && source < top) {
+			top = source
+		}
+	}
+
+	return top
+}
+
+func buildStatsResult(query StatsQuery, series []Series, now time.Time) StatsResult {
+	start, buckets := getStatsBuckets(now, query.Window, query.Step)
+
+	result := StatsResult{
+		Name:     query.Name,
+		GroupBy:  query.GroupBy,
+		Window:   query.Window,
+		Baseline: query.Baseline,
+	}
+
+	for _, s := range series {
+		values := fillBuckets(s, start, query.Step, buckets)
+
+		seriesResult := SeriesResult{
+			Name:      s.Labels[query.GroupBy],
+			Sparkline: renderSparkline(values),
+		}
+		for _, v := range values {
+			seriesResult.Total += v
+		}
+
+		// the latest bucket is still in progress, use the one before to calculate the rate
+		latest := len(values) - 1
+		if latest > 0 {
+			latest--
+		}
+		seriesResult.Rate = values[latest] * float64(time.Hour) / float64(query.Step)
+		seriesResult.AboveBaseline = query.Baseline > 0 && seriesResult.Rate > query.Baseline
+
+		result.Series = append(result.Series, seriesResult)
+	}
+
+	sort.SliceStable(result.Series, func(i, j int) bool {
+		if result.Series[i].Total != result.Series[j].Total {
+			return result.Series[i].Total > result.Series[j].Total
+		}
+		return result.Series[i].Name < result.Series[j].Name
+	})
+
+	return result
+}
+
+func getSummary(results []QueryResult, stats []StatsResult) []string {
+	var summary []string
+	for _, result := range stats {
+		for _, series := range result.Series {
+			if !series.AboveBaseline {
+				continue
+			}
+
+			prefix := "📈 "
+			if series.Name != "" {
+				prefix += series.Name + ": "
+			}
+			summary = append(summary, fmt.Sprintf("%s%.0f %s/h (baseline %.0f/h)", prefix, series.Rate, result.Name, result.Baseline))
+		}
+	}
+
+	for _, result := range results {
+		if result.Total == 0 {
+			continue
+		}
+
+		// the number of entries is capped by the query's limit, so it is only a lower bound if the result is truncated
+		count := fmt.Sprintf("%d %s", result.Total, pluralize(result.Total, "entry", "entries"))
+		if result.Truncated {
+			count = "at least " + count
+		}
+
+		line := fmt.Sprintf("%s: %s in the last %s", result.Name, count, formatLookback(result.Lookback))
+		if result.TopSource != "" {
+			line += fmt.Sprintf(", top source: %s", result.TopSource)
+		}
+		summary = append(summary, line)
+	}
+
+	return summary
+}
+
+// formatLookback formats a duration without its zero-valued trailing units, e.g. "45m" instead of "45m0s".
+func formatLookback(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,155 @@
+package logs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_groupEntries(t *testing.T) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []LogEntry{
+		newLogEntry(base, "connection refused", map[string]string{"host": "web1"}),
+		newLogEntry(base.Add(1*time.Minute), "disk full", map[string]string{"host": "db1"}),
+		newLogEntry(base.Add(2*time.Minute), "connection refused", map[string]string{"host": "web1"}),
+		newLogEntry(base.Add(3*time.Minute), "connection refused", map[string]string{"host": "web2"}),
+	}
+
+	lastRefused := newLogEntry(base.Add(2*time.Minute), "connection refused", map[string]string{"host": "web1"})
+	lastRefused.Count = 2
+
+	want := []LogEntry{
+		lastRefused,
+		newLogEntry(base.Add(3*time.Minute), "connection refused", map[string]string{"host": "web2"}),
+		newLogEntry(base.Add(1*time.Minute), "disk full", map[string]string{"host": "db1"}),
+	}
+
+	if got := groupEntries(entries, []string{"host"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("groupEntries() = %v, want %v", got, want)
+	}
+}
+
+func Test_getSummary(t *testing.T) {
+	entries := []LogEntry{
+		{Message: "a", Fields: map[string]string{"unit": "sshd"}},
+		{Message: "b", Fields: map[string]string{"unit": "nginx"}},
+		{Message: "c", Fields: map[string]string{"unit": "nginx"}},
+	}
+
+	tests := []struct {
+		name    string
+		results []QueryResult
+		want    []string
+	}{
+		{
+			name: "no results",
+			results: []QueryResult{
+				buildQueryResult(NamedQuery{Name: "errors", Lookback: 45 * time.Minute}, 20, nil),
+			},
+			want: nil,
+		},
+		{
+			name: "top source",
+			results: []QueryResult{
+				buildQueryResult(NamedQuery{Name: "errors", Lookback: 45 * time.Minute, Fields: []string{"unit"}}, 20, entries),
+			},
+			want: []string{"errors: 3 entries in the last 45m, top source: nginx"},
+		},
+		{
+			name: "truncated without source field",
+			results: []QueryResult{
+				buildQueryResult(NamedQuery{Name: "warnings", Lookback: 90 * time.Minute}, 3, entries),
+			},
+			want: []string{"warnings: at least 3 entries in the last 1h30m"},
+		},
+		{
+			name: "explicit source field",
+			results: []QueryResult{
+				buildQueryResult(NamedQuery{Name: "errors", Lookback: 2 * time.Hour, Fields: []string{"host", "unit"}, SourceField: "unit"}, 20, entries[:1]),
+			},
+			want: []string{"errors: 1 entry in the last 2h, top source: sshd"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.results, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildStatsResult(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 20, 0, 0, time.UTC)
+	bucket := func(hour int) int64 {
+		return time.Date(2025, 3, 1, hour, 0, 0, 0, time.UTC).Unix()
+	}
+
+	query := StatsQuery{Name: "errors", GroupBy: "service", Window: 3 * time.Hour, Step: time.Hour, Baseline: 10}
+	series := []Series{
+		{Labels: map[string]string{"service": "sshd"}, Timestamps: []int64{bucket(11)}, Values: []float64{2}},
+		{Labels: map[string]string{"service": "nginx"}, Timestamps: []int64{bucket(8), bucket(10), bucket(11), bucket(12)}, Values: []float64{100, 3, 37, 5}},
+	}
+
+	want := StatsResult{
+		Name:     "errors",
+		GroupBy:  "service",
+		Window:   3 * time.Hour,
+		Baseline: 10,
+		Series: []SeriesResult{
+			{Name: "nginx", Total: 45, Rate: 37, AboveBaseline: true, Sparkline: renderSparkline([]float64{3, 37, 5})},
+			{Name: "sshd", Total: 2, Rate: 2, Sparkline: renderSparkline([]float64{0, 2, 0})},
+		},
+	}
+
+	got := buildStatsResult(query, series, now)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildStatsResult() = %+v, want %+v", got, want)
+	}
+
+	wantSummary := []string{"📈 nginx: 37 errors/h (baseline 10/h)"}
+	if summary := getSummary(nil, []StatsResult{got}); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+}
+
+func Test_renderSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			want:   "",
+		},
+		{
+			name:   "all zero",
+			values: []float64{0, 0},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,19.0 120.0,19.0"/></svg>`,
+		},
+		{
+			name:   "scaled to max",
+			values: []float64{0, 9, 18},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,19.0 60.0,10.0 120.0,1.0"/></svg>`,
+		},
+		{
+			name:   "flat non-zero count stays at the top",
+			values: []float64{5, 5},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,1.0 120.0,1.0"/></svg>`,
+		},
+		{
+			name:   "small change is no spike",
+			values: []float64{5, 6},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,4.0 120.0,1.0"/></svg>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(renderSparkline(tt.values)); got != tt.want {
+				t.Errorf("renderSparkline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+const (
+	defaultStatsWindow = 6 * time.Hour
+	defaultStatsStep   = 15 * time.Minute
+	maxStatsBuckets    = 200
+
+	sparklineWidth  = 120
+	sparklineHeight = 20
+)
+
+// StatsQuery counts the log entries matching a query, optionally grouped by a field, over a time window.
+type StatsQuery struct {
+	Name  string
+	Query string
+	// GroupBy is the field the counts are grouped by, e.g. "service".
+	GroupBy string
+	Window  time.Duration
+	Step    time.Duration
+	// Baseline is the rate per hour above which a summary line is emitted. A value of 0 disables the summary.
+	Baseline float64
+}
+
+// LogsQL returns the query including the stats pipe. Queries that already contain a stats pipe are returned as-is.
+func (q *StatsQuery) LogsQL() string {
+	if strings.Contains(q.Query, "| stats") {
+		return q.Query
+	}
+
+	if q.GroupBy == "" {
+		return fmt.Sprintf("%s | stats count() hits", q.Query)
+	}
+
+	return fmt.Sprintf("%s | stats by (%s) count() hits", q.Query, q.GroupBy)
+}
+
+// Series holds the bucketed counts for a single group.
+type Series struct {
+	Labels map[string]string
+	// Timestamps holds the start of each bucket as unix timestamp.
+	Timestamps []int64
+	Values     []float64
+}
+
+// StatsBackend is implemented by backends that are able to compute statistics over log entries.
+type StatsBackend interface {
+	QueryStats(ctx context.Context, query StatsQuery, start, end time.Time) ([]Series, error)
+}
+
+type statsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]any          `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (c *VictorialogsClient) QueryStats(ctx context.Context, query StatsQuery, start, end time.Time) ([]Series, error) {
+	endpoint, err := buildURL(c.address, "select/logsql/stats_query_range")
+	if err != nil {
+		return nil, fmt.Errorf("could not build url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("query", query.LogsQL())
+	q.Set("start", start.Format(time.RFC3339))
+	q.Set("end", end.Format(time.RFC3339))
+	q.Set("step", query.Step.String())
+	req.URL.RawQuery = q.Encode()
+
+	body, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStatsResponse(body)
+}
+
+func parseStatsResponse(body []byte) ([]Series, error) {
+	var resp statsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("could not parse stats response: %w", err)
+	}
+
+	if resp.Status != "success" {
+		return nil, fmt.Errorf("stats query failed with status %q", resp.Status)
+	}
+
+	ret := make([]Series, 0, len(resp.Data.Result))
+	for _, result := range resp.Data.Result {
+		series := Series{
+			Labels: result.Metric,
+		}
+		delete(series.Labels, "__name__")
+
+		for _, value := range result.Values {
+			ts, ok := value[0].(float64)
+			if !ok {
+				continue
+			}
+			str, ok := value[1].(string)
+			if !ok {
+				continue
+			}
+			val, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				continue
+			}
+			series.Timestamps = append(series.Timestamps, int64(ts))
+			series.Values = append(series.Values, val)
+		}
+
+		ret = append(ret, series)
+	}
+
+	return ret, nil
+}
+
+// getStatsBuckets returns the start of the first bucket and the number of buckets for the given window. Buckets
+// are aligned to the step.
+func getStatsBuckets(now time.Time, window, step time.Duration) (time.Time, int) {
+	buckets := int(window / step)
+	if buckets < 1 {
+		buckets = 1
+	}
+	start := now.Truncate(step).Add(-time.Duration(buckets-1) * step)
+	return start, buckets
+}
+
+// fillBuckets maps the sparse values of a series onto a dense slice of buckets, filling gaps with zero.
+func fillBuckets(series Series, start time.Time, step time.Duration, buckets int) []float64 {
+	values := make([]float64, buckets)
+	for i, ts := range series.Timestamps {
+		index := int(time.Unix(ts, 0).Sub(start) / step)
+		if index >= 0 && index < buckets {
+			values[index] += series.Values[i]
+		}
+	}
+	return values
+}
+
+// renderSparkline renders the counts as inline SVG polyline. Counts are scaled from zero, so a steady error rate
+// is not drawn as a flat line at the bottom.
+func renderSparkline(values []float64) template.HTML {
+	return pkg.Sparkline(values, sparklineWidth, sparklineHeight, true)
+}
@@ -5,116 +5,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"html/template"
-	"io"
 	"net/http"
 	"net/url"
-	"sort"
 	"strconv"
 	"time"
-
-	"github.com/soerenschneider/aether/internal"
-	"github.com/soerenschneider/aether/internal/templates"
-	"go.uber.org/multierr"
-)
-
-const (
-	defaultQuery = "error AND _time:45m"
-	defaultLimit = 20
 )
 
-type Opt func(datasource *VictorialogsClient) error
-
+// VictorialogsClient queries VictoriaLogs using its LogsQL API.
 type VictorialogsClient struct {
-	endpoint string
-	limit    int
-	query    string
-
-	regularTemplate    *template.Template
-	simpleTemplate     *template.Template
-	httpClient         *http.Client
-	excludeFromSummary bool
+	httpBackend
 }
 
-func New(endpoint string, templateData templates.TemplateData, opts ...Opt) (*VictorialogsClient, error) {
-	if err := templateData.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid template data: %w", err)
-	}
-
-	ds := &VictorialogsClient{
-		endpoint:   endpoint,
-		httpClient: http.DefaultClient,
-		query:      defaultQuery,
-		limit:      defaultLimit,
-	}
-
-	var errs error
-	for _, opt := range opts {
-		if err := opt(ds); err != nil {
-			errs = multierr.Append(errs, err)
-		}
-	}
-
-	var err error
-	ds.regularTemplate, err = template.New("logs-regular").Parse(string(templateData.DefaultTemplate))
+func NewVictorialogsClient(address string, opts ...BackendOpt) (*VictorialogsClient, error) {
+	backend, err := newHttpBackend(address, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(templateData.SimpleTemplate) > 0 {
-		ds.simpleTemplate, err = template.New("logs-simple").Parse(string(templateData.SimpleTemplate))
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return ds, nil
-}
-
-func (c *VictorialogsClient) Name() string {
-	return "Logs"
+	return &VictorialogsClient{httpBackend: backend}, nil
 }
 
-func (c *VictorialogsClient) GetData(ctx context.Context) (*internal.Data, error) {
-	queryReq := VictorialogsQuery{
-		Address: c.endpoint,
-		Query:   c.query,
-		Limit:   c.limit,
-	}
-
-	logs, err := c.QueryVictorialogs(ctx, queryReq)
-	if err != nil {
-		return nil, err
-	}
-
-	var regularTemplateData bytes.Buffer
-	if err := c.regularTemplate.Execute(&regularTemplateData, logs); err != nil {
-		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", c.Name(), internal.ErrTemplate)
-	}
-
-	var simpleTemplateData bytes.Buffer
-	if err := c.simpleTemplate.Execute(&simpleTemplateData, logs); err != nil {
-		return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", c.Name(), internal.ErrTemplate)
-	}
-
-	var summary []string
-	if !c.excludeFromSummary {
-		summary = getSummary(logs)
-	}
-
-	return &internal.Data{
-		Summary:                    summary,
-		RenderedDefaultTemplate:    regularTemplateData.Bytes(),
-		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
-	}, nil
-}
-
-func getSummary(logs []LogEntry) []string {
-	return nil
-}
-
-func (c *VictorialogsClient) QueryVictorialogs(ctx context.Context, args VictorialogsQuery) ([]LogEntry, error) {
-	endpoint, err := buildURL(args.Address, "select/logsql/query")
+func (c *VictorialogsClient) Query(ctx context.Context, args Query) ([]LogEntry, error) {
+	endpoint, err := buildURL(c.address, "select/logsql/query")
 	if err != nil {
 		return nil, fmt.Errorf("could not build url: %w", err)
 	}
@@ -127,42 +39,48 @@ func (c *VictorialogsClient) QueryVictorialogs(ctx context.Context, args Victori
 	q := url.Values{}
 	q.Set("query", args.Query)
 	q.Set("limit", strconv.Itoa(args.GetLimit()))
+	q.Set("start", time.Now().Add(-args.GetLookback()).Format(time.RFC3339))
 	req.URL.RawQuery = q.Encode()
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
+	body, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("bad response: %s - %s", resp.Status, body)
-	}
+	return parseVictorialogsResponse(body, args.Fields), nil
+}
 
-	body, _ := io.ReadAll(resp.Body)
+// parseVictorialogsResponse parses the newline delimited JSON returned by VictoriaLogs.
+func parseVictorialogsResponse(body []byte, fields []string) []LogEntry {
 	lines := bytes.Split(body, []byte("\n"))
 	logs := make([]LogEntry, 0, len(lines))
 
 	for _, line := range lines {
-		var entry LogEntry
+		var entry map[string]any
 		if err := json.Unmarshal(line, &entry); err != nil {
 			continue
 		}
 
-		t, err := time.Parse(time.RFC3339, entry.Timestamp)
-		if err == nil {
-			entry.unix = t.Unix()
-			entry.Timestamp = t.Format("01-02 15:04:05")
+		extracted := extractFields(fields, func(field string) (string, bool) {
+			val, ok := entry[field]
+			if !ok {
+				return "", false
+			}
+			return fmt.Sprint(val), true
+		})
+
+		message, _ := entry["_msg"].(string)
+		timestamp, _ := entry["_time"].(string)
+		t, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil {
+			logs = append(logs, LogEntry{Timestamp: timestamp, Message: message, Fields: extracted, Count: 1})
+			continue
 		}
 
-		logs = append(logs, entry)
+		logs = append(logs, newLogEntry(t, message, extracted))
 	}
 
-	sort.Slice(logs, func(i, j int) bool {
-		return logs[i].unix < logs[j].unix
-	})
-
-	return logs, nil
+	sortLogEntries(logs)
+	return logs
 }
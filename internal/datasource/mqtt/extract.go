@@ -0,0 +1,62 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// extractValue returns the payload as string or, if a path is given, the value at the given dot-separated path of
+// a JSON payload. Array elements are addressed by their index.
+func extractValue(payload []byte, path string) (string, error) {
+	if path == "" {
+		return strings.TrimSpace(string(payload)), nil
+	}
+
+	var decoded any
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return "", fmt.Errorf("could not parse payload as json: %w", err)
+	}
+
+	current := decoded
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]any:
+			val, found := node[segment]
+			if !found {
+				return "", fmt.Errorf("key %q not found in path %q", segment, path)
+			}
+			current = val
+		case []any:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return "", fmt.Errorf("invalid index %q in path %q", segment, path)
+			}
+			current = node[index]
+		default:
+			return "", fmt.Errorf("can not descend into %q of path %q", segment, path)
+		}
+	}
+
+	return formatValue(current)
+}
+
+func formatValue(val any) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	}
+}
@@ -0,0 +1,39 @@
+package mqtt
+
+import (
+	"time"
+)
+
+// Topic is a topic that is subscribed to and displayed.
+type Topic struct {
+	Name  string
+	Topic string
+	// Path optionally selects a value from a JSON payload, e.g. "sensors.0.temperature".
+	Path string
+	Unit string
+	// StaleAfter overrides the datasource's default duration after which a value is flagged as stale.
+	StaleAfter time.Duration
+}
+
+type value struct {
+	value   string
+	updated time.Time
+	err     error
+}
+
+type TopicValue struct {
+	Name    string
+	Topic   string
+	Value   string
+	Unit    string
+	Updated time.Time
+	Age     string
+	Stale   bool
+	Error   string
+}
+
+type MqttData struct {
+	HtmlId string
+	Name   string
+	Values []TopicValue
+}
@@ -0,0 +1,317 @@
+package mqtt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultStaleAfter = 1 * time.Hour
+	defaultName       = "MQTT"
+	disconnectQuiesce = 250
+)
+
+type MqttDatasource struct {
+	broker        string
+	clientOptions *paho.ClientOptions
+	topics        []Topic
+	name          string
+	staleAfter    time.Duration
+
+	// values holds the last received value per configured topic, indexed like topics
+	values  []value
+	started time.Time
+	mutex   sync.RWMutex
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *MqttDatasource) error
+
+func New(broker string, topics []Topic, templateData templates.TemplateData, opts ...Opt) (*MqttDatasource, error) {
+	if broker == "" {
+		return nil, errors.New("empty broker supplied")
+	}
+
+	if len(topics) == 0 {
+		return nil, errors.New("no topics supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &MqttDatasource{
+		broker:     broker,
+		topics:     topics,
+		name:       defaultName,
+		staleAfter: defaultStaleAfter,
+		values:     make([]value, len(topics)),
+		clientOptions: paho.NewClientOptions().
+			AddBroker(broker).
+			SetClientID(defaultClientId()).
+			SetAutoReconnect(true).
+			SetConnectRetry(true).
+			SetMaxReconnectInterval(1 * time.Minute),
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("mqtt-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("mqtt-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, topic := range ds.topics {
+		if topic.Topic == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty topic for %q", topic.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+// defaultClientId returns a client id that is unique per datasource, as the broker disconnects a client when another
+// one connects with the same id.
+func defaultClientId() string {
+	clientId := "aether"
+	if hostname, err := os.Hostname(); err == nil {
+		clientId = fmt.Sprintf("aether-%s", hostname)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return clientId
+	}
+	return fmt.Sprintf("%s-%s", clientId, hex.EncodeToString(suffix))
+}
+
+func (d *MqttDatasource) Name() string {
+	return d.name
+}
+
+// Start connects to the broker and keeps the subscription alive in the background until the context is cancelled.
+// Connection errors are not fatal, the client keeps retrying to connect.
+func (d *MqttDatasource) Start(ctx context.Context, wg *sync.WaitGroup) {
+	d.mutex.Lock()
+	d.started = time.Now()
+	d.mutex.Unlock()
+
+	subscriptions := map[string]byte{}
+	for _, topic := range d.topics {
+		subscriptions[topic.Topic] = 0
+	}
+
+	d.clientOptions.SetOnConnectHandler(func(client paho.Client) {
+		log.Info().Str("broker", d.broker).Msg("connected to mqtt broker")
+		// (re-)subscribe on every connect as the broker does not necessarily keep our session
+		token := client.SubscribeMultiple(subscriptions, func(_ paho.Client, msg paho.Message) {
+			d.handleMessage(msg.Topic(), msg.Payload(), time.Now())
+		})
+		go func() {
+			if token.Wait() && token.Error() != nil {
+				log.Error().Err(token.Error()).Str("broker", d.broker).Msg("could not subscribe to topics")
+			}
+		}()
+	})
+	d.clientOptions.SetConnectionLostHandler(func(_ paho.Client, err error) {
+		log.Warn().Err(err).Str("broker", d.broker).Msg("lost connection to mqtt broker")
+	})
+
+	client := paho.NewClient(d.clientOptions)
+	client.Connect()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		log.Info().Str("broker", d.broker).Msg("disconnecting from mqtt broker")
+		client.Disconnect(disconnectQuiesce)
+	}()
+}
+
+func (d *MqttDatasource) handleMessage(topic string, payload []byte, now time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for index, configured := range d.topics {
+		if !matchTopic(configured.Topic, topic) {
+			continue
+		}
+
+		extracted, err := extractValue(payload, configured.Path)
+		if err != nil {
+			log.Warn().Err(err).Str("topic", topic).Msg("could not extract value from payload")
+			d.values[index].err = err
+			continue
+		}
+
+		d.values[index] = value{
+			value:   extracted,
+			updated: now,
+		}
+	}
+}
+
+// matchTopic returns whether the topic matches the subscribed filter, which may contain the wildcards + for a single
+// level and # for all remaining levels.
+func matchTopic(filter, topic string) bool {
+	// topics starting with $ are reserved for the broker and are not matched by wildcards on the first level
+	if strings.HasPrefix(topic, "$") && (strings.HasPrefix(filter, "+") || strings.HasPrefix(filter, "#")) {
+		return false
+	}
+
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for idx, level := range filterLevels {
+		if level == "#" {
+			// also matches the parent level, e.g. home/# matches home
+			return true
+		}
+		if idx >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[idx] {
+			return false
+		}
+	}
+
+	return len(filterLevels) == len(topicLevels)
+}
+
+func (d *MqttDatasource) getValues(now time.Time) []TopicValue {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	ret := make([]TopicValue, 0, len(d.topics))
+	for index, topic := range d.topics {
+		staleAfter := d.staleAfter
+		if topic.StaleAfter > 0 {
+			staleAfter = topic.StaleAfter
+		}
+
+		name := topic.Name
+		if name == "" {
+			name = topic.Topic
+		}
+
+		val := d.values[index]
+		row := TopicValue{
+			Name:    name,
+			Topic:   topic.Topic,
+			Value:   val.value,
+			Unit:    topic.Unit,
+			Updated: val.updated,
+		}
+
+		if val.err != nil {
+			row.Error = val.err.Error()
+		}
+
+		if val.updated.IsZero() {
+			// only complain about missing values once we had the chance to receive them
+			row.Stale = now.Sub(d.started) > staleAfter
+		} else {
+			row.Age = formatAge(now.Sub(val.updated))
+			row.Stale = now.Sub(val.updated) > staleAfter
+		}
+
+		ret = append(ret, row)
+	}
+
+	return ret
+}
+
+func getSummary(values []TopicValue) []string {
+	var summary []string
+	for _, val := range values {
+		if !val.Stale {
+			continue
+		}
+
+		if val.Updated.IsZero() {
+			summary = append(summary, fmt.Sprintf("⏳ No value received for %s", val.Name))
+		} else {
+			summary = append(summary, fmt.Sprintf("⏳ No update for %s since %s", val.Name, val.Age))
+		}
+	}
+
+	return summary
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "<1m"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(age.Hours()), int(age.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
+
+func (d *MqttDatasource) GetData(_ context.Context) (*internal.Data, error) {
+	values := d.getValues(time.Now())
+
+	data := MqttData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Name:   d.Name(),
+		Values: values,
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(values)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
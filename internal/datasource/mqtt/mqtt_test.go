@@ -0,0 +1,278 @@
+package mqtt
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/soerenschneider/aether/internal/templates"
+)
+
+func TestExtractValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", payload: " 21.5\n", want: "21.5"},
+		{name: "number", payload: `{"temperature": 21.5}`, path: "temperature", want: "21.5"},
+		{name: "nested", payload: `{"sensor": {"state": "open"}}`, path: "sensor.state", want: "open"},
+		{name: "array", payload: `{"sensors": [{"v": 1}, {"v": 2}]}`, path: "sensors.1.v", want: "2"},
+		{name: "bool", payload: `{"contact": false}`, path: "contact", want: "false"},
+		{name: "object", payload: `{"a": {"b": 1}}`, path: "a", want: `{"b":1}`},
+		{name: "missing key", payload: `{"a": 1}`, path: "b", wantErr: true},
+		{name: "invalid index", payload: `[1, 2]`, path: "2", wantErr: true},
+		{name: "descend into scalar", payload: `{"a": 1}`, path: "a.b", wantErr: true},
+		{name: "no json", payload: `21.5 °C`, path: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractValue([]byte(tt.payload), tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extractValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("extractValue() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetValues(t *testing.T) {
+	started := time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC)
+	ds := &MqttDatasource{
+		topics: []Topic{
+			{Name: "Living room", Topic: "home/living", Path: "temperature", Unit: "°C"},
+			{Topic: "home/door"},
+			{Name: "Garden", Topic: "home/garden", StaleAfter: 4 * time.Hour},
+			{Name: "Cellar", Topic: "home/cellar"},
+			{Name: "Battery", Topic: "zigbee/+/battery"},
+			{Name: "Attic", Topic: "home/attic/#"},
+		},
+		staleAfter: time.Hour,
+		values:     make([]value, 6),
+		started:    started,
+	}
+
+	ds.handleMessage("home/living", []byte(`{"temperature": 21.5}`), started.Add(2*time.Hour))
+	ds.handleMessage("home/door", []byte("closed"), started.Add(10*time.Minute))
+	ds.handleMessage("home/garden", []byte("8"), started.Add(10*time.Minute))
+	ds.handleMessage("home/living", []byte(`{"humidity": 40}`), started.Add(2*time.Hour+time.Minute))
+	ds.handleMessage("home/unknown", []byte("ignored"), started)
+	ds.handleMessage("zigbee/sensor1/battery", []byte("87"), started.Add(2*time.Hour))
+	ds.handleMessage("zigbee/sensor1/linkquality", []byte("ignored"), started.Add(2*time.Hour))
+	ds.handleMessage("home/attic/window/state", []byte("open"), started.Add(2*time.Hour+2*time.Minute))
+
+	now := started.Add(2*time.Hour + 5*time.Minute)
+	got := ds.getValues(now)
+	want := []TopicValue{
+		{Name: "Living room", Topic: "home/living", Value: "21.5", Unit: "°C", Updated: started.Add(2 * time.Hour), Age: "5m", Error: `key "temperature" not found in path "temperature"`},
+		{Name: "home/door", Topic: "home/door", Value: "closed", Updated: started.Add(10 * time.Minute), Age: "1h 55m", Stale: true},
+		{Name: "Garden", Topic: "home/garden", Value: "8", Updated: started.Add(10 * time.Minute), Age: "1h 55m"},
+		{Name: "Cellar", Topic: "home/cellar", Stale: true},
+		{Name: "Battery", Topic: "zigbee/+/battery", Value: "87", Updated: started.Add(2 * time.Hour), Age: "5m"},
+		{Name: "Attic", Topic: "home/attic/#", Value: "open", Updated: started.Add(2*time.Hour + 2*time.Minute), Age: "3m"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getValues() = %+v, want %+v", got, want)
+	}
+
+	wantSummary := []string{
+		"⏳ No update for home/door since 1h 55m",
+		"⏳ No value received for Cellar",
+	}
+	if summary := getSummary(got); !reflect.DeepEqual(summary, wantSummary) {
+		t.Errorf("getSummary() = %v, want %v", summary, wantSummary)
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		filter string
+		topic  string
+		want   bool
+	}{
+		{filter: "home/living", topic: "home/living", want: true},
+		{filter: "home/living", topic: "home/living/temperature", want: false},
+		{filter: "home/+/temperature", topic: "home/living/temperature", want: true},
+		{filter: "home/+/temperature", topic: "home/living/humidity", want: false},
+		{filter: "home/+/temperature", topic: "home/temperature", want: false},
+		{filter: "home/+", topic: "home/", want: true},
+		{filter: "home/#", topic: "home/living/temperature", want: true},
+		{filter: "home/#", topic: "home", want: true},
+		{filter: "home/#", topic: "garden/shed", want: false},
+		{filter: "#", topic: "home/living", want: true},
+		{filter: "#", topic: "$SYS/broker/uptime", want: false},
+		{filter: "+/broker/uptime", topic: "$SYS/broker/uptime", want: false},
+		{filter: "$SYS/#", topic: "$SYS/broker/uptime", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter+" "+tt.topic, func(t *testing.T) {
+			if got := matchTopic(tt.filter, tt.topic); got != tt.want {
+				t.Errorf("matchTopic() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_clientId(t *testing.T) {
+	templateData := templates.TemplateData{DefaultTemplate: []byte("{{ range .Values }}{{ .Value }}{{ end }}")}
+	topics := []Topic{{Topic: "home/door"}}
+
+	first, err := New("tcp://localhost:1883", topics, templateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := New("tcp://localhost:1883", topics, templateData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(first.clientOptions.ClientID, "aether") || first.clientOptions.ClientID == second.clientOptions.ClientID {
+		t.Errorf("expected distinct default client ids, got %q and %q", first.clientOptions.ClientID, second.clientOptions.ClientID)
+	}
+
+	ds, err := New("tcp://localhost:1883", topics, templateData, WithClientId("aether-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds.clientOptions.ClientID != "aether-test" {
+		t.Errorf("ClientID = %q, want %q", ds.clientOptions.ClientID, "aether-test")
+	}
+}
+
+// fakeBroker is a minimal MQTT broker that accepts a single client, acknowledges its subscription and publishes the
+// given messages afterward.
+type fakeBroker struct {
+	listener     net.Listener
+	messages     map[string]string
+	disconnected chan struct{}
+}
+
+func newFakeBroker(t *testing.T, messages map[string]string) *fakeBroker {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	broker := &fakeBroker{
+		listener:     listener,
+		messages:     messages,
+		disconnected: make(chan struct{}),
+	}
+	go broker.serve(t)
+	return broker
+}
+
+func (b *fakeBroker) serve(t *testing.T) {
+	conn, err := b.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	for {
+		packet, err := packets.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+
+		var replies []packets.ControlPacket
+		switch p := packet.(type) {
+		case *packets.ConnectPacket:
+			replies = append(replies, packets.NewControlPacket(packets.Connack))
+		case *packets.SubscribePacket:
+			suback := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+			suback.MessageID = p.MessageID
+			suback.ReturnCodes = make([]byte, len(p.Topics))
+			replies = append(replies, suback)
+			for topic, payload := range b.messages {
+				publish := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				publish.TopicName = topic
+				publish.Payload = []byte(payload)
+				replies = append(replies, publish)
+			}
+		case *packets.PingreqPacket:
+			replies = append(replies, packets.NewControlPacket(packets.Pingresp))
+		case *packets.DisconnectPacket:
+			close(b.disconnected)
+			return
+		}
+
+		for _, reply := range replies {
+			if err := reply.Write(conn); err != nil {
+				t.Errorf("could not write packet: %v", err)
+				return
+			}
+		}
+	}
+}
+
+func TestMqttDatasource_Start(t *testing.T) {
+	broker := newFakeBroker(t, map[string]string{
+		"home/living": `{"sensors": [{"temperature": 21.5}]}`,
+		"home/door":   "closed",
+	})
+
+	templateData := templates.TemplateData{DefaultTemplate: []byte("{{ range .Values }}{{ .Name }}={{ .Value }};{{ end }}")}
+	ds, err := New("tcp://"+broker.listener.Addr().String(), []Topic{
+		{Name: "Living room", Topic: "home/living", Path: "sensors.0.temperature"},
+		{Name: "Door", Topic: "home/door"},
+	}, templateData, WithClientId("aether-test"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	ds.Start(ctx, wg)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, err := ds.GetData(ctx)
+		if err != nil {
+			t.Fatalf("GetData() error = %v", err)
+		}
+		if string(data.RenderedDefaultTemplate) == "Living room=21.5;Door=closed;" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("did not receive values in time, got %q", data.RenderedDefaultTemplate)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("datasource did not shut down")
+	}
+
+	select {
+	case <-broker.disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("client did not disconnect from broker")
+	}
+}
@@ -0,0 +1,95 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *MqttDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("mqtt-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+func WithName(name string) Opt {
+	return func(ds *MqttDatasource) error {
+		if name == "" {
+			return errors.New("empty name supplied")
+		}
+
+		ds.name = name
+		return nil
+	}
+}
+
+func WithClientId(clientId string) Opt {
+	return func(ds *MqttDatasource) error {
+		if clientId == "" {
+			return errors.New("empty client id supplied")
+		}
+
+		ds.clientOptions.SetClientID(clientId)
+		return nil
+	}
+}
+
+func WithCredentials(username, password string) Opt {
+	return func(ds *MqttDatasource) error {
+		if username == "" {
+			return errors.New("empty username supplied")
+		}
+
+		ds.clientOptions.SetUsername(username)
+		ds.clientOptions.SetPassword(password)
+		return nil
+	}
+}
+
+// WithTlsCaFile sets a CA file that is used to verify the broker's certificate.
+func WithTlsCaFile(file string) Opt {
+	return func(ds *MqttDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("could not read ca file %q: %w", file, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("no certificates found in %q", file)
+		}
+
+		ds.clientOptions.SetTLSConfig(&tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		})
+		return nil
+	}
+}
+
+// WithStaleAfter sets the default duration after which a value without update is flagged as stale.
+func WithStaleAfter(staleAfter time.Duration) Opt {
+	return func(ds *MqttDatasource) error {
+		if staleAfter < time.Minute {
+			return errors.New("stale duration must be at least 1m")
+		}
+
+		ds.staleAfter = staleAfter
+		return nil
+	}
+}
@@ -0,0 +1,22 @@
+package notes
+
+import (
+	"html/template"
+	"time"
+)
+
+type Note struct {
+	File  string
+	Title string
+	Html  template.HTML
+	// SummaryText is added to the summary if set.
+	SummaryText string
+	Expires     time.Time
+	Error       string
+}
+
+type NotesData struct {
+	HtmlId string
+	Name   string
+	Notes  []Note
+}
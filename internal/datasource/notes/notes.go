@@ -0,0 +1,158 @@
+package notes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+type NotesDatasource struct {
+	// files are paths or glob patterns of markdown files
+	files []string
+	name  string
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *NotesDatasource) error
+
+func New(files []string, templateData templates.TemplateData, opts ...Opt) (*NotesDatasource, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no files supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &NotesDatasource{
+		files: files,
+		name:  "Notes",
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("notes-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("notes-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, file := range ds.files {
+		if _, err := filepath.Match(file, ""); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid pattern %q: %w", file, err))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *NotesDatasource) Name() string {
+	return d.name
+}
+
+// readNotes reads the files on every invocation so changes are picked up without restarting.
+func (d *NotesDatasource) readNotes(now time.Time) []Note {
+	var files []string
+	for _, pattern := range d.files {
+		matches, _ := filepath.Glob(pattern)
+		if len(matches) == 0 {
+			log.Warn().Str("file", pattern).Msg("no notes found")
+		}
+		for _, match := range matches {
+			if !slices.Contains(files, match) {
+				files = append(files, match)
+			}
+		}
+	}
+
+	var notes []Note
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			log.Warn().Err(err).Str("file", file).Msg("could not read note")
+			notes = append(notes, Note{File: file, Error: err.Error()})
+			continue
+		}
+
+		note, err := parseNote(file, content)
+		if err != nil {
+			log.Warn().Err(err).Str("file", file).Msg("could not parse note")
+			note.Error = err.Error()
+		}
+
+		if !note.Expires.IsZero() && !now.Before(note.Expires) {
+			continue
+		}
+		notes = append(notes, note)
+	}
+
+	return notes
+}
+
+func getSummary(notes []Note) []string {
+	var summary []string
+	for _, note := range notes {
+		if note.SummaryText != "" {
+			summary = append(summary, fmt.Sprintf("📌 %s", note.SummaryText))
+		}
+	}
+	return summary
+}
+
+func (d *NotesDatasource) GetData(_ context.Context) (*internal.Data, error) {
+	data := NotesData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Name:   d.Name(),
+		Notes:  d.readNotes(time.Now()),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Notes)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,169 @@
+package notes
+
+import (
+	"html/template"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantHeader string
+		wantBody   string
+	}{
+		{
+			name:     "no front matter",
+			content:  "# Hello\n\nWorld",
+			wantBody: "# Hello\n\nWorld",
+		},
+		{
+			name:       "front matter",
+			content:    "---\ntitle: Plumber\n---\nArrives Tuesday\n",
+			wantHeader: "title: Plumber",
+			wantBody:   "Arrives Tuesday\n",
+		},
+		{
+			name:       "windows line endings",
+			content:    "---\r\ntitle: Plumber\r\n---\r\nArrives Tuesday",
+			wantHeader: "title: Plumber",
+			wantBody:   "Arrives Tuesday",
+		},
+		{
+			name:     "empty front matter",
+			content:  "---\n---\nBody",
+			wantBody: "Body",
+		},
+		{
+			name:       "only front matter",
+			content:    "---\ntitle: Plumber\n---",
+			wantHeader: "title: Plumber",
+		},
+		{
+			name:     "horizontal rule without closing delimiter",
+			content:  "---\nnot a header",
+			wantBody: "---\nnot a header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, body := splitFrontMatter([]byte(tt.content))
+			if string(header) != tt.wantHeader {
+				t.Errorf("splitFrontMatter() header = %q, want %q", header, tt.wantHeader)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("splitFrontMatter() body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestParseNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Note
+		wantErr bool
+	}{
+		{
+			name:    "markdown is rendered",
+			content: "**Plumber** arrives *Tuesday*\n\n- [x] clear the sink",
+			want: Note{
+				File: "note.md",
+				Html: template.HTML("<p><strong>Plumber</strong> arrives <em>Tuesday</em></p>\n<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> clear the sink</li>\n</ul>\n"),
+			},
+		},
+		{
+			name:    "html is sanitized",
+			content: "Hello <script>alert(1)</script><a href=\"javascript:alert(1)\" onclick=\"x()\">link</a>",
+			want: Note{
+				File: "note.md",
+				Html: template.HTML("<p>Hello alert(1)link</p>\n"),
+			},
+		},
+		{
+			name:    "dangerous links are removed",
+			content: "[link](javascript:alert(1)) [ok](https://example.com)",
+			want: Note{
+				File: "note.md",
+				Html: template.HTML("<p>link <a href=\"https://example.com\" rel=\"nofollow\">ok</a></p>\n"),
+			},
+		},
+		{
+			name:    "front matter with summary from title",
+			content: "---\ntitle: Plumber\nsummary: true\nexpires: 2025-03-04\n---\nArrives Tuesday",
+			want: Note{
+				File:        "note.md",
+				Title:       "Plumber",
+				SummaryText: "Plumber",
+				Expires:     time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local),
+				Html:        template.HTML("<p>Arrives Tuesday</p>\n"),
+			},
+		},
+		{
+			name:    "summary from first line",
+			content: "---\nsummary: true\nexpires: 2025-03-04 18:30\n---\n\n## Plumber arrives Tuesday\n\nBring coffee",
+			want: Note{
+				File:        "note.md",
+				SummaryText: "Plumber arrives Tuesday",
+				Expires:     time.Date(2025, 3, 4, 18, 30, 0, 0, time.Local),
+				Html:        template.HTML("<h2>Plumber arrives Tuesday</h2>\n<p>Bring coffee</p>\n"),
+			},
+		},
+		{
+			name:    "invalid expiry",
+			content: "---\nexpires: next week\n---\nBody",
+			want:    Note{File: "note.md"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNote("note.md", []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseNote() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseNote() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadNotes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.md", "---\ntitle: Plumber\nsummary: true\nexpires: 2025-03-04\n---\nArrives Tuesday")
+	write("b.md", "---\ntitle: Expired\nsummary: true\nexpires: 2025-03-01\n---\nGone")
+	write("c.md", "Just a note")
+
+	ds := &NotesDatasource{files: []string{filepath.Join(dir, "*.md"), filepath.Join(dir, "c.md")}}
+	now := time.Date(2025, 3, 4, 20, 0, 0, 0, time.Local)
+
+	notes := ds.readNotes(now)
+	var files []string
+	for _, note := range notes {
+		files = append(files, filepath.Base(note.File))
+	}
+	if want := []string{"a.md", "c.md"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("readNotes() = %v, want %v", files, want)
+	}
+
+	if got, want := getSummary(notes), []string{"📌 Plumber"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+
+	// changes are picked up on the next read
+	write("c.md", "---\nsummary: true\n---\nUpdated note")
+	if got, want := getSummary(ds.readNotes(now)), []string{"📌 Plumber", "📌 Updated note"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() after update = %v, want %v", got, want)
+	}
+}
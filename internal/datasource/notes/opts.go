@@ -0,0 +1,36 @@
+package notes
+
+import (
+	"errors"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *NotesDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("notes-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithName sets the name of the datasource, which is useful when configuring multiple sections of notes.
+func WithName(name string) Opt {
+	return func(ds *NotesDatasource) error {
+		if len(name) == 0 {
+			return errors.New("empty name supplied")
+		}
+
+		ds.name = name
+		return nil
+	}
+}
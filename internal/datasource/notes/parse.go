@@ -0,0 +1,119 @@
+package notes
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	policy   = newPolicy()
+)
+
+// newPolicy returns a policy for user generated content that additionally allows the checkboxes of task lists.
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+	return policy
+}
+
+type frontMatter struct {
+	Title   string `yaml:"title"`
+	Summary bool   `yaml:"summary"`
+	Expires date   `yaml:"expires"`
+}
+
+// date accepts either a day, which expires at the end of that day, or a day with time in the local timezone.
+type date struct {
+	time.Time
+}
+
+func (d *date) UnmarshalYAML(node *yaml.Node) error {
+	day, err := time.ParseInLocation("2006-01-02", node.Value, time.Local)
+	if err == nil {
+		d.Time = day.AddDate(0, 0, 1)
+		return nil
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", time.RFC3339} {
+		if d.Time, err = time.ParseInLocation(layout, node.Value, time.Local); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid date %q, expected YYYY-MM-DD, YYYY-MM-DD HH:MM or RFC3339", node.Value)
+}
+
+// splitFrontMatter separates the optional yaml front matter, delimited by lines consisting of "---", from the body.
+func splitFrontMatter(content []byte) ([]byte, []byte) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil, content
+	}
+
+	rest := content[len("---\n"):]
+	if bytes.HasPrefix(rest, []byte("---\n")) {
+		return nil, rest[len("---\n"):]
+	}
+
+	end := bytes.Index(rest, []byte("\n---\n"))
+	if end < 0 {
+		if !bytes.HasSuffix(rest, []byte("\n---")) {
+			return nil, content
+		}
+		return rest[:len(rest)-len("\n---")], nil
+	}
+
+	return rest[:end], rest[end+len("\n---\n"):]
+}
+
+func parseNote(file string, content []byte) (Note, error) {
+	note := Note{File: file}
+
+	header, body := splitFrontMatter(content)
+	if len(header) > 0 {
+		var meta frontMatter
+		if err := yaml.Unmarshal(header, &meta); err != nil {
+			return note, fmt.Errorf("could not parse front matter of %q: %w", file, err)
+		}
+		note.Title = meta.Title
+		note.Expires = meta.Expires.Time
+		if meta.Summary {
+			note.SummaryText = getSummaryText(meta.Title, body, file)
+		}
+	}
+
+	var rendered bytes.Buffer
+	if err := markdown.Convert(body, &rendered); err != nil {
+		return note, fmt.Errorf("could not render %q: %w", file, err)
+	}
+	note.Html = template.HTML(policy.SanitizeBytes(rendered.Bytes()))
+
+	return note, nil
+}
+
+// getSummaryText returns the title of the note or, if there is none, the first line of its body.
+func getSummaryText(title string, body []byte, file string) string {
+	if title != "" {
+		return title
+	}
+
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(strings.TrimLeft(line, "#>*- "))
+		if line != "" {
+			return line
+		}
+	}
+
+	return file
+}
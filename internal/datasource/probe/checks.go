@@ -0,0 +1,145 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+)
+
+type prober struct {
+	httpClient *http.Client
+	dialer     *net.Dialer
+	resolver   *net.Resolver
+	tlsConfig  *tls.Config
+}
+
+func newProber(timeout time.Duration) *prober {
+	return &prober{
+		// don't use a retrying client, retries would hide outages and distort the latency
+		httpClient: &http.Client{Timeout: timeout},
+		dialer:     &net.Dialer{Timeout: timeout},
+		resolver:   net.DefaultResolver,
+		tlsConfig:  &tls.Config{MinVersion: tls.VersionTLS12},
+	}
+}
+
+func (p *prober) probe(ctx context.Context, target Target) Result {
+	var result Result
+	switch target.Type {
+	case TypeHttp:
+		result = p.probeHttp(ctx, target)
+	case TypeTcp:
+		result = p.probeTcp(ctx, target)
+	case TypeTls:
+		result = p.probeTls(ctx, target)
+	case TypeDns:
+		result = p.probeDns(ctx, target)
+	default:
+		result = Result{Error: fmt.Sprintf("unknown probe type %q", target.Type)}
+	}
+
+	result.Target = target
+	return result
+}
+
+func (p *prober) probeHttp(ctx context.Context, target Target) Result {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target.Address, nil)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	request.Header.Set("User-Agent", "aether")
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(request)
+	if err != nil {
+		return Result{Error: err.Error(), Latency: time.Since(start)}
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	result := Result{
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+	}
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		result.CertExpiry = resp.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if len(target.ExpectedStatus) > 0 {
+		result.Up = slices.Contains(target.ExpectedStatus, resp.StatusCode)
+	} else {
+		result.Up = resp.StatusCode >= 200 && resp.StatusCode < 400
+	}
+
+	if !result.Up {
+		result.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return result
+}
+
+func (p *prober) probeTcp(ctx context.Context, target Target) Result {
+	start := time.Now()
+	conn, err := p.dialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return Result{Error: err.Error(), Latency: time.Since(start)}
+	}
+	_ = conn.Close()
+
+	return Result{
+		Up:      true,
+		Latency: time.Since(start),
+	}
+}
+
+func (p *prober) probeTls(ctx context.Context, target Target) Result {
+	tlsDialer := &tls.Dialer{
+		NetDialer: p.dialer,
+		Config:    p.tlsConfig,
+	}
+
+	start := time.Now()
+	conn, err := tlsDialer.DialContext(ctx, "tcp", target.Address)
+	if err != nil {
+		return Result{Error: err.Error(), Latency: time.Since(start)}
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	result := Result{
+		Up:      true,
+		Latency: time.Since(start),
+	}
+
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) > 0 {
+		result.CertExpiry = certs[0].NotAfter
+	}
+
+	return result
+}
+
+func (p *prober) probeDns(ctx context.Context, target Target) Result {
+	start := time.Now()
+	addresses, err := p.resolver.LookupHost(ctx, target.Address)
+	if err != nil {
+		return Result{Error: err.Error(), Latency: time.Since(start)}
+	}
+
+	return Result{
+		Up:        len(addresses) > 0,
+		Latency:   time.Since(start),
+		Addresses: addresses,
+	}
+}
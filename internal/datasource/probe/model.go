@@ -0,0 +1,49 @@
+package probe
+
+import (
+	"time"
+)
+
+const (
+	TypeHttp = "http"
+	TypeTcp  = "tcp"
+	TypeTls  = "tls"
+	TypeDns  = "dns"
+)
+
+// Target is an endpoint that is probed.
+type Target struct {
+	Name string
+	// Type is one of http, tcp, tls or dns.
+	Type string
+	// Address is a URL for http, host:port for tcp and tls and a hostname for dns targets.
+	Address string
+	// ExpectedStatus optionally lists the accepted status codes of http targets, defaults to 2xx and 3xx.
+	ExpectedStatus []int
+}
+
+type Result struct {
+	Target     Target
+	Up         bool
+	StatusCode int
+	Latency    time.Duration
+	CertExpiry time.Time
+	Addresses  []string
+	Error      string
+}
+
+type ResultRow struct {
+	Result
+	Name          string
+	Status        string
+	CertExpiresIn string
+	CssClass      string
+	CertCssClass  string
+}
+
+type ProbeData struct {
+	HtmlId string
+	Rows   []ResultRow
+	Up     int
+	Total  int
+}
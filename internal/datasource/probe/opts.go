@@ -0,0 +1,61 @@
+package probe
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *ProbeDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("probe-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout of a single probe.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(ds *ProbeDatasource) error {
+		if timeout < 100*time.Millisecond || timeout > time.Minute {
+			return errors.New("timeout must be [100ms, 1m]")
+		}
+
+		ds.prober = newProber(timeout)
+		return nil
+	}
+}
+
+// WithCertWarningDays sets the number of days before a certificate's expiry from which on it's called out.
+func WithCertWarningDays(days int) Opt {
+	return func(ds *ProbeDatasource) error {
+		if days < 1 {
+			return errors.New("cert warning days can not be < 1")
+		}
+
+		ds.certWarning = time.Duration(days) * 24 * time.Hour
+		return nil
+	}
+}
+
+// WithSlowThreshold sets the latency above which a target is marked as slow.
+func WithSlowThreshold(threshold time.Duration) Opt {
+	return func(ds *ProbeDatasource) error {
+		if threshold <= 0 {
+			return errors.New("slow threshold must be positive")
+		}
+
+		ds.slowThreshold = threshold
+		return nil
+	}
+}
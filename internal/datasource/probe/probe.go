@@ -0,0 +1,141 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"time"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	defaultCertWarning = 14 * 24 * time.Hour
+)
+
+type ProbeDatasource struct {
+	targets       []Target
+	prober        *prober
+	certWarning   time.Duration
+	slowThreshold time.Duration
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *ProbeDatasource) error
+
+var validTypes = []string{TypeHttp, TypeTcp, TypeTls, TypeDns}
+
+func New(targets []Target, templateData templates.TemplateData, opts ...Opt) (*ProbeDatasource, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("no targets supplied")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &ProbeDatasource{
+		targets:     targets,
+		prober:      newProber(defaultTimeout),
+		certWarning: defaultCertWarning,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("probe-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("probe-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	for _, target := range ds.targets {
+		if !slices.Contains(validTypes, target.Type) {
+			errs = multierr.Append(errs, fmt.Errorf("invalid type %q for target %q", target.Type, target.Address))
+		}
+		if target.Address == "" {
+			errs = multierr.Append(errs, fmt.Errorf("empty address for target %q", target.Name))
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *ProbeDatasource) Name() string {
+	return "Probes"
+}
+
+func (d *ProbeDatasource) probeAll(ctx context.Context) []Result {
+	results := make([]Result, len(d.targets))
+
+	p := pool.New().WithMaxGoroutines(8)
+	for index, target := range d.targets {
+		p.Go(func() {
+			results[index] = d.prober.probe(ctx, target)
+		})
+	}
+	p.Wait()
+
+	return results
+}
+
+func (d *ProbeDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	results := d.probeAll(ctx)
+	now := time.Now()
+
+	data := ProbeData{
+		HtmlId: pkg.NameToId(d.Name()),
+		Rows:   buildRows(results, d.certWarning, d.slowThreshold, now),
+		Total:  len(results),
+	}
+	for _, result := range results {
+		if result.Up {
+			data.Up++
+		}
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Rows, d.certWarning, now)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
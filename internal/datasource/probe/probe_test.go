@@ -0,0 +1,109 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProber(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddress := listener.Addr().String()
+	_ = listener.Close()
+
+	p := newProber(2 * time.Second)
+	p.httpClient = server.Client()
+	p.tlsConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	serverAddress := strings.TrimPrefix(server.URL, "https://")
+	certExpiry := server.Certificate().NotAfter
+
+	tests := []struct {
+		name       string
+		target     Target
+		wantUp     bool
+		wantStatus int
+		wantCert   bool
+	}{
+		{name: "http up", target: Target{Type: TypeHttp, Address: server.URL}, wantUp: true, wantStatus: 200, wantCert: true},
+		{name: "http bad status", target: Target{Type: TypeHttp, Address: server.URL + "/broken"}, wantStatus: 503, wantCert: true},
+		{name: "http expected status", target: Target{Type: TypeHttp, Address: server.URL + "/broken", ExpectedStatus: []int{503}}, wantUp: true, wantStatus: 503, wantCert: true},
+		{name: "tcp open", target: Target{Type: TypeTcp, Address: serverAddress}, wantUp: true},
+		{name: "tcp closed", target: Target{Type: TypeTcp, Address: closedAddress}},
+		{name: "tls", target: Target{Type: TypeTls, Address: serverAddress}, wantUp: true, wantCert: true},
+		{name: "dns", target: Target{Type: TypeDns, Address: "localhost"}, wantUp: true},
+		{name: "dns invalid", target: Target{Type: TypeDns, Address: "does-not-exist.invalid"}},
+		{name: "unknown type", target: Target{Type: "icmp", Address: "localhost"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.probe(context.Background(), tt.target)
+			if got.Up != tt.wantUp {
+				t.Errorf("probe() up = %v, want %v (error %q)", got.Up, tt.wantUp, got.Error)
+			}
+			if got.Up == (got.Error != "") {
+				t.Errorf("probe() up = %v, error = %q", got.Up, got.Error)
+			}
+			if got.StatusCode != tt.wantStatus {
+				t.Errorf("probe() status = %d, want %d", got.StatusCode, tt.wantStatus)
+			}
+			if tt.wantCert != got.CertExpiry.Equal(certExpiry) {
+				t.Errorf("probe() cert expiry = %v, want %v", got.CertExpiry, tt.wantCert)
+			}
+			if !reflect.DeepEqual(got.Target, tt.target) {
+				t.Errorf("probe() target = %v, want %v", got.Target, tt.target)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	results := []Result{
+		{Target: Target{Name: "web", Type: TypeHttp, Address: "https://example.com"}, Up: true, StatusCode: 200, Latency: 50 * time.Millisecond, CertExpiry: now.Add(60 * 24 * time.Hour)},
+		{Target: Target{Type: TypeHttp, Address: "https://slow.example.com"}, Up: true, StatusCode: 301, Latency: 3 * time.Second, CertExpiry: now.Add(10*24*time.Hour + time.Hour)},
+		{Target: Target{Name: "api", Type: TypeHttp, Address: "https://api.example.com"}, StatusCode: 502, Error: "unexpected status code 502"},
+		{Target: Target{Name: "ssh", Type: TypeTcp, Address: "host:22"}, Error: "connection refused"},
+		{Target: Target{Name: "mail", Type: TypeTls, Address: "mail:993"}, Up: true, CertExpiry: now.Add(-time.Hour)},
+		{Target: Target{Name: "dns", Type: TypeDns, Address: "example.com"}, Up: true, Addresses: []string{"1.1.1.1", "2.2.2.2", "::1"}},
+	}
+
+	rows := buildRows(results, 14*24*time.Hour, time.Second, now)
+
+	wantStatus := []string{"200", "301", "502", "down", "open", "1.1.1.1, 2.2.2.2, …"}
+	wantCss := []string{"green", "yellow", "red", "red", "green", "green"}
+	wantCert := []string{"60 days", "10 days", "", "", "expired", ""}
+	wantCertCss := []string{"", "orange", "", "", "red", ""}
+	for i, row := range rows {
+		if row.Status != wantStatus[i] || row.CssClass != wantCss[i] || row.CertExpiresIn != wantCert[i] || row.CertCssClass != wantCertCss[i] {
+			t.Errorf("row %d: got %q/%q/%q/%q, want %q/%q/%q/%q", i, row.Status, row.CssClass, row.CertExpiresIn, row.CertCssClass, wantStatus[i], wantCss[i], wantCert[i], wantCertCss[i])
+		}
+	}
+
+	want := []string{
+		"🔐 Certificate of https://slow.example.com expires in 10 days",
+		"🔴 api is down: unexpected status code 502",
+		"🔴 ssh is down: connection refused",
+		"🔐 Certificate of mail has expired",
+	}
+	if got := getSummary(rows, 14*24*time.Hour, now); !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+}
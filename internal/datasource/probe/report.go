@@ -0,0 +1,106 @@
+package probe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func buildRows(results []Result, certWarning time.Duration, slowThreshold time.Duration, now time.Time) []ResultRow {
+	rows := make([]ResultRow, 0, len(results))
+	for _, result := range results {
+		name := result.Target.Name
+		if name == "" {
+			name = result.Target.Address
+		}
+
+		row := ResultRow{
+			Result:   result,
+			Name:     name,
+			Status:   getStatus(result),
+			CssClass: "green",
+		}
+
+		if !result.Up {
+			row.CssClass = "red"
+		} else if slowThreshold > 0 && result.Latency > slowThreshold {
+			row.CssClass = "yellow"
+		}
+
+		if !result.CertExpiry.IsZero() {
+			until := result.CertExpiry.Sub(now)
+			row.CertExpiresIn = formatDays(until)
+			row.CertCssClass = getCertCssClass(until, certWarning)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func getStatus(result Result) string {
+	if !result.Up {
+		if result.StatusCode > 0 {
+			return strconv.Itoa(result.StatusCode)
+		}
+		return "down"
+	}
+
+	switch result.Target.Type {
+	case TypeHttp:
+		return strconv.Itoa(result.StatusCode)
+	case TypeDns:
+		if len(result.Addresses) > 2 {
+			return strings.Join(result.Addresses[:2], ", ") + ", …"
+		}
+		return strings.Join(result.Addresses, ", ")
+	default:
+		return "open"
+	}
+}
+
+func getCertCssClass(until time.Duration, warning time.Duration) string {
+	if until <= 3*24*time.Hour {
+		return "red"
+	}
+	if until <= warning {
+		return "orange"
+	}
+	return ""
+}
+
+func formatDays(until time.Duration) string {
+	days := int(until.Hours() / 24)
+	switch {
+	case until < 0:
+		return "expired"
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "1 day"
+	default:
+		return fmt.Sprintf("%d days", days)
+	}
+}
+
+func getSummary(rows []ResultRow, certWarning time.Duration, now time.Time) []string {
+	var summary []string
+	for _, row := range rows {
+		if !row.Up {
+			summary = append(summary, fmt.Sprintf("🔴 %s is down: %s", row.Name, row.Error))
+			continue
+		}
+
+		if !row.CertExpiry.IsZero() && row.CertExpiry.Sub(now) <= certWarning {
+			if row.CertExpiry.Before(now) {
+				summary = append(summary, fmt.Sprintf("🔐 Certificate of %s has expired", row.Name))
+			} else {
+				summary = append(summary, fmt.Sprintf("🔐 Certificate of %s expires in %s", row.Name, row.CertExpiresIn))
+			}
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,76 @@
+package stocks
+
+import (
+	"html/template"
+)
+
+type StocksData struct {
+	HtmlId    string
+	Ranges    []string
+	Symbols   []SymbolData
+	Portfolio *Portfolio
+}
+
+type SymbolData struct {
+	Symbol   string
+	Name     string
+	Link     string
+	Currency string
+	Price    float64
+	// Changes holds the change in percent for each of the configured ranges.
+	Changes []Change
+	Chart   template.HTML
+	// ChartChange is the change over the whole charted period.
+	ChartChange float64
+	Holding     *Holding
+}
+
+type Change struct {
+	Range     string
+	Percent   float64
+	Available bool
+}
+
+func (s *SymbolData) GetChange(r string) (Change, bool) {
+	for _, change := range s.Changes {
+		if change.Range == r {
+			return change, change.Available
+		}
+	}
+	return Change{}, false
+}
+
+// Position is the number of shares held of a symbol.
+type Position struct {
+	Symbol string
+	Shares float64
+}
+
+type Holding struct {
+	Shares float64
+	// Value is the value of the holding in the portfolio's currency.
+	Value float64
+	// DayChange is the absolute change of the holding's value since the previous close in the portfolio's currency.
+	DayChange float64
+}
+
+type Portfolio struct {
+	Currency         string
+	Value            float64
+	DayChange        float64
+	DayChangePercent float64
+	// Missing holds the held symbols whose quote or exchange rate could not be fetched and are therefore not included
+	// in the value.
+	Missing []string
+}
+
+func getCssClass(percent float64) string {
+	switch {
+	case percent > 0:
+		return "green"
+	case percent < 0:
+		return "red"
+	default:
+		return ""
+	}
+}
@@ -2,46 +2,87 @@ package stocks
 
 import (
 	"errors"
+	"fmt"
 	"html/template"
-	"net/http"
 	"os"
+	"slices"
 )
 
-func WithClient(client *http.Client) Opts {
-	return func(datasource *StocksDatasource) error {
-		if client == nil {
-			return errors.New("empty httpClient provided")
+func WithTemplateFile(file string) Opt {
+	return func(ds *StocksDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("stocks-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
 		}
 
-		datasource.httpClient = client
+		ds.defaultTemplate = temp
 		return nil
 	}
 }
 
-func WithTemplateFile(file string) Opts {
+// WithHoldings sets the number of shares held per symbol. Symbols that are not already displayed are added.
+func WithHoldings(positions []Position) Opt {
 	return func(ds *StocksDatasource) error {
-		data, err := os.ReadFile(file)
-		if err != nil {
-			return err
+		holdings := make(map[string]float64, len(positions))
+		for _, position := range positions {
+			if position.Symbol == "" {
+				return errors.New("empty symbol supplied")
+			}
+			if position.Shares <= 0 {
+				return fmt.Errorf("shares of %q must be positive", position.Symbol)
+			}
+			holdings[position.Symbol] += position.Shares
+
+			if !slices.Contains(ds.symbols, position.Symbol) {
+				ds.symbols = append(ds.symbols, position.Symbol)
+			}
 		}
 
-		temp, err := template.New("stocks").Parse(string(data))
-		if err != nil {
+		ds.holdings = holdings
+		return nil
+	}
+}
+
+// WithCurrency sets the currency holdings are converted into.
+func WithCurrency(currency string) Opt {
+	return func(ds *StocksDatasource) error {
+		if len(currency) != 3 {
+			return fmt.Errorf("invalid currency %q", currency)
+		}
+
+		ds.currency = currency
+		return nil
+	}
+}
+
+func WithRanges(ranges []string) Opt {
+	return func(ds *StocksDatasource) error {
+		if len(ranges) == 0 {
+			return errors.New("no ranges supplied")
+		}
+
+		if err := validateRanges(ranges); err != nil {
 			return err
 		}
 
-		ds.template = temp
+		ds.ranges = ranges
 		return nil
 	}
 }
 
-func WithHttpClient(client *http.Client) Opts {
+// WithMoverThreshold sets the daily change in percent that is required for a symbol to be mentioned in the summary.
+func WithMoverThreshold(threshold float64) Opt {
 	return func(ds *StocksDatasource) error {
-		if client == nil {
-			return errors.New("empty http httpClient provided")
+		if threshold < 0 {
+			return errors.New("threshold must not be negative")
 		}
 
-		ds.httpClient = client
+		ds.moverThreshold = threshold
 		return nil
 	}
 }
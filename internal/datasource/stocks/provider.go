@@ -0,0 +1,29 @@
+package stocks
+
+import (
+	"context"
+	"time"
+)
+
+// QuoteProvider retrieves quotes, historical prices and exchange rates.
+type QuoteProvider interface {
+	GetQuote(ctx context.Context, symbol string) (*Quote, error)
+	// GetHistory returns the closing prices since the given time in ascending order.
+	GetHistory(ctx context.Context, symbol string, since time.Time) ([]Point, error)
+	// GetExchangeRate returns the rate to convert an amount from one currency into another.
+	GetExchangeRate(ctx context.Context, from, to string) (float64, error)
+}
+
+type Quote struct {
+	Symbol        string
+	Name          string
+	Currency      string
+	Link          string
+	Price         float64
+	PreviousClose float64
+}
+
+type Point struct {
+	Time  time.Time
+	Close float64
+}
@@ -0,0 +1,62 @@
+package stocks
+
+import (
+	"fmt"
+	"time"
+)
+
+const RangeDay = "1d"
+
+var DefaultRanges = []string{RangeDay, "1mo", "1y"}
+
+// rangeStarts maps the supported ranges to a function that returns the start of the range.
+var rangeStarts = map[string]func(now time.Time) time.Time{
+	RangeDay: func(now time.Time) time.Time { return now.AddDate(0, 0, -1) },
+	"1w":     func(now time.Time) time.Time { return now.AddDate(0, 0, -7) },
+	"1mo":    func(now time.Time) time.Time { return now.AddDate(0, -1, 0) },
+	"3mo":    func(now time.Time) time.Time { return now.AddDate(0, -3, 0) },
+	"6mo":    func(now time.Time) time.Time { return now.AddDate(0, -6, 0) },
+	"ytd":    func(now time.Time) time.Time { return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location()) },
+	"1y":     func(now time.Time) time.Time { return now.AddDate(-1, 0, 0) },
+	"5y":     func(now time.Time) time.Time { return now.AddDate(-5, 0, 0) },
+}
+
+func validateRanges(ranges []string) error {
+	for _, r := range ranges {
+		if _, ok := rangeStarts[r]; !ok {
+			return fmt.Errorf("unsupported range %q", r)
+		}
+	}
+	return nil
+}
+
+// getHistoryStart returns the earliest start of all ranges. The history always covers at least a week.
+func getHistoryStart(ranges []string, now time.Time) time.Time {
+	start := rangeStarts["1w"](now)
+	for _, r := range ranges {
+		if rangeStart := rangeStarts[r](now); rangeStart.Before(start) {
+			start = rangeStart
+		}
+	}
+	return start
+}
+
+// getChange returns the change in percent for the given range. The day range is based on the previous close, all
+// other ranges on the first closing price within the range. False is returned if there is no data for the range.
+func getChange(r string, quote *Quote, history []Point, now time.Time) (float64, bool) {
+	if r == RangeDay {
+		if quote.PreviousClose <= 0 {
+			return 0, false
+		}
+		return (quote.Price/quote.PreviousClose - 1) * 100, true
+	}
+
+	start := rangeStarts[r](now)
+	for _, point := range history {
+		if !point.Time.Before(start) {
+			return (quote.Price/point.Close - 1) * 100, true
+		}
+	}
+
+	return 0, false
+}
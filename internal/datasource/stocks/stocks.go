@@ -3,47 +3,75 @@ package stocks
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
-	"io"
-	"net/http"
-	"net/url"
 	"sync"
-
-	"github.com/soerenschneider/aether/pkg"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
 	"go.uber.org/multierr"
 )
 
 const (
-	baseUrl         = "https://query1.finance.yahoo.com"
-	apiPrefix       = "v8/finance"
-	linkBasePath    = "https://finance.yahoo.com/quote"
-	defaultInterval = "3mo"
-	defaultRange    = "2y"
+	defaultMoverThreshold = 3.
+
+	chartWidth  = 160
+	chartHeight = 32
 )
 
 type StocksDatasource struct {
-	symbols []string
+	provider QuoteProvider
+	symbols  []string
+	holdings map[string]float64
+	currency string
+	ranges   []string
+
+	moverThreshold     float64
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
 
-	template   *template.Template
-	once       sync.Once
-	httpClient *http.Client
+type Opt func(datasource *StocksDatasource) error
+
+var funcMap = template.FuncMap{
+	"getCssClass":   getCssClass,
+	"formatPercent": formatPercent,
 }
 
-type Opts func(datasource *StocksDatasource) error
+func New(provider QuoteProvider, symbols []string, templateData templates.TemplateData, opts ...Opt) (*StocksDatasource, error) {
+	if provider == nil {
+		return nil, errors.New("empty provider supplied")
+	}
 
-func New(symbols []string, opts ...Opts) (*StocksDatasource, error) {
-	if len(symbols) == 0 {
-		return nil, errors.New("no symbols supplied")
+	if err := templateData.Validate(); err != nil {
+		return nil, err
 	}
 
 	ds := &StocksDatasource{
-		symbols:    symbols,
-		httpClient: http.DefaultClient,
+		provider:       provider,
+		symbols:        symbols,
+		holdings:       map[string]float64{},
+		ranges:         DefaultRanges,
+		moverThreshold: defaultMoverThreshold,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("stocks-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("stocks-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var errs error
@@ -53,131 +81,215 @@ func New(symbols []string, opts ...Opts) (*StocksDatasource, error) {
 		}
 	}
 
+	if len(ds.symbols) == 0 {
+		errs = multierr.Append(errs, errors.New("no symbols supplied"))
+	}
+
+	if len(ds.holdings) > 0 && ds.currency == "" {
+		errs = multierr.Append(errs, errors.New("holdings require a currency"))
+	}
+
 	return ds, errs
 }
 
 func (s *StocksDatasource) Name() string {
-	return "stocks"
+	return "Stocks"
 }
 
-func (s *StocksDatasource) GetHtml(ctx context.Context) (string, error) {
-	s.once.Do(func() {
-		if s.template == nil {
-			s.template = template.Must(template.New("stocks").Parse(defaultTemplate))
-		}
-	})
-
-	data, err := s.getStocks(ctx)
+func (s *StocksDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	data, err := s.getStocks(ctx, time.Now())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
+	data.HtmlId = pkg.NameToId(s.Name())
 
-	var tpl bytes.Buffer
-	err = s.template.Execute(&tpl, data)
-	return tpl.String(), err
-}
-
-func buildChartURL(symbol, interval, timeRange string) (string, error) {
-	u, err := url.Parse(baseUrl)
-	if err != nil {
-		return "", err
+	var defaultTemplateData bytes.Buffer
+	if err := s.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", s.Name(), internal.ErrTemplate)
 	}
 
-	u.Path = fmt.Sprintf("%s/chart/%s", apiPrefix, symbol)
-
-	q := u.Query()
-	q.Set("metrics", "high")
-	q.Set("interval", interval)
-	q.Set("range", timeRange)
+	var simpleTemplateData bytes.Buffer
+	if s.simpleTemplate != nil {
+		if err := s.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", s.Name(), internal.ErrTemplate)
+		}
+	}
 
-	u.RawQuery = q.Encode()
+	var summary []string
+	if !s.excludeFromSummary {
+		summary = getSummary(data, s.moverThreshold)
+	}
 
-	return u.String(), nil
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
 }
 
-func (s *StocksDatasource) getStock(ctx context.Context, symbol, interval, timeRange string) (*Response, error) {
-	url, err := buildChartURL(symbol, interval, timeRange)
-	old := fmt.Sprintf("%s/chart/%s?metrics=high?&interval=%s&range=%s", baseUrl, symbol, interval, timeRange)
-	if err != nil {
-		return nil, errors.New(old)
-	}
+type symbolResult struct {
+	// symbol is the symbol as configured, which may differ from the symbol returned by the provider
+	symbol string
+	// quote is nil if the symbol could not be fetched
+	quote   *Quote
+	history []Point
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (s *StocksDatasource) getSymbol(ctx context.Context, symbol string, since time.Time) (*symbolResult, error) {
+	quote, err := s.provider.GetQuote(ctx, symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	req = req.WithContext(ctx)
-
-	resp, err := s.httpClient.Do(req)
+	// without history, the quote is still shown but the changes of the ranges and the chart are unavailable
+	history, err := s.provider.GetHistory(ctx, symbol, since)
 	if err != nil {
-		return nil, err
+		log.Warn().Err(err).Msgf("could not get history of symbol %q", symbol)
 	}
 
-	defer resp.Body.Close()
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-	chart := &Response{}
-	if err := json.Unmarshal(data, &chart); err != nil {
-		return nil, err
-	}
-
-	return chart, nil
+	return &symbolResult{symbol: symbol, quote: quote, history: history}, nil
 }
 
-func (s *StocksDatasource) getStocks(ctx context.Context) (*StockData, error) {
-	ret := make([]*Response, len(s.symbols))
-	errs := make(chan error, len(s.symbols))
+func (s *StocksDatasource) getStocks(ctx context.Context, now time.Time) (*StocksData, error) {
+	since := getHistoryStart(s.ranges, now)
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(s.symbols))
+	results := make([]*symbolResult, len(s.symbols))
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(4)
+	var errs error
+	var mutex sync.Mutex
 	for index, symbol := range s.symbols {
-		go func(index int, sym string) {
-			res, err := s.getStock(ctx, sym, defaultInterval, defaultRange)
+		p.Go(func(ctx context.Context) error {
+			result, err := s.getSymbol(ctx, symbol, since)
 			if err != nil {
-				log.Error().Err(err).Msgf("could not resolve symbol %q", sym)
-				errs <- err
-			} else {
-				ret[index] = res
+				log.Error().Err(err).Msgf("could not resolve symbol %q", symbol)
+				mutex.Lock()
+				errs = multierr.Append(errs, err)
+				mutex.Unlock()
+				results[index] = &symbolResult{symbol: symbol}
+				return nil
 			}
-			wg.Done()
-		}(index, symbol)
+			results[index] = result
+			return nil
+		})
 	}
+	_ = p.Wait()
 
-	wg.Wait()
+	if errs != nil && len(multierr.Errors(errs)) == len(s.symbols) {
+		return nil, errs
+	}
 
-	select {
-	case err := <-errs:
-		return nil, err
-	default:
-		return convert(ret)
+	rates := s.getExchangeRates(ctx, results)
+	return buildStocksData(results, s.ranges, s.holdings, s.currency, rates, now), nil
+}
+
+// getExchangeRates returns the rates to convert the currencies of all held symbols into the portfolio's currency.
+// Currencies whose rate could not be fetched are missing from the result.
+func (s *StocksDatasource) getExchangeRates(ctx context.Context, results []*symbolResult) map[string]float64 {
+	rates := map[string]float64{}
+	failed := map[string]bool{}
+	for _, result := range results {
+		if result.quote == nil || result.quote.Currency == s.currency {
+			continue
+		}
+
+		if _, held := s.holdings[result.symbol]; !held {
+			continue
+		}
+
+		if _, found := rates[result.quote.Currency]; found || failed[result.quote.Currency] {
+			continue
+		}
+
+		rate, err := s.provider.GetExchangeRate(ctx, result.quote.Currency, s.currency)
+		if err != nil {
+			log.Error().Err(err).Msgf("could not get exchange rate %s/%s", result.quote.Currency, s.currency)
+			failed[result.quote.Currency] = true
+			continue
+		}
+		rates[result.quote.Currency] = rate
 	}
+
+	return rates
 }
 
-func convert(r []*Response) (*StockData, error) {
-	if len(r) == 0 {
-		return nil, errors.New("empty slice")
+func buildStocksData(results []*symbolResult, ranges []string, holdings map[string]float64, currency string, rates map[string]float64, now time.Time) *StocksData {
+	data := &StocksData{
+		Ranges: ranges,
+	}
+
+	var portfolio *Portfolio
+	if len(holdings) > 0 {
+		portfolio = &Portfolio{Currency: currency}
 	}
 
-	var symbols []Symbol
-	for _, respo := range r {
-		resp := respo
-		if resp == nil || len(resp.Chart.Result) == 0 {
+	for _, result := range results {
+		if result.quote == nil {
+			// the value of a held symbol that could not be fetched is missing from the portfolio's total
+			if _, held := holdings[result.symbol]; held {
+				portfolio.Missing = append(portfolio.Missing, result.symbol)
+			}
 			continue
 		}
 
-		sym := Symbol{
-			Name:   resp.Chart.Result[0].Meta.Symbol,
-			Link:   fmt.Sprintf("%s/%s", linkBasePath, resp.Chart.Result[0].Meta.Symbol),
-			Values: pkg.DeepCopyReverseSlice(resp.Chart.Result[0].Indicators.AdjClose[0].AdjClose),
+		quote := result.quote
+		symbol := SymbolData{
+			Symbol:   quote.Symbol,
+			Name:     quote.Name,
+			Link:     quote.Link,
+			Currency: quote.Currency,
+			Price:    quote.Price,
+		}
+
+		for _, r := range ranges {
+			change := Change{Range: r}
+			change.Percent, change.Available = getChange(r, quote, result.history, now)
+			symbol.Changes = append(symbol.Changes, change)
+		}
+
+		if len(result.history) > 0 {
+			values := make([]float64, 0, len(result.history)+1)
+			for _, point := range result.history {
+				values = append(values, point.Close)
+			}
+			values = append(values, quote.Price)
+			symbol.Chart = pkg.Sparkline(values, chartWidth, chartHeight, false)
+			if values[0] > 0 {
+				symbol.ChartChange = (quote.Price/values[0] - 1) * 100
+			}
 		}
-		symbols = append(symbols, sym)
+
+		rate, convertible := 1., true
+		if quote.Currency != currency {
+			rate, convertible = rates[quote.Currency]
+		}
+
+		if shares, held := holdings[result.symbol]; held && !convertible {
+			// the holding can not be converted into the portfolio's currency
+			portfolio.Missing = append(portfolio.Missing, result.symbol)
+		} else if held {
+			symbol.Holding = &Holding{
+				Shares:    shares,
+				Value:     shares * quote.Price * rate,
+				DayChange: shares * (quote.Price - quote.PreviousClose) * rate,
+			}
+			if quote.PreviousClose <= 0 {
+				symbol.Holding.DayChange = 0
+			}
+
+			portfolio.Value += symbol.Holding.Value
+			portfolio.DayChange += symbol.Holding.DayChange
+		}
+
+		data.Symbols = append(data.Symbols, symbol)
 	}
 
-	return &StockData{
-		Timestamps: pkg.DeepCopyReverseSlice(r[0].Chart.Result[0].Timestamp),
-		Symbols:    symbols,
-	}, nil
+	if portfolio != nil && (portfolio.Value > 0 || len(portfolio.Missing) > 0) {
+		previousValue := portfolio.Value - portfolio.DayChange
+		if previousValue > 0 {
+			portfolio.DayChangePercent = portfolio.DayChange / previousValue * 100
+		}
+		data.Portfolio = portfolio
+	}
+
+	return data
 }
@@ -0,0 +1,95 @@
+package stocks
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	quotes  map[string]*Quote
+	history map[string][]Point
+	rates   map[string]float64
+}
+
+func (f *fakeProvider) GetQuote(_ context.Context, symbol string) (*Quote, error) {
+	quote, ok := f.quotes[symbol]
+	if !ok {
+		return nil, errors.New("no quote")
+	}
+	return quote, nil
+}
+
+func (f *fakeProvider) GetHistory(_ context.Context, symbol string, _ time.Time) ([]Point, error) {
+	history, ok := f.history[symbol]
+	if !ok {
+		return nil, errors.New("no history")
+	}
+	return history, nil
+}
+
+func (f *fakeProvider) GetExchangeRate(_ context.Context, from, to string) (float64, error) {
+	rate, ok := f.rates[from+to]
+	if !ok {
+		return 0, errors.New("no rate")
+	}
+	return rate, nil
+}
+
+func TestStocksDatasource_getStocks(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{
+		quotes: map[string]*Quote{
+			"AAPL":   {Symbol: "AAPL", Currency: "USD", Price: 110, PreviousClose: 100},
+			"SAP.DE": {Symbol: "SAP.DE", Currency: "EUR", Price: 200, PreviousClose: 205},
+		},
+		history: map[string][]Point{
+			"AAPL": {{Time: time.Date(2025, 2, 17, 0, 0, 0, 0, time.UTC), Close: 88}},
+		},
+		rates: map[string]float64{"USDEUR": 0.5},
+	}
+
+	ds := &StocksDatasource{
+		provider: provider,
+		symbols:  []string{"AAPL", "SAP.DE"},
+		holdings: map[string]float64{"AAPL": 10, "SAP.DE": 2},
+		currency: "EUR",
+		ranges:   []string{"1d", "1mo"},
+	}
+
+	got, err := ds.getStocks(context.Background(), now)
+	if err != nil {
+		t.Fatalf("getStocks() error = %v", err)
+	}
+
+	// the quote is kept if the history could not be fetched
+	if len(got.Symbols) != 2 {
+		t.Fatalf("expected 2 symbols, got %+v", got.Symbols)
+	}
+	sap := got.Symbols[1]
+	if _, ok := sap.GetChange("1d"); !ok {
+		t.Errorf("expected day change of %s to be available", sap.Symbol)
+	}
+	if _, ok := sap.GetChange("1mo"); ok || sap.Chart != "" {
+		t.Errorf("expected 1mo change and chart of %s to be unavailable", sap.Symbol)
+	}
+
+	if got.Portfolio == nil || got.Portfolio.Value != 950 || len(got.Portfolio.Missing) != 0 {
+		t.Errorf("Portfolio = %+v, want value 950 without missing symbols", got.Portfolio)
+	}
+
+	// holdings without exchange rate are missing from the portfolio, all other symbols are still shown
+	provider.rates = nil
+	got, err = ds.getStocks(context.Background(), now)
+	if err != nil {
+		t.Fatalf("getStocks() error = %v", err)
+	}
+	if len(got.Symbols) != 2 || got.Symbols[0].Holding != nil {
+		t.Errorf("expected 2 symbols without holding for AAPL, got %+v", got.Symbols)
+	}
+	if got.Portfolio == nil || got.Portfolio.Value != 400 || !reflect.DeepEqual(got.Portfolio.Missing, []string{"AAPL"}) {
+		t.Errorf("Portfolio = %+v, want value 400 with AAPL missing", got.Portfolio)
+	}
+}
@@ -0,0 +1,50 @@
+package stocks
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%+.1f%%", percent)
+}
+
+func getSummary(data *StocksData, threshold float64) []string {
+	type mover struct {
+		symbol string
+		change float64
+	}
+
+	var movers []mover
+	for _, symbol := range data.Symbols {
+		change, ok := symbol.GetChange(RangeDay)
+		if ok && math.Abs(change.Percent) >= threshold {
+			movers = append(movers, mover{symbol: symbol.Symbol, change: change.Percent})
+		}
+	}
+
+	sort.SliceStable(movers, func(i, j int) bool {
+		return math.Abs(movers[i].change) > math.Abs(movers[j].change)
+	})
+
+	var summary []string
+	for _, m := range movers {
+		emoji := "📈"
+		if m.change < 0 {
+			emoji = "📉"
+		}
+		summary = append(summary, fmt.Sprintf("%s %s %s today", emoji, m.symbol, formatPercent(m.change)))
+	}
+
+	if data.Portfolio != nil && math.Abs(data.Portfolio.DayChangePercent) >= threshold {
+		summary = append(summary, fmt.Sprintf("💼 Portfolio %s today (%.2f %s)", formatPercent(data.Portfolio.DayChangePercent), data.Portfolio.Value, data.Portfolio.Currency))
+	}
+
+	if data.Portfolio != nil && len(data.Portfolio.Missing) > 0 {
+		summary = append(summary, fmt.Sprintf("⚠️ Portfolio incomplete, could not fetch %s", strings.Join(data.Portfolio.Missing, ", ")))
+	}
+
+	return summary
+}
@@ -0,0 +1,99 @@
+package stocks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_buildStocksData(t *testing.T) {
+	now := time.Date(2025, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	results := []*symbolResult{
+		{
+			symbol: "aapl",
+			quote:  &Quote{Symbol: "AAPL", Currency: "USD", Price: 110, PreviousClose: 100},
+			history: []Point{
+				{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Close: 40},
+				{Time: time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC), Close: 55},
+				{Time: time.Date(2025, 2, 17, 0, 0, 0, 0, time.UTC), Close: 88},
+				{Time: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), Close: 100},
+			},
+		},
+		{symbol: "msft"},
+		{
+			symbol: "SAP.DE",
+			quote:  &Quote{Symbol: "SAP.DE", Currency: "EUR", Price: 200, PreviousClose: 205},
+		},
+	}
+
+	holdings := map[string]float64{"aapl": 10, "msft": 5, "SAP.DE": 2}
+	rates := map[string]float64{"USD": 0.5}
+
+	got := buildStocksData(results, []string{"1d", "1mo", "1y"}, holdings, "EUR", rates, now)
+
+	if len(got.Symbols) != 2 {
+		t.Fatalf("expected 2 symbols, got %d", len(got.Symbols))
+	}
+
+	wantChanges := []Change{
+		{Range: "1d", Percent: 10.000000000000009, Available: true},
+		{Range: "1mo", Percent: 25, Available: true},
+		{Range: "1y", Percent: 100, Available: true},
+	}
+	if !reflect.DeepEqual(got.Symbols[0].Changes, wantChanges) {
+		t.Errorf("Changes = %v, want %v", got.Symbols[0].Changes, wantChanges)
+	}
+
+	if got.Symbols[0].Chart == "" || got.Symbols[1].Chart != "" {
+		t.Errorf("expected a chart only for symbols with history")
+	}
+
+	wantHolding := &Holding{Shares: 10, Value: 550, DayChange: 50}
+	if !reflect.DeepEqual(got.Symbols[0].Holding, wantHolding) {
+		t.Errorf("Holding = %v, want %v", got.Symbols[0].Holding, wantHolding)
+	}
+
+	wantPortfolio := &Portfolio{Currency: "EUR", Value: 950, DayChange: 40, DayChangePercent: 40. / 910 * 100, Missing: []string{"msft"}}
+	if !reflect.DeepEqual(got.Portfolio, wantPortfolio) {
+		t.Errorf("Portfolio = %v, want %v", got.Portfolio, wantPortfolio)
+	}
+
+	// a missing first closing price does not yield an infinite change
+	zero := buildStocksData([]*symbolResult{{
+		symbol:  "msft",
+		quote:   &Quote{Symbol: "MSFT", Currency: "USD", Price: 110},
+		history: []Point{{Time: time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), Close: 0}},
+	}}, []string{"1d"}, nil, "EUR", nil, now)
+	if change := zero.Symbols[0].ChartChange; change != 0 {
+		t.Errorf("ChartChange = %v, want 0", change)
+	}
+
+	// without history, only the day range is available
+	if _, ok := got.Symbols[1].GetChange("1mo"); ok {
+		t.Errorf("expected 1mo change to be unavailable")
+	}
+}
+
+func Test_getSummary(t *testing.T) {
+	data := &StocksData{
+		Symbols: []SymbolData{
+			{Symbol: "AAPL", Changes: []Change{{Range: "1d", Percent: 4.2, Available: true}}},
+			{Symbol: "MSFT", Changes: []Change{{Range: "1d", Percent: -1.5, Available: true}}},
+			{Symbol: "TSLA", Changes: []Change{{Range: "1d", Percent: -7.25, Available: true}}},
+			{Symbol: "NVDA", Changes: []Change{{Range: "1mo", Percent: 20, Available: true}}},
+		},
+		Portfolio: &Portfolio{Currency: "EUR", Value: 12345.678, DayChangePercent: -3.1, Missing: []string{"MSFT"}},
+	}
+
+	want := []string{
+		"📉 TSLA -7.2% today",
+		"📈 AAPL +4.2% today",
+		"💼 Portfolio -3.1% today (12345.68 EUR)",
+		"⚠️ Portfolio incomplete, could not fetch MSFT",
+	}
+
+	if got := getSummary(data, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("getSummary() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,201 @@
+package stocks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	yahooBaseUrl      = "https://query1.finance.yahoo.com"
+	yahooApiPrefix    = "v8/finance"
+	yahooLinkBasePath = "https://finance.yahoo.com/quote"
+	// yahoo rejects requests without a browser-like user agent
+	yahooUserAgent = "Mozilla/5.0 (X11; Linux x86_64)"
+)
+
+type YahooProvider struct {
+	baseUrl    string
+	httpClient *http.Client
+}
+
+func NewYahooProvider(baseUrl string, httpClient *http.Client) (*YahooProvider, error) {
+	if baseUrl == "" {
+		baseUrl = yahooBaseUrl
+	}
+
+	if httpClient == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &YahooProvider{
+		baseUrl:    baseUrl,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (y *YahooProvider) buildChartURL(symbol string, params url.Values) (string, error) {
+	u, err := url.Parse(y.baseUrl)
+	if err != nil {
+		return "", err
+	}
+
+	u = u.JoinPath(yahooApiPrefix, "chart", symbol)
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+func (y *YahooProvider) getChart(ctx context.Context, symbol string, params url.Values) (*Result, error) {
+	endpoint, err := y.buildChartURL(symbol, params)
+	if err != nil {
+		return nil, fmt.Errorf("could not build url: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", yahooUserAgent)
+
+	resp, err := y.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad response for symbol %q: %s", symbol, resp.Status)
+	}
+
+	chart := &Response{}
+	if err := json.Unmarshal(data, chart); err != nil {
+		return nil, err
+	}
+
+	if chart.Chart.Error != nil {
+		return nil, fmt.Errorf("could not get chart for symbol %q: %v", symbol, chart.Chart.Error)
+	}
+
+	if len(chart.Chart.Result) == 0 {
+		return nil, fmt.Errorf("empty result for symbol %q", symbol)
+	}
+
+	return &chart.Chart.Result[0], nil
+}
+
+func (y *YahooProvider) GetQuote(ctx context.Context, symbol string) (*Quote, error) {
+	params := url.Values{}
+	params.Set("range", "1d")
+	params.Set("interval", "1d")
+
+	// for a range of 1d, the chart's previous close is the close of the previous trading day
+	result, err := y.getChart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	name := result.Meta.LongName
+	if name == "" {
+		name = result.Meta.ShortName
+	}
+
+	quote := &Quote{
+		Symbol:        result.Meta.Symbol,
+		Name:          name,
+		Currency:      result.Meta.Currency,
+		Link:          fmt.Sprintf("%s/%s", yahooLinkBasePath, url.PathEscape(result.Meta.Symbol)),
+		Price:         result.Meta.RegularMarketPrice,
+		PreviousClose: result.Meta.ChartPreviousClose,
+	}
+
+	// prices of stocks listed in London are quoted in pence
+	if quote.Currency == "GBp" {
+		quote.Currency = "GBP"
+		quote.Price /= 100
+		quote.PreviousClose /= 100
+	}
+
+	return quote, nil
+}
+
+func (y *YahooProvider) GetHistory(ctx context.Context, symbol string, since time.Time) ([]Point, error) {
+	params := url.Values{}
+	params.Set("period1", strconv.FormatInt(since.Unix(), 10))
+	params.Set("period2", strconv.FormatInt(time.Now().Unix(), 10))
+	params.Set("interval", getInterval(time.Since(since)))
+
+	result, err := y.getChart(ctx, symbol, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(result)
+}
+
+func (y *YahooProvider) GetExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	quote, err := y.GetQuote(ctx, fmt.Sprintf("%s%s=X", from, to))
+	if err != nil {
+		return 0, err
+	}
+
+	if quote.Price <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate for %s/%s", from, to)
+	}
+
+	return quote.Price, nil
+}
+
+// getInterval returns an interval that yields a reasonable amount of data points for the given span.
+func getInterval(span time.Duration) string {
+	switch {
+	case span <= 8*24*time.Hour:
+		return "1h"
+	case span <= 2*366*24*time.Hour:
+		return "1d"
+	default:
+		return "1wk"
+	}
+}
+
+// convert extracts the closing prices from the result. The unadjusted closing prices are used, as the changes are
+// calculated against the unadjusted current price. Missing values are skipped.
+func convert(result *Result) ([]Point, error) {
+	var closes []float64
+	if len(result.Indicators.Quote) > 0 && len(result.Indicators.Quote[0].Close) == len(result.Timestamp) {
+		closes = result.Indicators.Quote[0].Close
+	}
+
+	if len(closes) == 0 {
+		return nil, fmt.Errorf("no closing prices available for symbol %q", result.Meta.Symbol)
+	}
+
+	divisor := 1.
+	if result.Meta.Currency == "GBp" {
+		divisor = 100
+	}
+
+	points := make([]Point, 0, len(closes))
+	for index, val := range closes {
+		if val == 0 {
+			continue
+		}
+
+		points = append(points, Point{
+			Time:  result.Timestamp[index],
+			Close: val / divisor,
+		})
+	}
+
+	return points, nil
+}
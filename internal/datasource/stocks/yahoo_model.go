@@ -5,17 +5,6 @@ import (
 	"time"
 )
 
-type StockData struct {
-	Timestamps []time.Time
-	Symbols    []Symbol
-}
-
-type Symbol struct {
-	Name   string
-	Link   string
-	Values []float64
-}
-
 type CurrentTradingPeriod struct {
 	Pre     TradingPeriod `json:"pre"`
 	Regular TradingPeriod `json:"regular"`
@@ -49,6 +38,8 @@ type AdjClose struct {
 type Meta struct {
 	Currency             string               `json:"currency"`
 	Symbol               string               `json:"symbol"`
+	LongName             string               `json:"longName"`
+	ShortName            string               `json:"shortName"`
 	ExchangeName         string               `json:"exchangeName"`
 	InstrumentType       string               `json:"instrumentType"`
 	FirstTradeDate       int                  `json:"firstTradeDate"`
@@ -0,0 +1,110 @@
+package stocks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestYahooProvider(t *testing.T) {
+	fixtures := map[string]string{
+		"/v8/finance/chart/AAPL": `{"chart": {"result": [{
+			"meta": {"currency": "USD", "symbol": "AAPL", "longName": "Apple Inc.", "regularMarketPrice": 110, "chartPreviousClose": 100},
+			"timestamp": [1740787200, 1740873600, 1740960000],
+			"indicators": {"quote": [{"close": [98, null, 105]}], "adjclose": [{"adjclose": [97.5, null, 104.5]}]}
+		}], "error": null}}`,
+		"/v8/finance/chart/VOD.L": `{"chart": {"result": [{
+			"meta": {"currency": "GBp", "symbol": "VOD.L", "regularMarketPrice": 7000, "chartPreviousClose": 7100},
+			"timestamp": [1740787200, 1740873600],
+			"indicators": {"quote": [{"close": [6900, 7000]}], "adjclose": []}
+		}], "error": null}}`,
+		"/v8/finance/chart/EMPTY": `{"chart": {"result": [{
+			"meta": {"currency": "USD", "symbol": "EMPTY"},
+			"timestamp": [1740787200],
+			"indicators": {"quote": [], "adjclose": []}
+		}], "error": null}}`,
+		"/v8/finance/chart/USDEUR=X": `{"chart": {"result": [{
+			"meta": {"currency": "EUR", "symbol": "USDEUR=X", "regularMarketPrice": 0.9, "chartPreviousClose": 0.91},
+			"timestamp": [],
+			"indicators": {"quote": [], "adjclose": []}
+		}], "error": null}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("expected user agent")
+		}
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"chart": {"result": null, "error": {"code": "Not Found"}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(fixture))
+	}))
+	defer server.Close()
+
+	provider, err := NewYahooProvider(server.URL, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	quote, err := provider.GetQuote(ctx, "AAPL")
+	if err != nil {
+		t.Fatalf("GetQuote() error = %v", err)
+	}
+	wantQuote := &Quote{Symbol: "AAPL", Name: "Apple Inc.", Currency: "USD", Link: "https://finance.yahoo.com/quote/AAPL", Price: 110, PreviousClose: 100}
+	if !reflect.DeepEqual(quote, wantQuote) {
+		t.Errorf("GetQuote() = %+v, want %+v", quote, wantQuote)
+	}
+
+	history, err := provider.GetHistory(ctx, "AAPL", time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	wantHistory := []Point{
+		{Time: time.Unix(1740787200, 0), Close: 98},
+		{Time: time.Unix(1740960000, 0), Close: 105},
+	}
+	if !reflect.DeepEqual(history, wantHistory) {
+		t.Errorf("GetHistory() = %v, want %v", history, wantHistory)
+	}
+
+	// prices are quoted in pence
+	history, err = provider.GetHistory(ctx, "VOD.L", time.Now().AddDate(0, -1, 0))
+	if err != nil {
+		t.Fatalf("GetHistory() error = %v", err)
+	}
+	wantHistory = []Point{
+		{Time: time.Unix(1740787200, 0), Close: 69},
+		{Time: time.Unix(1740873600, 0), Close: 70},
+	}
+	if !reflect.DeepEqual(history, wantHistory) {
+		t.Errorf("GetHistory() = %v, want %v", history, wantHistory)
+	}
+
+	quote, err = provider.GetQuote(ctx, "VOD.L")
+	if err != nil {
+		t.Fatalf("GetQuote() error = %v", err)
+	}
+	if quote.Currency != "GBP" || quote.Price != 70 || quote.PreviousClose != 71 {
+		t.Errorf("GetQuote() = %+v, expected prices in GBP", quote)
+	}
+
+	if _, err := provider.GetHistory(ctx, "EMPTY", time.Now().AddDate(0, -1, 0)); err == nil {
+		t.Error("expected error for missing closing prices")
+	}
+
+	if _, err := provider.GetQuote(ctx, "UNKNOWN"); err == nil {
+		t.Error("expected error for unknown symbol")
+	}
+
+	rate, err := provider.GetExchangeRate(ctx, "USD", "EUR")
+	if err != nil || rate != 0.9 {
+		t.Errorf("GetExchangeRate() = %v, %v, want 0.9", rate, err)
+	}
+}
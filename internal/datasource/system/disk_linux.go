@@ -0,0 +1,22 @@
+//go:build linux
+
+package system
+
+import (
+	"syscall"
+)
+
+func readDisk(mount string) (Disk, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(mount, &stat); err != nil {
+		return Disk{}, err
+	}
+
+	return Disk{
+		Mount: mount,
+		Total: stat.Blocks * uint64(stat.Bsize),
+		Used:  (stat.Blocks - stat.Bfree) * uint64(stat.Bsize),
+		// blocks reserved for root are not available to regular users
+		Free: stat.Bavail * uint64(stat.Bsize),
+	}, nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package system
+
+import (
+	"errors"
+)
+
+func readDisk(_ string) (Disk, error) {
+	return Disk{}, errors.New("disk usage is only supported on linux")
+}
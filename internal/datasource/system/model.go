@@ -0,0 +1,88 @@
+package system
+
+import (
+	"time"
+)
+
+type Load struct {
+	One     float64
+	Five    float64
+	Fifteen float64
+	Cpus    int
+}
+
+type Memory struct {
+	Total     uint64
+	Available uint64
+	SwapTotal uint64
+	SwapFree  uint64
+}
+
+func (m Memory) Used() uint64 {
+	return m.Total - m.Available
+}
+
+func (m Memory) Percent() float64 {
+	if m.Total == 0 {
+		return 0
+	}
+	return float64(m.Used()) / float64(m.Total) * 100
+}
+
+type Disk struct {
+	Mount string
+	Total uint64
+	Used  uint64
+	// Free is the space available to regular users, which excludes the blocks reserved for root.
+	Free uint64
+}
+
+// Percent returns the usage the way df does, relative to the space that is usable by regular users.
+func (d Disk) Percent() float64 {
+	if d.Used+d.Free == 0 {
+		return 0
+	}
+	return float64(d.Used) / float64(d.Used+d.Free) * 100
+}
+
+// Unit is the relevant subset of a unit as printed by `systemctl list-units --output=json`.
+type Unit struct {
+	Unit        string `json:"unit"`
+	Load        string `json:"load"`
+	Active      string `json:"active"`
+	Sub         string `json:"sub"`
+	Description string `json:"description"`
+}
+
+type Thresholds struct {
+	// Disk and Memory are the usage in percent from which on a warning is issued.
+	Disk   float64
+	Memory float64
+	// Load is the load average per CPU from which on a warning is issued.
+	Load float64
+}
+
+type DiskRow struct {
+	Disk
+	Percent  float64
+	CssClass string
+}
+
+type SystemData struct {
+	HtmlId   string
+	Hostname string
+	Uptime   time.Duration
+
+	Load          Load
+	LoadCssClass  string
+	Memory        Memory
+	MemoryPercent float64
+	MemoryCss     string
+	Disks         []DiskRow
+
+	FailedUnits    []Unit
+	RebootRequired bool
+	RebootPackages []string
+
+	Errors []string
+}
@@ -0,0 +1,82 @@
+package system
+
+import (
+	"errors"
+	"html/template"
+	"os"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *SystemDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("system-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithMounts sets the mount points to report the disk usage for, by default all physical filesystems are reported.
+func WithMounts(mounts []string) Opt {
+	return func(ds *SystemDatasource) error {
+		if len(mounts) == 0 {
+			return errors.New("no mounts supplied")
+		}
+
+		ds.mounts = mounts
+		return nil
+	}
+}
+
+// WithUnitsProvider enables reporting failed systemd units.
+func WithUnitsProvider(provider UnitsProvider) Opt {
+	return func(ds *SystemDatasource) error {
+		if provider == nil {
+			return errors.New("nil units provider supplied")
+		}
+
+		ds.units = provider
+		return nil
+	}
+}
+
+func WithRebootMarkers(markers []string) Opt {
+	return func(ds *SystemDatasource) error {
+		ds.rebootMarkers = markers
+		return nil
+	}
+}
+
+func WithThresholds(thresholds Thresholds) Opt {
+	return func(ds *SystemDatasource) error {
+		if thresholds.Disk > 0 {
+			ds.thresholds.Disk = thresholds.Disk
+		}
+		if thresholds.Memory > 0 {
+			ds.thresholds.Memory = thresholds.Memory
+		}
+		if thresholds.Load > 0 {
+			ds.thresholds.Load = thresholds.Load
+		}
+		return nil
+	}
+}
+
+// WithProcDir allows reading the proc filesystem from a different location, e.g. when running in a container.
+func WithProcDir(dir string) Opt {
+	return func(ds *SystemDatasource) error {
+		if len(dir) == 0 {
+			return errors.New("empty proc dir supplied")
+		}
+
+		ds.procDir = dir
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+package system
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// physicalFilesystems are the filesystems considered if no mounts are configured explicitly.
+var physicalFilesystems = []string{"ext2", "ext3", "ext4", "xfs", "btrfs", "zfs", "f2fs", "vfat", "exfat", "ntfs", "ntfs3", "jfs", "reiserfs"}
+
+func readLoad(procDir string) (Load, error) {
+	data, err := os.ReadFile(filepath.Join(procDir, "loadavg"))
+	if err != nil {
+		return Load{}, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return Load{}, fmt.Errorf("invalid loadavg %q", data)
+	}
+
+	var values [3]float64
+	for i := range values {
+		values[i], err = strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Load{}, fmt.Errorf("invalid loadavg %q: %w", data, err)
+		}
+	}
+
+	return Load{
+		One:     values[0],
+		Five:    values[1],
+		Fifteen: values[2],
+		Cpus:    runtime.NumCPU(),
+	}, nil
+}
+
+func readMemory(procDir string) (Memory, error) {
+	file, err := os.Open(filepath.Join(procDir, "meminfo"))
+	if err != nil {
+		return Memory{}, err
+	}
+
+	defer func() {
+		_ = file.Close()
+	}()
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// MemTotal:       16318480 kB
+		key, rest, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+
+		val, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if len(fields) > 1 && fields[1] == "kB" {
+			val *= 1024
+		}
+		values[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Memory{}, err
+	}
+
+	if _, found := values["MemTotal"]; !found {
+		return Memory{}, fmt.Errorf("no MemTotal in %s", file.Name())
+	}
+
+	return Memory{
+		Total:     values["MemTotal"],
+		Available: values["MemAvailable"],
+		SwapTotal: values["SwapTotal"],
+		SwapFree:  values["SwapFree"],
+	}, nil
+}
+
+func readUptime(procDir string) (time.Duration, error) {
+	data, err := os.ReadFile(filepath.Join(procDir, "uptime"))
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid uptime %q", data)
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid uptime %q: %w", data, err)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// readMounts returns the mount points of physical filesystems.
+func readMounts(procDir string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(procDir, "mounts"))
+	if err != nil {
+		return nil, err
+	}
+
+	var mounts []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		// /dev/nvme0n1p2 / ext4 rw,relatime 0 0
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !slices.Contains(physicalFilesystems, fields[2]) {
+			continue
+		}
+
+		// spaces and other special characters are octal escaped
+		mount, err := strconv.Unquote(`"` + strings.ReplaceAll(fields[1], `"`, `\"`) + `"`)
+		if err != nil {
+			mount = fields[1]
+		}
+
+		if !slices.Contains(mounts, mount) {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	return mounts, scanner.Err()
+}
+
+// readRebootMarkers returns whether any of the marker files exists and the packages that require the reboot, as listed
+// in the accompanying .pkgs file on Debian-based systems.
+func readRebootMarkers(markers []string) (bool, []string) {
+	for _, marker := range markers {
+		if _, err := os.Stat(marker); err != nil {
+			continue
+		}
+
+		var packages []string
+		if data, err := os.ReadFile(marker + ".pkgs"); err == nil {
+			for _, pkg := range strings.Fields(string(data)) {
+				if !slices.Contains(packages, pkg) {
+					packages = append(packages, pkg)
+				}
+			}
+		}
+		return true, packages
+	}
+
+	return false, nil
+}
@@ -0,0 +1,81 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/soerenschneider/aether/pkg"
+)
+
+func getCssClass(value, threshold float64) string {
+	if value >= threshold {
+		return "red"
+	}
+	if value >= threshold*0.9 {
+		return "yellow"
+	}
+	return "green"
+}
+
+func buildDiskRows(disks []Disk, threshold float64) []DiskRow {
+	rows := make([]DiskRow, 0, len(disks))
+	for _, disk := range disks {
+		rows = append(rows, DiskRow{
+			Disk:     disk,
+			Percent:  disk.Percent(),
+			CssClass: getCssClass(disk.Percent(), threshold),
+		})
+	}
+	return rows
+}
+
+func formatUptime(uptime time.Duration) string {
+	days := int(uptime.Hours() / 24)
+	hours := int(uptime.Hours()) % 24
+	if days > 0 {
+		return fmt.Sprintf("%dd %dh", days, hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, int(uptime.Minutes())%60)
+}
+
+func getSummary(data SystemData, thresholds Thresholds) []string {
+	var summary []string
+
+	if len(data.FailedUnits) > 0 {
+		names := make([]string, 0, len(data.FailedUnits))
+		for _, unit := range data.FailedUnits {
+			names = append(names, unit.Unit)
+		}
+
+		noun := "units"
+		if len(names) == 1 {
+			noun = "unit"
+		}
+		summary = append(summary, fmt.Sprintf("❌ %d failed %s: %s", len(names), noun, strings.Join(names, ", ")))
+	}
+
+	for _, disk := range data.Disks {
+		if disk.Percent >= thresholds.Disk {
+			summary = append(summary, fmt.Sprintf("💾 Disk %s at %.0f%% (%s free)", disk.Mount, disk.Percent, pkg.FormatBytes(disk.Free)))
+		}
+	}
+
+	if data.Memory.Total > 0 && data.MemoryPercent >= thresholds.Memory {
+		summary = append(summary, fmt.Sprintf("🧠 Memory usage at %.0f%%", data.MemoryPercent))
+	}
+
+	if data.Load.Cpus > 0 && data.Load.Five/float64(data.Load.Cpus) >= thresholds.Load {
+		summary = append(summary, fmt.Sprintf("🔥 Load %.2f on %d CPUs", data.Load.Five, data.Load.Cpus))
+	}
+
+	if data.RebootRequired {
+		line := "🔄 Reboot required"
+		if len(data.RebootPackages) > 0 {
+			line = fmt.Sprintf("%s by %s", line, strings.Join(data.RebootPackages, ", "))
+		}
+		summary = append(summary, line)
+	}
+
+	return summary
+}
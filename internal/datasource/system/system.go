@@ -0,0 +1,179 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const defaultProcDir = "/proc"
+
+var defaultRebootMarkers = []string{"/var/run/reboot-required", "/run/reboot-required"}
+
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		Disk:   90,
+		Memory: 90,
+		Load:   1.5,
+	}
+}
+
+type SystemDatasource struct {
+	procDir       string
+	mounts        []string
+	units         UnitsProvider
+	rebootMarkers []string
+	thresholds    Thresholds
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *SystemDatasource) error
+
+var funcMap = template.FuncMap{
+	"bytes":  pkg.FormatBytes,
+	"uptime": formatUptime,
+}
+
+func New(templateData templates.TemplateData, opts ...Opt) (*SystemDatasource, error) {
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &SystemDatasource{
+		procDir:       defaultProcDir,
+		rebootMarkers: defaultRebootMarkers,
+		thresholds:    DefaultThresholds(),
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("system-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("system-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *SystemDatasource) Name() string {
+	return "System"
+}
+
+// getSystemData collects as much information as possible, errors of individual parts are reported but not fatal.
+func (d *SystemDatasource) getSystemData(ctx context.Context) (SystemData, error) {
+	data := SystemData{}
+	var errs error
+	addErr := func(err error) {
+		log.Warn().Err(err).Msg("could not gather system information")
+		errs = multierr.Append(errs, err)
+		data.Errors = append(data.Errors, err.Error())
+	}
+
+	var err error
+	data.Hostname, _ = os.Hostname()
+
+	if data.Uptime, err = readUptime(d.procDir); err != nil {
+		addErr(err)
+	}
+
+	if data.Load, err = readLoad(d.procDir); err != nil {
+		addErr(err)
+	} else if data.Load.Cpus > 0 {
+		data.LoadCssClass = getCssClass(data.Load.Five/float64(data.Load.Cpus), d.thresholds.Load)
+	}
+
+	if data.Memory, err = readMemory(d.procDir); err != nil {
+		addErr(err)
+	} else {
+		data.MemoryPercent = data.Memory.Percent()
+		data.MemoryCss = getCssClass(data.MemoryPercent, d.thresholds.Memory)
+	}
+
+	mounts := d.mounts
+	if len(mounts) == 0 {
+		if mounts, err = readMounts(d.procDir); err != nil {
+			addErr(err)
+		}
+	}
+
+	var disks []Disk
+	for _, mount := range mounts {
+		disk, err := readDisk(mount)
+		if err != nil {
+			addErr(fmt.Errorf("could not read disk usage of %q: %w", mount, err))
+			continue
+		}
+		disks = append(disks, disk)
+	}
+	data.Disks = buildDiskRows(disks, d.thresholds.Disk)
+
+	if d.units != nil {
+		if data.FailedUnits, err = d.units.GetFailedUnits(ctx); err != nil {
+			addErr(err)
+		}
+	}
+
+	data.RebootRequired, data.RebootPackages = readRebootMarkers(d.rebootMarkers)
+
+	if data.Memory.Total == 0 && data.Load.Cpus == 0 && len(data.Disks) == 0 {
+		return data, errors.Join(errors.New("could not gather any system information"), errs)
+	}
+
+	return data, nil
+}
+
+func (d *SystemDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	data, err := d.getSystemData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data.HtmlId = pkg.NameToId(d.Name())
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data, d.thresholds)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
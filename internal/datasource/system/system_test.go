@@ -0,0 +1,215 @@
+package system
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadProc(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "loadavg", "0.52 1.25 2.00 2/1180 123456\n")
+	writeFile(t, dir, "uptime", "93784.12 351234.56\n")
+	writeFile(t, dir, "meminfo", `MemTotal:       16000000 kB
+MemFree:         1000000 kB
+MemAvailable:    4000000 kB
+Buffers:          500000 kB
+SwapTotal:       2000000 kB
+SwapFree:        1500000 kB
+HugePages_Total:       0
+`)
+	writeFile(t, dir, "mounts", `/dev/nvme0n1p2 / ext4 rw,relatime 0 0
+proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+tmpfs /run tmpfs rw,nosuid,nodev 0 0
+/dev/nvme0n1p1 /boot/efi vfat rw,relatime 0 0
+/dev/sda1 /mnt/my\040data btrfs rw 0 0
+/dev/nvme0n1p2 / ext4 rw,relatime 0 0
+`)
+
+	load, err := readLoad(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLoad := Load{One: 0.52, Five: 1.25, Fifteen: 2, Cpus: runtime.NumCPU()}
+	if !reflect.DeepEqual(load, wantLoad) {
+		t.Errorf("readLoad() = %v, want %v", load, wantLoad)
+	}
+
+	uptime, err := readUptime(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 26*time.Hour + 3*time.Minute + 4*time.Second; uptime != want {
+		t.Errorf("readUptime() = %v, want %v", uptime, want)
+	}
+
+	memory, err := readMemory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMemory := Memory{Total: 16000000 * 1024, Available: 4000000 * 1024, SwapTotal: 2000000 * 1024, SwapFree: 1500000 * 1024}
+	if !reflect.DeepEqual(memory, wantMemory) {
+		t.Errorf("readMemory() = %v, want %v", memory, wantMemory)
+	}
+	if memory.Percent() != 75 {
+		t.Errorf("Percent() = %v, want 75", memory.Percent())
+	}
+
+	mounts, err := readMounts(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantMounts := []string{"/", "/boot/efi", "/mnt/my data"}
+	if !reflect.DeepEqual(mounts, wantMounts) {
+		t.Errorf("readMounts() = %v, want %v", mounts, wantMounts)
+	}
+
+	if _, err := readLoad(t.TempDir()); err == nil {
+		t.Error("expected error for missing loadavg")
+	}
+}
+
+func TestReadRebootMarkers(t *testing.T) {
+	dir := t.TempDir()
+	marker := writeFile(t, dir, "reboot-required", "*** System restart required ***\n")
+	writeFile(t, dir, "reboot-required.pkgs", "linux-image-6.1.0-18-amd64\nlibc6\nlibc6\n")
+
+	tests := []struct {
+		name         string
+		markers      []string
+		wantRequired bool
+		wantPackages []string
+	}{
+		{
+			name:    "no marker",
+			markers: []string{filepath.Join(dir, "missing")},
+		},
+		{
+			name:         "marker with packages",
+			markers:      []string{filepath.Join(dir, "missing"), marker},
+			wantRequired: true,
+			wantPackages: []string{"linux-image-6.1.0-18-amd64", "libc6"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required, packages := readRebootMarkers(tt.markers)
+			if required != tt.wantRequired {
+				t.Errorf("readRebootMarkers() required = %v, want %v", required, tt.wantRequired)
+			}
+			if !reflect.DeepEqual(packages, tt.wantPackages) {
+				t.Errorf("readRebootMarkers() packages = %v, want %v", packages, tt.wantPackages)
+			}
+		})
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	data := `[{"unit":"backup.service","load":"loaded","active":"failed","sub":"failed","description":"Nightly backup"},{"unit":"certbot.timer","load":"loaded","active":"failed","sub":"failed","description":"Renew certs"}]`
+
+	got, err := parseUnits([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Unit{
+		{Unit: "backup.service", Load: "loaded", Active: "failed", Sub: "failed", Description: "Nightly backup"},
+		{Unit: "certbot.timer", Load: "loaded", Active: "failed", Sub: "failed", Description: "Renew certs"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseUnits() = %v, want %v", got, want)
+	}
+
+	if _, err := parseUnits([]byte("UNIT LOAD ACTIVE")); err == nil {
+		t.Error("expected error for non-json output")
+	}
+}
+
+func TestDiskPercent(t *testing.T) {
+	tests := []struct {
+		name string
+		disk Disk
+		want float64
+	}{
+		{name: "empty", disk: Disk{}, want: 0},
+		{name: "no reserved blocks", disk: Disk{Total: 100, Used: 25, Free: 75}, want: 25},
+		// like df, the blocks reserved for root are not taken into account
+		{name: "reserved blocks", disk: Disk{Total: 100, Used: 76, Free: 19}, want: 80},
+		{name: "reserved blocks in use", disk: Disk{Total: 100, Used: 98, Free: 0}, want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.disk.Percent(); got != tt.want {
+				t.Errorf("Percent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	const gib = 1024 * 1024 * 1024
+
+	tests := []struct {
+		name string
+		data SystemData
+		want []string
+	}{
+		{
+			name: "all fine",
+			data: SystemData{
+				Load:          Load{Five: 1, Cpus: 4},
+				Memory:        Memory{Total: 10 * gib, Available: 5 * gib},
+				MemoryPercent: 50,
+				Disks:         buildDiskRows([]Disk{{Mount: "/", Total: 100 * gib, Used: 50 * gib, Free: 50 * gib}}, 90),
+			},
+		},
+		{
+			name: "everything broken",
+			data: SystemData{
+				Load:          Load{Five: 8.2, Cpus: 4},
+				Memory:        Memory{Total: 10 * gib, Available: 0.5 * gib},
+				MemoryPercent: 95,
+				Disks: buildDiskRows([]Disk{
+					{Mount: "/", Total: 100 * gib, Used: 88 * gib, Free: 7 * gib},
+					{Mount: "/boot", Total: 1 * gib, Used: 0.5 * gib, Free: 0.5 * gib},
+				}, 90),
+				FailedUnits:    []Unit{{Unit: "backup.service"}, {Unit: "certbot.timer"}},
+				RebootRequired: true,
+				RebootPackages: []string{"libc6"},
+			},
+			want: []string{
+				"❌ 2 failed units: backup.service, certbot.timer",
+				"💾 Disk / at 93% (7.0 GiB free)",
+				"🧠 Memory usage at 95%",
+				"🔥 Load 8.20 on 4 CPUs",
+				"🔄 Reboot required by libc6",
+			},
+		},
+		{
+			name: "single failed unit",
+			data: SystemData{
+				FailedUnits: []Unit{{Unit: "backup.service"}},
+			},
+			want: []string{"❌ 1 failed unit: backup.service"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.data, DefaultThresholds()); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
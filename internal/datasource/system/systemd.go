@@ -0,0 +1,44 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+type UnitsProvider interface {
+	GetFailedUnits(ctx context.Context) ([]Unit, error)
+}
+
+// SystemctlProvider gets the failed units from `systemctl`.
+type SystemctlProvider struct {
+	user bool
+}
+
+func NewSystemctlProvider(user bool) *SystemctlProvider {
+	return &SystemctlProvider{user: user}
+}
+
+func (p *SystemctlProvider) GetFailedUnits(ctx context.Context) ([]Unit, error) {
+	args := []string{"list-units", "--state=failed", "--output=json", "--no-pager"}
+	if p.user {
+		args = append(args, "--user")
+	}
+
+	out, err := exec.CommandContext(ctx, "systemctl", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("could not list failed units: %w", err)
+	}
+
+	return parseUnits(out)
+}
+
+func parseUnits(data []byte) ([]Unit, error) {
+	var units []Unit
+	if err := json.Unmarshal(data, &units); err != nil {
+		return nil, fmt.Errorf("could not parse units: %w", err)
+	}
+
+	return units, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"html/template"
 	"os"
+
+	"github.com/soerenschneider/aether/internal/calendar"
 )
 
 func WithLimit(limit int) Opt {
@@ -28,6 +30,18 @@ func WithSummaryDays(days int) Opt {
 	}
 }
 
+// WithCalendar only counts working days when looking for tasks due soon.
+func WithCalendar(cal *calendar.Calendar) Opt {
+	return func(datasource *Datasource) error {
+		if cal == nil {
+			return errors.New("empty calendar supplied")
+		}
+
+		datasource.calendar = cal
+		return nil
+	}
+}
+
 func WithTemplateFile(file string) Opt {
 	return func(ds *Datasource) error {
 		data, err := os.ReadFile(file)
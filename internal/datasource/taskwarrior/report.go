@@ -3,11 +3,22 @@ package taskwarrior
 import (
 	"fmt"
 	"time"
+
+	"github.com/soerenschneider/aether/internal/calendar"
 )
 
-func GenerateReport(tasks []Task, now time.Time, addSummaryForNoEvents bool, n int) []string {
+// GenerateReport summarizes overdue tasks and tasks due within the next n days. If a calendar is given, only working
+// days are counted.
+func GenerateReport(tasks []Task, now time.Time, addSummaryForNoEvents bool, n int, cal *calendar.Calendar) []string {
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 
+	days := "days"
+	windowEnd := today.AddDate(0, 0, n)
+	if cal != nil {
+		days = "working days"
+		windowEnd = cal.AddWorkingDays(today, n)
+	}
+
 	// Track affected tasks
 	var overdueTasks []Task
 	var dueTodayTasks []Task
@@ -27,7 +38,7 @@ func GenerateReport(tasks []Task, now time.Time, addSummaryForNoEvents bool, n i
 			overdueTasks = append(overdueTasks, task)
 		} else if dueDate.Equal(today) {
 			dueTodayTasks = append(dueTodayTasks, task)
-		} else if n > 0 && dueDate.After(today) && dueDate.Before(today.Add(time.Duration(n)*24*time.Hour)) {
+		} else if n > 0 && dueDate.After(today) && dueDate.Before(windowEnd) {
 			dueInNDaysTasks = append(dueInNDaysTasks, task)
 		}
 	}
@@ -51,14 +62,14 @@ func GenerateReport(tasks []Task, now time.Time, addSummaryForNoEvents bool, n i
 
 	if len(dueInNDaysTasks) > 0 {
 		if len(dueInNDaysTasks) == 1 {
-			report = append(report, fmt.Sprintf("📋 1 Task due within the next %d days: %q", n, dueInNDaysTasks[0].Description))
+			report = append(report, fmt.Sprintf("📋 1 Task due within the next %d %s: %q", n, days, dueInNDaysTasks[0].Description))
 		} else {
-			report = append(report, fmt.Sprintf("📋 %d tasks due within the next %d days", len(dueInNDaysTasks), n))
+			report = append(report, fmt.Sprintf("📋 %d tasks due within the next %d %s", len(dueInNDaysTasks), n, days))
 		}
 	}
 
 	if addSummaryForNoEvents && len(report) == 0 {
-		report = append(report, fmt.Sprintf("✅ No tasks due next %d days", n))
+		report = append(report, fmt.Sprintf("✅ No tasks due next %d %s", n, days))
 	}
 
 	return report
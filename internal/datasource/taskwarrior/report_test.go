@@ -0,0 +1,91 @@
+package taskwarrior
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/soerenschneider/aether/internal/calendar"
+)
+
+func TestGenerateReport(t *testing.T) {
+	germany, err := calendar.New("DE", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Thursday before Easter: Good Friday, the weekend and Easter Monday are no working days
+	now := time.Date(2025, 4, 17, 9, 0, 0, 0, time.UTC)
+	due := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 12, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		tasks []Task
+		n     int
+		cal   *calendar.Calendar
+		want  []string
+	}{
+		{
+			name: "calendar days",
+			tasks: []Task{
+				{Description: "good friday", Due: due(4, 18)},
+				{Description: "tuesday", Due: due(4, 22)},
+			},
+			n:    2,
+			want: []string{`📋 1 Task due within the next 2 days: "good friday"`},
+		},
+		{
+			name: "working days skip holidays and the weekend",
+			tasks: []Task{
+				{Description: "good friday", Due: due(4, 18)},
+				{Description: "easter monday", Due: due(4, 21)},
+				{Description: "tuesday", Due: due(4, 22)},
+				{Description: "wednesday", Due: due(4, 23)},
+			},
+			n:    2,
+			cal:  germany,
+			want: []string{"📋 3 tasks due within the next 2 working days"},
+		},
+		{
+			name: "single task within working days",
+			tasks: []Task{
+				{Description: "tuesday", Due: due(4, 22)},
+			},
+			n:    2,
+			cal:  germany,
+			want: []string{`📋 1 Task due within the next 2 working days: "tuesday"`},
+		},
+		{
+			name: "overdue and due today",
+			tasks: []Task{
+				{Description: "yesterday", Due: due(4, 16)},
+				{Description: "today", Due: due(4, 17)},
+				{Description: "no due date"},
+			},
+			n:   2,
+			cal: germany,
+			want: []string{
+				`❗📋 1 Overdue task: "yesterday"`,
+				`📋 1 Task due today: "today"`,
+			},
+		},
+		{
+			name: "nothing due",
+			tasks: []Task{
+				{Description: "next month", Due: due(5, 20)},
+			},
+			n:    2,
+			cal:  germany,
+			want: []string{"✅ No tasks due next 2 working days"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GenerateReport(tt.tasks, now, true, tt.n, tt.cal); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GenerateReport() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
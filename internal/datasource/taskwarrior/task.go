@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/calendar"
 	"github.com/soerenschneider/aether/internal/templates"
 	"go.uber.org/multierr"
 )
@@ -34,6 +35,7 @@ type Datasource struct {
 	client          Client
 
 	summaryDays        int
+	calendar           *calendar.Calendar
 	excludeFromSummary bool
 }
 
@@ -114,7 +116,7 @@ func (t *Datasource) GetData(ctx context.Context) (*internal.Data, error) {
 
 	var summary []string
 	if !t.excludeFromSummary {
-		summary = GenerateReport(tasks, time.Now(), true, defaultSummaryLookForwardDays)
+		summary = GenerateReport(tasks, time.Now(), true, t.summaryDays, t.calendar)
 	}
 
 	return &internal.Data{
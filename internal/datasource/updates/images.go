@@ -0,0 +1,282 @@
+package updates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	dockerHub         = "docker.io"
+	dockerHubEndpoint = "registry-1.docker.io"
+)
+
+var errPinned = errors.New("image is pinned by digest")
+
+// reference is a normalized image reference, e.g. docker.io/library/nginx:latest.
+type reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+}
+
+func parseReference(ref string) (reference, error) {
+	if ref == "" {
+		return reference{}, errors.New("empty image reference")
+	}
+
+	if strings.Contains(ref, "@") {
+		return reference{}, errPinned
+	}
+
+	ret := reference{Registry: dockerHub, Tag: "latest"}
+	remainder := ref
+	if idx := strings.Index(ref, "/"); idx > 0 {
+		domain := ref[:idx]
+		if strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			ret.Registry = domain
+			remainder = ref[idx+1:]
+		}
+	}
+
+	if ret.Registry == "index.docker.io" {
+		ret.Registry = dockerHub
+	}
+
+	// a colon after the last slash separates the tag, others belong to the registry's port
+	if idx := strings.LastIndex(remainder, ":"); idx > strings.LastIndex(remainder, "/") {
+		ret.Tag = remainder[idx+1:]
+		remainder = remainder[:idx]
+	}
+
+	if remainder == "" || ret.Tag == "" {
+		return reference{}, fmt.Errorf("invalid image reference %q", ref)
+	}
+
+	ret.Repository = remainder
+	if ret.Registry == dockerHub && !strings.Contains(remainder, "/") {
+		ret.Repository = "library/" + remainder
+	}
+
+	return ret, nil
+}
+
+// hexId matches image ids that are shown instead of a name for images that lost their tag.
+var hexId = regexp.MustCompile(`^(sha256:)?[0-9a-f]{12,64}$`)
+
+func (d *UpdatesDatasource) getRunningImages(ctx context.Context) ([]string, error) {
+	out, err := d.run(ctx, d.runtime, "ps", "--format", "{{.Image}}")
+	if err != nil {
+		return nil, err
+	}
+
+	var images []string
+	for _, line := range strings.Split(string(out), "\n") {
+		image := strings.TrimSpace(line)
+		if image == "" || hexId.MatchString(image) || strings.Contains(image, "@") || slices.Contains(images, image) {
+			continue
+		}
+		images = append(images, image)
+	}
+
+	slices.Sort(images)
+	return images, nil
+}
+
+// getLocalDigests returns the digests the local image is known by in the registry it has been pulled from.
+func (d *UpdatesDatasource) getLocalDigests(ctx context.Context, image string, ref reference) ([]string, error) {
+	out, err := d.run(ctx, d.runtime, "image", "inspect", "--format", "{{json .RepoDigests}}", image)
+	if err != nil {
+		return nil, err
+	}
+
+	var repoDigests []string
+	if err := json.Unmarshal(out, &repoDigests); err != nil {
+		return nil, fmt.Errorf("could not parse repo digests: %w", err)
+	}
+
+	var digests []string
+	for _, repoDigest := range repoDigests {
+		repo, digest, found := strings.Cut(repoDigest, "@")
+		if !found {
+			continue
+		}
+
+		// re-use the tag so the repository part is normalized the same way
+		parsed, err := parseReference(repo + ":" + ref.Tag)
+		if err != nil || parsed != ref {
+			continue
+		}
+		digests = append(digests, digest)
+	}
+
+	return digests, nil
+}
+
+func (d *UpdatesDatasource) checkImage(ctx context.Context, name string) Image {
+	image := Image{Name: name}
+
+	ref, err := parseReference(name)
+	if err != nil {
+		image.Error = err.Error()
+		return image
+	}
+
+	image.LocalDigests, err = d.getLocalDigests(ctx, name, ref)
+	if err != nil {
+		image.Error = err.Error()
+		return image
+	}
+	if len(image.LocalDigests) == 0 {
+		image.Error = "no digest known for image, it has probably been built locally"
+		return image
+	}
+
+	image.RemoteDigest, err = d.registry.getDigest(ctx, ref)
+	if err != nil {
+		image.Error = err.Error()
+		return image
+	}
+
+	image.Outdated = !slices.Contains(image.LocalDigests, image.RemoteDigest)
+	return image
+}
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryClient queries the digest of a tag using the OCI distribution API. Registries requiring authentication
+// are supported as long as they hand out anonymous tokens, such as Docker Hub or ghcr.io do for public images.
+type registryClient struct {
+	httpClient *http.Client
+	// insecure contains the registries that are talked to using plain http, e.g. a local registry
+	insecure []string
+}
+
+func (r *registryClient) getDigest(ctx context.Context, ref reference) (string, error) {
+	host := ref.Registry
+	if host == dockerHub {
+		host = dockerHubEndpoint
+	}
+
+	scheme := "https"
+	if slices.Contains(r.insecure, ref.Registry) {
+		scheme = "http"
+	}
+
+	u := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", scheme, host, ref.Repository, url.PathEscape(ref.Tag))
+	resp, err := r.headManifest(ctx, u, "")
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.getToken(ctx, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", fmt.Errorf("could not get token for %s: %w", ref.Registry, err)
+		}
+
+		resp, err = r.headManifest(ctx, u, token)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status code from registry: %d", resp.StatusCode)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return "", errors.New("registry did not return a digest")
+	}
+
+	return digest, nil
+}
+
+func (r *registryClient) headManifest(ctx context.Context, u string, token string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body.Close()
+
+	return resp, nil
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+func (r *registryClient) getToken(ctx context.Context, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", fmt.Errorf("unsupported authentication scheme %q", scheme)
+	}
+
+	values := url.Values{}
+	realm := ""
+	for _, match := range challengeParam.FindAllStringSubmatch(params, -1) {
+		if match[1] == "realm" {
+			realm = match[2]
+		} else {
+			values.Set(match[1], match[2])
+		}
+	}
+
+	if realm == "" {
+		return "", errors.New("no realm in authentication challenge")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+values.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := r.httpClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	if token.AccessToken != "" {
+		return token.AccessToken, nil
+	}
+	return "", errors.New("empty token received")
+}
@@ -0,0 +1,43 @@
+package updates
+
+const (
+	ManagerAuto = "auto"
+	ManagerApt  = "apt"
+	ManagerDnf  = "dnf"
+	ManagerApk  = "apk"
+
+	RuntimeDocker = "docker"
+	RuntimePodman = "podman"
+)
+
+type Package struct {
+	Name      string
+	Current   string
+	Available string
+	Origin    string
+	Security  bool
+}
+
+type Image struct {
+	Name          string
+	LocalDigests  []string
+	RemoteDigest  string
+	Outdated      bool
+	Error         string
+	CssClass      string
+	StatusMessage string
+}
+
+type UpdatesData struct {
+	HtmlId string
+
+	Manager       string
+	Packages      []Package
+	Pending       int
+	Security      int
+	Hidden        int
+	PackagesError string
+
+	Images      []Image
+	ImagesError string
+}
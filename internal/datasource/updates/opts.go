@@ -0,0 +1,105 @@
+package updates
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *UpdatesDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("updates-default").Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithPackageManager checks for pending package updates, "auto" picks the package manager found in PATH.
+func WithPackageManager(manager string) Opt {
+	return func(ds *UpdatesDatasource) error {
+		if manager == ManagerAuto {
+			detected, err := detectManager()
+			if err != nil {
+				return err
+			}
+			manager = detected
+		}
+
+		if !slices.Contains([]string{ManagerApt, ManagerDnf, ManagerApk}, manager) {
+			return fmt.Errorf("unsupported package manager %q", manager)
+		}
+
+		ds.manager = manager
+		return nil
+	}
+}
+
+// WithContainers compares the digests of the given images against their registries. If no images are given, the
+// images of all running containers are checked.
+func WithContainers(runtime string, images []string, client *http.Client) Opt {
+	return func(ds *UpdatesDatasource) error {
+		if runtime != RuntimeDocker && runtime != RuntimePodman {
+			return fmt.Errorf("unsupported container runtime %q", runtime)
+		}
+
+		if client == nil {
+			return errors.New("empty http client provided")
+		}
+
+		ds.runtime = runtime
+		ds.images = images
+		if ds.registry == nil {
+			ds.registry = &registryClient{}
+		}
+		ds.registry.httpClient = client
+		return nil
+	}
+}
+
+// WithInsecureRegistries talks plain http to the given registries, e.g. a local registry such as localhost:5000.
+func WithInsecureRegistries(registries []string) Opt {
+	return func(ds *UpdatesDatasource) error {
+		if ds.registry == nil {
+			ds.registry = &registryClient{}
+		}
+		ds.registry.insecure = registries
+		return nil
+	}
+}
+
+// WithLimit sets the maximum number of packages that are listed, all packages are counted regardless.
+func WithLimit(limit int) Opt {
+	return func(ds *UpdatesDatasource) error {
+		if limit < 1 {
+			return errors.New("limit can not be < 1")
+		}
+
+		ds.limit = limit
+		return nil
+	}
+}
+
+// WithTimeout sets the timeout for all checks, refreshing the dnf metadata may take a while.
+func WithTimeout(timeout time.Duration) Opt {
+	return func(ds *UpdatesDatasource) error {
+		if timeout < time.Second || timeout > 10*time.Minute {
+			return errors.New("timeout must be [1s, 10m]")
+		}
+
+		ds.timeout = timeout
+		return nil
+	}
+}
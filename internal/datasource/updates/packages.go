@@ -0,0 +1,191 @@
+package updates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// commandRunner runs an external command and returns its stdout.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	// the parsers rely on the untranslated output
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, msg)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return out, nil
+}
+
+// detectManager returns the first package manager found in PATH.
+func detectManager() (string, error) {
+	binaries := []struct {
+		manager string
+		binary  string
+	}{
+		{ManagerApt, "apt-get"},
+		{ManagerDnf, "dnf"},
+		{ManagerApk, "apk"},
+	}
+
+	for _, candidate := range binaries {
+		if _, err := exec.LookPath(candidate.binary); err == nil {
+			return candidate.manager, nil
+		}
+	}
+
+	return "", fmt.Errorf("none of apt-get, dnf or apk found in PATH")
+}
+
+func (d *UpdatesDatasource) getPackages(ctx context.Context) ([]Package, error) {
+	switch d.manager {
+	case ManagerApt:
+		// simulating an upgrade only reads the existing package lists and does not need root
+		out, err := d.run(ctx, "apt-get", "--simulate", "--quiet", "-o", "Debug::NoLocking=true", "upgrade")
+		if err != nil {
+			return nil, err
+		}
+		return parseApt(out), nil
+	case ManagerDnf:
+		out, err := d.run(ctx, "dnf", "--quiet", "list", "--upgrades")
+		if err != nil {
+			return nil, err
+		}
+		advisories, err := d.run(ctx, "dnf", "--quiet", "updateinfo", "list", "--security")
+		if err != nil {
+			return nil, err
+		}
+		return parseDnf(out, advisories), nil
+	case ManagerApk:
+		out, err := d.run(ctx, "apk", "version", "-l", "<")
+		if err != nil {
+			return nil, err
+		}
+		return parseApk(out), nil
+	default:
+		return nil, fmt.Errorf("unknown package manager %q", d.manager)
+	}
+}
+
+// aptInstallLine matches lines such as
+// Inst libssl3 [3.0.11-1~deb12u2] (3.0.13-1~deb12u1 Debian-Security:12/stable-security [amd64])
+var aptInstallLine = regexp.MustCompile(`^Inst (\S+)(?: \[([^\]]*)\])? \((\S+) (.*?)(?: \[[^\]]*\])?\)`)
+
+func parseApt(out []byte) []Package {
+	var packages []Package
+	for _, line := range strings.Split(string(out), "\n") {
+		match := aptInstallLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		origins := match[4]
+		packages = append(packages, Package{
+			Name:      match[1],
+			Current:   match[2],
+			Available: match[3],
+			Origin:    origins,
+			Security:  strings.Contains(strings.ToLower(origins), "-security"),
+		})
+	}
+	return packages
+}
+
+// splitVersion splits name-version-release into name and version-release, as used by both rpm and apk.
+func splitVersion(pkg string) (string, string) {
+	name := pkg
+	for range 2 {
+		idx := strings.LastIndex(name, "-")
+		if idx <= 0 {
+			return pkg, ""
+		}
+		name = name[:idx]
+	}
+	return name, pkg[len(name)+1:]
+}
+
+// rpmName turns name-version-release.arch into name.arch, the format used by dnf list.
+func rpmName(nevra string) string {
+	idx := strings.LastIndex(nevra, ".")
+	if idx <= 0 {
+		return nevra
+	}
+
+	name, _ := splitVersion(nevra[:idx])
+	return name + nevra[idx:]
+}
+
+func parseDnf(upgrades, advisories []byte) []Package {
+	security := map[string]bool{}
+	for _, line := range strings.Split(string(advisories), "\n") {
+		// FEDORA-2024-1a2b3c4d5e Important/Sec. openssl-libs-1:3.1.4-1.fc39.x86_64
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.HasSuffix(fields[1], "Sec.") {
+			continue
+		}
+		security[rpmName(fields[2])] = true
+	}
+
+	var packages []Package
+	for _, line := range strings.Split(string(upgrades), "\n") {
+		// openssl-libs.x86_64    1:3.1.4-1.fc39    updates
+		fields := strings.Fields(line)
+		if len(fields) != 3 || !strings.Contains(fields[0], ".") {
+			continue
+		}
+
+		packages = append(packages, Package{
+			Name:      fields[0],
+			Available: fields[1],
+			Origin:    fields[2],
+			Security:  security[fields[0]],
+		})
+	}
+	return packages
+}
+
+func parseApk(out []byte) []Package {
+	var packages []Package
+	for _, line := range strings.Split(string(out), "\n") {
+		// busybox-1.36.1-r15    < 1.36.1-r16
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[1] != "<" {
+			continue
+		}
+
+		name, current := splitVersion(fields[0])
+		packages = append(packages, Package{
+			Name:      name,
+			Current:   current,
+			Available: fields[2],
+		})
+	}
+	return packages
+}
+
+func sortPackages(packages []Package) {
+	slices.SortStableFunc(packages, func(a, b Package) int {
+		if a.Security != b.Security {
+			if a.Security {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
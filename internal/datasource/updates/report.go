@@ -0,0 +1,52 @@
+package updates
+
+import (
+	"fmt"
+	"strings"
+)
+
+func buildImageRows(images []Image) {
+	for idx := range images {
+		switch {
+		case images[idx].Error != "":
+			images[idx].CssClass = "red"
+			images[idx].StatusMessage = images[idx].Error
+		case images[idx].Outdated:
+			images[idx].CssClass = "orange"
+			images[idx].StatusMessage = "update available"
+		default:
+			images[idx].CssClass = "green"
+			images[idx].StatusMessage = "up to date"
+		}
+	}
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, singular)
+	}
+	return fmt.Sprintf("%d %s", count, plural)
+}
+
+func getSummary(data UpdatesData) []string {
+	var summary []string
+	if data.Pending > 0 {
+		line := "📦 " + pluralize(data.Pending, "update", "updates") + " pending"
+		if data.Security > 0 {
+			line += fmt.Sprintf(", %d security", data.Security)
+		}
+		summary = append(summary, line)
+	}
+
+	var outdated []string
+	for _, image := range data.Images {
+		if image.Outdated {
+			outdated = append(outdated, image.Name)
+		}
+	}
+	if len(outdated) > 0 {
+		summary = append(summary, fmt.Sprintf("🐳 %s outdated: %s", pluralize(len(outdated), "container image", "container images"), strings.Join(outdated, ", ")))
+	}
+
+	return summary
+}
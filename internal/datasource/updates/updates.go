@@ -0,0 +1,188 @@
+package updates
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"slices"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"github.com/sourcegraph/conc/pool"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultTimeout = 2 * time.Minute
+	defaultLimit   = 25
+)
+
+type UpdatesDatasource struct {
+	manager  string
+	runtime  string
+	images   []string
+	registry *registryClient
+	run      commandRunner
+	timeout  time.Duration
+	limit    int
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *UpdatesDatasource) error
+
+func New(templateData templates.TemplateData, opts ...Opt) (*UpdatesDatasource, error) {
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &UpdatesDatasource{
+		run:     runCommand,
+		timeout: defaultTimeout,
+		limit:   defaultLimit,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("updates-default").Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("updates-simple").Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if ds.manager == "" && ds.runtime == "" {
+		errs = multierr.Append(errs, errors.New("neither package manager nor container runtime supplied"))
+	}
+
+	return ds, errs
+}
+
+func (d *UpdatesDatasource) Name() string {
+	return "Updates"
+}
+
+func (d *UpdatesDatasource) getImages(ctx context.Context) ([]Image, error) {
+	names := d.images
+	if len(names) == 0 {
+		var err error
+		names, err = d.getRunningImages(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	images := make([]Image, len(names))
+	p := pool.New().WithMaxGoroutines(4)
+	for idx, name := range names {
+		p.Go(func() {
+			images[idx] = d.checkImage(ctx, name)
+			if images[idx].Error != "" {
+				log.Warn().Str("datasource", d.Name()).Str("image", name).Msg(images[idx].Error)
+			}
+		})
+	}
+	p.Wait()
+
+	return images, nil
+}
+
+func (d *UpdatesDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	data := UpdatesData{
+		HtmlId:  pkg.NameToId(d.Name()),
+		Manager: d.manager,
+	}
+
+	var packages []Package
+	var packagesErr, imagesErr error
+	p := pool.New()
+	if d.manager != "" {
+		p.Go(func() {
+			packages, packagesErr = d.getPackages(ctx)
+		})
+	}
+	if d.runtime != "" {
+		p.Go(func() {
+			data.Images, imagesErr = d.getImages(ctx)
+		})
+	}
+	p.Wait()
+
+	if packagesErr != nil {
+		log.Warn().Err(packagesErr).Str("datasource", d.Name()).Msg("could not check for package updates")
+		data.PackagesError = packagesErr.Error()
+	}
+	if imagesErr != nil {
+		log.Warn().Err(imagesErr).Str("datasource", d.Name()).Msg("could not list container images")
+		data.ImagesError = imagesErr.Error()
+	}
+
+	failedImages := len(data.Images) > 0 && !slices.ContainsFunc(data.Images, func(image Image) bool { return image.Error == "" })
+	if (d.manager == "" || packagesErr != nil) && (d.runtime == "" || imagesErr != nil || failedImages) {
+		errs := multierr.Combine(packagesErr, imagesErr)
+		if failedImages {
+			for _, image := range data.Images {
+				errs = multierr.Append(errs, fmt.Errorf("%s: %s", image.Name, image.Error))
+			}
+		}
+		return nil, fmt.Errorf("could not check for updates: %w", errs)
+	}
+
+	sortPackages(packages)
+	data.Pending = len(packages)
+	for _, update := range packages {
+		if update.Security {
+			data.Security++
+		}
+	}
+	if len(packages) > d.limit {
+		data.Hidden = len(packages) - d.limit
+		packages = packages[:d.limit]
+	}
+	data.Packages = packages
+	buildImageRows(data.Images)
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,267 @@
+package updates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseApt(t *testing.T) {
+	out := []byte(`NOTE: This is only a simulation!
+Reading package lists...
+The following packages will be upgraded:
+  libssl3 openssl tzdata
+Inst libssl3 [3.0.11-1~deb12u2] (3.0.13-1~deb12u1 Debian-Security:12/stable-security [amd64])
+Inst tzdata [2024a-0+deb12u1] (2025b-0+deb12u1 Debian:12.11/stable, Debian:12-updates/stable-updates [all])
+Inst linux-image-6.1.0-37-amd64 (6.1.140-1 Debian:12.11/stable [amd64])
+Conf libssl3 (3.0.13-1~deb12u1 Debian-Security:12/stable-security [amd64])
+`)
+
+	want := []Package{
+		{Name: "libssl3", Current: "3.0.11-1~deb12u2", Available: "3.0.13-1~deb12u1", Origin: "Debian-Security:12/stable-security", Security: true},
+		{Name: "tzdata", Current: "2024a-0+deb12u1", Available: "2025b-0+deb12u1", Origin: "Debian:12.11/stable, Debian:12-updates/stable-updates"},
+		{Name: "linux-image-6.1.0-37-amd64", Available: "6.1.140-1", Origin: "Debian:12.11/stable"},
+	}
+
+	if got := parseApt(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseApt() = %v, want %v", got, want)
+	}
+}
+
+func TestParseDnf(t *testing.T) {
+	upgrades := []byte(`Available Upgrades
+kernel-core.x86_64                 6.11.4-201.fc40           updates
+openssl-libs.x86_64                1:3.2.2-5.fc40            updates
+python3-requests.noarch            2.31.0-4.fc40             updates
+`)
+	advisories := []byte(`FEDORA-2024-1a2b3c4d5e Important/Sec. openssl-libs-1:3.2.2-5.fc40.x86_64
+FEDORA-2024-6f7e8d9c0b bugfix      python3-requests-2.31.0-4.fc40.noarch
+`)
+
+	want := []Package{
+		{Name: "kernel-core.x86_64", Available: "6.11.4-201.fc40", Origin: "updates"},
+		{Name: "openssl-libs.x86_64", Available: "1:3.2.2-5.fc40", Origin: "updates", Security: true},
+		{Name: "python3-requests.noarch", Available: "2.31.0-4.fc40", Origin: "updates"},
+	}
+
+	if got := parseDnf(upgrades, advisories); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDnf() = %v, want %v", got, want)
+	}
+}
+
+func TestParseApk(t *testing.T) {
+	out := []byte(`Installed:                                Available:
+busybox-1.36.1-r15                      < 1.36.1-r16
+ca-certificates-bundle-20240226-r0      < 20241121-r0
+`)
+
+	want := []Package{
+		{Name: "busybox", Current: "1.36.1-r15", Available: "1.36.1-r16"},
+		{Name: "ca-certificates-bundle", Current: "20240226-r0", Available: "20241121-r0"},
+	}
+
+	if got := parseApk(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseApk() = %v, want %v", got, want)
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    reference
+		wantErr bool
+	}{
+		{ref: "nginx", want: reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{ref: "nginx:1.27", want: reference{Registry: "docker.io", Repository: "library/nginx", Tag: "1.27"}},
+		{ref: "grafana/grafana:11.3.0", want: reference{Registry: "docker.io", Repository: "grafana/grafana", Tag: "11.3.0"}},
+		{ref: "docker.io/library/nginx", want: reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{ref: "index.docker.io/library/nginx", want: reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}},
+		{ref: "ghcr.io/soerenschneider/aether:main", want: reference{Registry: "ghcr.io", Repository: "soerenschneider/aether", Tag: "main"}},
+		{ref: "localhost:5000/app", want: reference{Registry: "localhost:5000", Repository: "app", Tag: "latest"}},
+		{ref: "localhost/app:dev", want: reference{Registry: "localhost", Repository: "app", Tag: "dev"}},
+		{ref: "nginx@sha256:abc", wantErr: true},
+		{ref: "nginx:", wantErr: true},
+		{ref: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseReference() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// serveRegistry serves the digests per repository and tag, requiring an anonymous token if token is not empty.
+func serveRegistry(t *testing.T, token string, digests map[string]string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if r.URL.Query().Get("scope") != "repository:library/nginx:pull" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"token": %q}`, token)
+			return
+		}
+
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:library/nginx:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Method != http.MethodHead || !strings.Contains(r.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		digest, found := digests[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRegistryClientGetDigest(t *testing.T) {
+	digests := map[string]string{"/v2/library/nginx/manifests/latest": "sha256:new"}
+	tests := []struct {
+		name    string
+		token   string
+		ref     reference
+		want    string
+		wantErr bool
+	}{
+		{name: "anonymous", ref: reference{Repository: "library/nginx", Tag: "latest"}, want: "sha256:new"},
+		{name: "token", token: "secret", ref: reference{Repository: "library/nginx", Tag: "latest"}, want: "sha256:new"},
+		{name: "unknown tag", ref: reference{Repository: "library/nginx", Tag: "unknown"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := serveRegistry(t, tt.token, digests)
+			registry := strings.TrimPrefix(server.URL, "http://")
+			client := &registryClient{httpClient: server.Client(), insecure: []string{registry}}
+
+			tt.ref.Registry = registry
+			got, err := client.getDigest(context.Background(), tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getDigest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetImages(t *testing.T) {
+	server := serveRegistry(t, "", map[string]string{
+		"/v2/app/manifests/latest": "sha256:new",
+		"/v2/web/manifests/1.0":    "sha256:current",
+	})
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	repoDigests := map[string]string{
+		registry + "/app":     fmt.Sprintf(`["%s/app@sha256:old"]`, registry),
+		registry + "/web:1.0": fmt.Sprintf(`["%s/web@sha256:other","%s/web@sha256:current"]`, registry, registry),
+		registry + "/own:1.0": `[]`,
+	}
+
+	ds := &UpdatesDatasource{
+		runtime:  RuntimePodman,
+		registry: &registryClient{httpClient: server.Client(), insecure: []string{registry}},
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			if name != "podman" {
+				return nil, fmt.Errorf("unexpected command %q", name)
+			}
+			if args[0] == "ps" {
+				return []byte(fmt.Sprintf("%s/web:1.0\n%s/app\n%s/web:1.0\n0123456789ab\n%s/own:1.0\n", registry, registry, registry, registry)), nil
+			}
+			digests, found := repoDigests[args[len(args)-1]]
+			if !found {
+				return nil, errors.New("no such image")
+			}
+			return []byte(digests), nil
+		},
+	}
+
+	want := []Image{
+		{Name: registry + "/app", LocalDigests: []string{"sha256:old"}, RemoteDigest: "sha256:new", Outdated: true},
+		{Name: registry + "/own:1.0", Error: "no digest known for image, it has probably been built locally"},
+		{Name: registry + "/web:1.0", LocalDigests: []string{"sha256:other", "sha256:current"}, RemoteDigest: "sha256:current"},
+	}
+
+	got, err := ds.getImages(context.Background())
+	if err != nil {
+		t.Fatalf("getImages() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getImages() = %v, want %v", got, want)
+	}
+}
+
+func TestGetSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		data UpdatesData
+		want []string
+	}{
+		{
+			name: "nothing pending",
+			data: UpdatesData{Images: []Image{{Name: "nginx"}}},
+		},
+		{
+			name: "updates and security updates",
+			data: UpdatesData{Pending: 12, Security: 3},
+			want: []string{"📦 12 updates pending, 3 security"},
+		},
+		{
+			name: "single update",
+			data: UpdatesData{Pending: 1},
+			want: []string{"📦 1 update pending"},
+		},
+		{
+			name: "outdated images",
+			data: UpdatesData{Images: []Image{{Name: "nginx", Outdated: true}, {Name: "app"}, {Name: "grafana/grafana", Outdated: true}}},
+			want: []string{"🐳 2 container images outdated: nginx, grafana/grafana"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetData_failedImages(t *testing.T) {
+	ds := &UpdatesDatasource{
+		runtime: RuntimePodman,
+		images:  []string{"example.com/app:1.0"},
+		timeout: time.Second,
+		run: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			return nil, errors.New("no such image")
+		},
+	}
+
+	_, err := ds.GetData(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "example.com/app:1.0: ") || strings.Contains(err.Error(), "%!") {
+		t.Errorf("GetData() error = %v, want the cause of the failed image", err)
+	}
+}
@@ -0,0 +1,41 @@
+package waste
+
+import (
+	"time"
+)
+
+// Bin describes a type of bin and how to recognize its collections.
+type Bin struct {
+	Name     string
+	Emoji    string
+	CssClass string
+	// Match holds case-insensitive substrings that identify a collection of this bin, e.g. "Papier" or "paper".
+	Match []string
+}
+
+// DefaultBins are used if no bins are configured.
+var DefaultBins = []Bin{
+	{Name: "Paper", Emoji: "📦", CssClass: "blue", Match: []string{"paper", "papier", "altpapier", "pappe"}},
+	{Name: "Organic", Emoji: "🍂", CssClass: "green", Match: []string{"bio", "organic", "compost"}},
+	{Name: "Packaging", Emoji: "♻️", CssClass: "yellow", Match: []string{"gelb", "yellow", "packaging", "plastic", "wertstoff"}},
+	{Name: "Glass", Emoji: "🍾", CssClass: "lightblue", Match: []string{"glas"}},
+	{Name: "Residual", Emoji: "🗑️", CssClass: "", Match: []string{"rest", "residual", "general", "trash"}},
+}
+
+// Collection is a single collection as read from a source, Name is the raw name, e.g. the summary of an event.
+type Collection struct {
+	Date time.Time
+	Name string
+}
+
+type BinCollections struct {
+	Bin   Bin
+	Dates []time.Time
+}
+
+type WasteData struct {
+	HtmlId   string
+	Bins     []BinCollections
+	Today    time.Time
+	Tomorrow time.Time
+}
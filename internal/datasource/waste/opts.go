@@ -0,0 +1,80 @@
+package waste
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"time"
+)
+
+func WithTemplateFile(file string) Opt {
+	return func(ds *WasteDatasource) error {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		temp, err := template.New("waste-default").Funcs(funcMap).Parse(string(data))
+		if err != nil {
+			return err
+		}
+
+		ds.defaultTemplate = temp
+		return nil
+	}
+}
+
+// WithBins replaces the default bins.
+func WithBins(bins []Bin) Opt {
+	return func(ds *WasteDatasource) error {
+		if len(bins) == 0 {
+			return errors.New("no bins supplied")
+		}
+
+		for _, bin := range bins {
+			if bin.Name == "" {
+				return errors.New("empty bin name supplied")
+			}
+		}
+
+		ds.bins = bins
+		return nil
+	}
+}
+
+// WithHorizon sets how far into the future collections are read.
+func WithHorizon(horizon time.Duration) Opt {
+	return func(ds *WasteDatasource) error {
+		if horizon < 24*time.Hour {
+			return errors.New("horizon must be at least 24h")
+		}
+
+		ds.horizon = horizon
+		return nil
+	}
+}
+
+// WithCount sets the number of upcoming collections displayed per bin.
+func WithCount(count int) Opt {
+	return func(ds *WasteDatasource) error {
+		if count < 1 {
+			return errors.New("count can not be < 1")
+		}
+
+		ds.count = count
+		return nil
+	}
+}
+
+// WithEveningHour sets the hour from which on the summary reminds of the next day's collections.
+func WithEveningHour(hour int) Opt {
+	return func(ds *WasteDatasource) error {
+		if hour < 0 || hour > 23 {
+			return fmt.Errorf("invalid hour %d", hour)
+		}
+
+		ds.eveningHour = hour
+		return nil
+	}
+}
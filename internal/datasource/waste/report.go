@@ -0,0 +1,91 @@
+package waste
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func getBin(name string, bins []Bin) Bin {
+	lower := strings.ToLower(name)
+	for _, bin := range bins {
+		if strings.EqualFold(bin.Name, name) {
+			return bin
+		}
+
+		for _, match := range bin.Match {
+			if strings.Contains(lower, strings.ToLower(match)) {
+				return bin
+			}
+		}
+	}
+
+	return Bin{Name: name, Emoji: "🗑️"}
+}
+
+// buildBinCollections groups the collections by bin and keeps the next count dates per bin. The bins are ordered by
+// their next collection.
+func buildBinCollections(collections []Collection, bins []Bin, count int, today time.Time) []BinCollections {
+	var ret []BinCollections
+	index := map[string]int{}
+
+	for _, collection := range collections {
+		if collection.Date.Before(today) {
+			continue
+		}
+
+		bin := getBin(collection.Name, bins)
+		pos, found := index[bin.Name]
+		if !found {
+			pos = len(ret)
+			index[bin.Name] = pos
+			ret = append(ret, BinCollections{Bin: bin})
+		}
+
+		if !slices.ContainsFunc(ret[pos].Dates, collection.Date.Equal) {
+			ret[pos].Dates = append(ret[pos].Dates, collection.Date)
+		}
+	}
+
+	for i := range ret {
+		slices.SortFunc(ret[i].Dates, func(a, b time.Time) int {
+			return a.Compare(b)
+		})
+		if len(ret[i].Dates) > count {
+			ret[i].Dates = ret[i].Dates[:count]
+		}
+	}
+
+	slices.SortStableFunc(ret, func(a, b BinCollections) int {
+		if c := a.Dates[0].Compare(b.Dates[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Bin.Name, b.Bin.Name)
+	})
+
+	return ret
+}
+
+// getSummary reminds of collections in the evening before and in the morning of the collection.
+func getSummary(bins []BinCollections, eveningHour int, now time.Time) []string {
+	today := toDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var summary []string
+	for _, bin := range bins {
+		emoji := bin.Bin.Emoji
+		if emoji == "" {
+			emoji = "🗑️"
+		}
+
+		next := bin.Dates[0]
+		if next.Equal(today) && now.Hour() < 12 {
+			summary = append(summary, fmt.Sprintf("%s %s bin today", emoji, bin.Bin.Name))
+		} else if next.Equal(tomorrow) && now.Hour() >= eveningHour {
+			summary = append(summary, fmt.Sprintf("%s %s bin tomorrow", emoji, bin.Bin.Name))
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,198 @@
+package waste
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"gopkg.in/yaml.v3"
+)
+
+const dateLayout = "2006-01-02"
+
+type Source interface {
+	GetCollections(ctx context.Context, from, to time.Time) ([]Collection, error)
+}
+
+// IcsSource reads collections from an iCalendar file or URL as offered by most municipalities.
+type IcsSource struct {
+	location   string
+	httpClient *http.Client
+}
+
+func NewIcsSource(location string, client *http.Client) (*IcsSource, error) {
+	if location == "" {
+		return nil, errors.New("empty location supplied")
+	}
+
+	if client == nil {
+		return nil, errors.New("empty http client provided")
+	}
+
+	return &IcsSource{
+		location:   location,
+		httpClient: client,
+	}, nil
+}
+
+func (s *IcsSource) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		return os.ReadFile(s.location)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.location, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (s *IcsSource) GetCollections(ctx context.Context, from, to time.Time) ([]Collection, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ics from %q: %w", s.location, err)
+	}
+
+	return parseIcs(data, from, to)
+}
+
+func parseIcs(data []byte, from, to time.Time) ([]Collection, error) {
+	cal, err := ical.NewDecoder(bytes.NewReader(data)).Decode()
+	if err != nil {
+		return nil, fmt.Errorf("could not parse ics: %w", err)
+	}
+
+	var ret []Collection
+	for _, event := range cal.Events() {
+		summary, err := event.Props.Text(ical.PropSummary)
+		if err != nil || summary == "" {
+			continue
+		}
+
+		start, err := event.DateTimeStart(time.Local)
+		if err != nil {
+			continue
+		}
+
+		dates := []time.Time{start}
+		recurrences, err := event.RecurrenceSet(time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse recurrence of %q: %w", summary, err)
+		}
+		if recurrences != nil {
+			dates = recurrences.Between(from, to, true)
+		}
+
+		for _, date := range dates {
+			if date.Before(from) || date.After(to) {
+				continue
+			}
+			ret = append(ret, Collection{Date: toDay(date), Name: summary})
+		}
+	}
+
+	return ret, nil
+}
+
+// ListSource reads collections from a YAML or CSV file with date and bin per collection.
+type ListSource struct {
+	file string
+}
+
+func NewListSource(file string) (*ListSource, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml", ".csv":
+		return &ListSource{file: file}, nil
+	default:
+		return nil, fmt.Errorf("unsupported file type %q, expected yaml or csv", file)
+	}
+}
+
+type listEntry struct {
+	Date string `yaml:"date"`
+	Bin  string `yaml:"bin"`
+}
+
+func (s *ListSource) GetCollections(_ context.Context, from, to time.Time) ([]Collection, error) {
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []listEntry
+	if strings.ToLower(filepath.Ext(s.file)) == ".csv" {
+		entries, err = parseCsv(data)
+	} else {
+		err = yaml.Unmarshal(data, &entries)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %q: %w", s.file, err)
+	}
+
+	var ret []Collection
+	for _, entry := range entries {
+		date, err := time.ParseInLocation(dateLayout, strings.TrimSpace(entry.Date), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q in %q: %w", entry.Date, s.file, err)
+		}
+
+		if date.Before(toDay(from)) || date.After(to) {
+			continue
+		}
+
+		ret = append(ret, Collection{Date: date, Name: strings.TrimSpace(entry.Bin)})
+	}
+
+	return ret, nil
+}
+
+func parseCsv(data []byte) ([]listEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []listEntry
+	for index, record := range records {
+		// skip an optional header
+		if index == 0 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		ret = append(ret, listEntry{Date: record[0], Bin: record[1]})
+	}
+
+	return ret, nil
+}
+
+func toDay(t time.Time) time.Time {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,129 @@
+package waste
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"math"
+	"time"
+
+	"github.com/soerenschneider/aether/internal"
+	"github.com/soerenschneider/aether/internal/templates"
+	"github.com/soerenschneider/aether/pkg"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultHorizon     = 60 * 24 * time.Hour
+	defaultCount       = 2
+	defaultEveningHour = 16
+)
+
+type WasteDatasource struct {
+	source      Source
+	bins        []Bin
+	horizon     time.Duration
+	count       int
+	eveningHour int
+
+	defaultTemplate    *template.Template
+	simpleTemplate     *template.Template
+	excludeFromSummary bool
+}
+
+type Opt func(datasource *WasteDatasource) error
+
+var funcMap = template.FuncMap{
+	"weekday": func(t time.Time) string {
+		return t.Weekday().String()[:3]
+	},
+	"daysUntil": func(t time.Time) int {
+		return int(math.Round(t.Sub(toDay(time.Now())).Hours() / 24))
+	},
+}
+
+func New(source Source, templateData templates.TemplateData, opts ...Opt) (*WasteDatasource, error) {
+	if source == nil {
+		return nil, errors.New("nil source passed")
+	}
+
+	if err := templateData.Validate(); err != nil {
+		return nil, err
+	}
+
+	ds := &WasteDatasource{
+		source:      source,
+		bins:        DefaultBins,
+		horizon:     defaultHorizon,
+		count:       defaultCount,
+		eveningHour: defaultEveningHour,
+	}
+
+	var err error
+	ds.defaultTemplate, err = template.New("waste-default").Funcs(funcMap).Parse(string(templateData.DefaultTemplate))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(templateData.SimpleTemplate) > 0 {
+		ds.simpleTemplate, err = template.New("waste-simple").Funcs(funcMap).Parse(string(templateData.SimpleTemplate))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs error
+	for _, opt := range opts {
+		if err := opt(ds); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	return ds, errs
+}
+
+func (d *WasteDatasource) Name() string {
+	return "Waste Collection"
+}
+
+func (d *WasteDatasource) GetData(ctx context.Context) (*internal.Data, error) {
+	now := time.Now()
+	today := toDay(now)
+
+	collections, err := d.source.GetCollections(ctx, today, now.Add(d.horizon))
+	if err != nil {
+		return nil, err
+	}
+
+	data := WasteData{
+		HtmlId:   pkg.NameToId(d.Name()),
+		Bins:     buildBinCollections(collections, d.bins, d.count, today),
+		Today:    today,
+		Tomorrow: today.AddDate(0, 0, 1),
+	}
+
+	var defaultTemplateData bytes.Buffer
+	if err := d.defaultTemplate.Execute(&defaultTemplateData, data); err != nil {
+		return nil, fmt.Errorf("could not render 'regular' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+	}
+
+	var simpleTemplateData bytes.Buffer
+	if d.simpleTemplate != nil {
+		if err := d.simpleTemplate.Execute(&simpleTemplateData, data); err != nil {
+			return nil, fmt.Errorf("could not render 'simple' template for datasource %q: %w", d.Name(), internal.ErrTemplate)
+		}
+	}
+
+	var summary []string
+	if !d.excludeFromSummary {
+		summary = getSummary(data.Bins, d.eveningHour, now)
+	}
+
+	return &internal.Data{
+		Summary:                    summary,
+		RenderedDefaultTemplate:    defaultTemplateData.Bytes(),
+		RenderedSimplifiedTemplate: simpleTemplateData.Bytes(),
+	}, nil
+}
@@ -0,0 +1,141 @@
+package waste
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const icsFixture = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Abfallkalender//DE
+BEGIN:VEVENT
+UID:1@example.com
+DTSTAMP:20250101T000000Z
+DTSTART;VALUE=DATE:20250314
+SUMMARY:Altpapier
+END:VEVENT
+BEGIN:VEVENT
+UID:2@example.com
+DTSTAMP:20250101T000000Z
+DTSTART;VALUE=DATE:20250401
+SUMMARY:Altpapier
+END:VEVENT
+BEGIN:VEVENT
+UID:3@example.com
+DTSTAMP:20250101T000000Z
+DTSTART;VALUE=DATE:20250303
+RRULE:FREQ=WEEKLY;INTERVAL=2
+SUMMARY:Restmüll
+END:VEVENT
+BEGIN:VEVENT
+UID:4@example.com
+DTSTAMP:20250101T000000Z
+DTSTART;VALUE=DATE:20250201
+SUMMARY:Gelber Sack
+END:VEVENT
+END:VCALENDAR
+`
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseIcs(t *testing.T) {
+	got, err := parseIcs([]byte(icsFixture), day(2025, 3, 10), day(2025, 4, 10))
+	if err != nil {
+		t.Fatalf("parseIcs() error = %v", err)
+	}
+
+	want := []Collection{
+		{Date: day(2025, 3, 14), Name: "Altpapier"},
+		{Date: day(2025, 4, 1), Name: "Altpapier"},
+		{Date: day(2025, 3, 17), Name: "Restmüll"},
+		{Date: day(2025, 3, 31), Name: "Restmüll"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseIcs() = %v, want %v", got, want)
+	}
+}
+
+func TestListSource(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "bins.yaml")
+	csvFile := filepath.Join(dir, "bins.csv")
+
+	if err := os.WriteFile(yamlFile, []byte("- date: 2025-03-01\n  bin: paper\n- date: 2025-03-14\n  bin: paper\n- date: 2025-03-15\n  bin: organic\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(csvFile, []byte("date,bin\n# comment\n2025-03-01,paper\n2025-03-14, paper\n2025-03-15,organic\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Collection{
+		{Date: day(2025, 3, 14), Name: "paper"},
+		{Date: day(2025, 3, 15), Name: "organic"},
+	}
+
+	for _, file := range []string{yamlFile, csvFile} {
+		source, err := NewListSource(file)
+		if err != nil {
+			t.Fatalf("NewListSource() error = %v", err)
+		}
+
+		got, err := source.GetCollections(context.Background(), day(2025, 3, 10).Add(8*time.Hour), day(2025, 4, 10))
+		if err != nil {
+			t.Fatalf("GetCollections() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetCollections(%s) = %v, want %v", filepath.Ext(file), got, want)
+		}
+	}
+
+	if _, err := NewListSource(filepath.Join(dir, "bins.json")); err == nil {
+		t.Error("expected error for unsupported file type")
+	}
+}
+
+func TestReport(t *testing.T) {
+	collections := []Collection{
+		{Date: day(2025, 3, 31), Name: "Restmüll"},
+		{Date: day(2025, 3, 14), Name: "Altpapier"},
+		{Date: day(2025, 3, 17), Name: "Restmüll"},
+		{Date: day(2025, 4, 11), Name: "Altpapier"},
+		{Date: day(2025, 3, 28), Name: "Papier"},
+		{Date: day(2025, 3, 14), Name: "Altpapier"},
+		{Date: day(2025, 3, 12), Name: "Sperrmüll"},
+		{Date: day(2025, 3, 20), Name: "Sperrmüll"},
+	}
+
+	got := buildBinCollections(collections, DefaultBins, 2, day(2025, 3, 13))
+	want := []BinCollections{
+		{Bin: DefaultBins[0], Dates: []time.Time{day(2025, 3, 14), day(2025, 3, 28)}},
+		{Bin: DefaultBins[4], Dates: []time.Time{day(2025, 3, 17), day(2025, 3, 31)}},
+		{Bin: Bin{Name: "Sperrmüll", Emoji: "🗑️"}, Dates: []time.Time{day(2025, 3, 20)}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildBinCollections() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{name: "afternoon before", now: day(2025, 3, 13).Add(15 * time.Hour), want: nil},
+		{name: "evening before", now: day(2025, 3, 13).Add(18 * time.Hour), want: []string{"📦 Paper bin tomorrow"}},
+		{name: "morning", now: day(2025, 3, 14).Add(7 * time.Hour), want: []string{"📦 Paper bin today"}},
+		{name: "evening before unknown bin", now: day(2025, 3, 19).Add(18 * time.Hour), want: []string{"🗑️ Sperrmüll bin tomorrow"}},
+		{name: "noon", now: day(2025, 3, 14).Add(12 * time.Hour), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSummary(got, 16, tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package pkg
+
+import "testing"
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		bytes uint64
+		want  string
+	}{
+		{bytes: 0, want: "0 B"},
+		{bytes: 1023, want: "1023 B"},
+		{bytes: 1024, want: "1.0 KiB"},
+		{bytes: 1536, want: "1.5 KiB"},
+		{bytes: 5 * 1024 * 1024 * 1024, want: "5.0 GiB"},
+		{bytes: 3 * 1024 * 1024 * 1024 * 1024 * 1024, want: "3.0 PiB"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatBytes(tt.bytes); got != tt.want {
+				t.Errorf("FormatBytes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
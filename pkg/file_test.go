@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteJSONAtomic(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "state.json")
+
+	if err := os.WriteFile(file, []byte("outdated"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteJSONAtomic(file, map[string]int{"a": 1}); err != nil {
+		t.Fatalf("WriteJSONAtomic() error = %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"a":1}` {
+		t.Errorf("file content = %q, want %q", data, `{"a":1}`)
+	}
+
+	// no temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the state file, got %v", entries)
+	}
+
+	if err := WriteJSONAtomic(filepath.Join(dir, "missing", "state.json"), nil); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
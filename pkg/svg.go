@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strings"
+)
+
+// Sparkline renders the values as an inline SVG polyline that is scaled between the minimum and maximum value to fit
+// the given dimensions. If fromZero is set, the baseline is zero instead of the minimum, which suits counts where a
+// flat line should not look like nothing happened. The line is drawn using the current text color.
+func Sparkline(values []float64, width, height int, fromZero bool) template.HTML {
+	if len(values) == 0 {
+		return ""
+	}
+
+	minVal, maxVal := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		minVal = math.Min(minVal, v)
+		maxVal = math.Max(maxVal, v)
+	}
+	if fromZero {
+		minVal = math.Min(minVal, 0)
+	}
+
+	const padding = 1.
+	w, h := float64(width), float64(height)
+	points := make([]string, 0, len(values))
+	for i, v := range values {
+		x := 0.
+		if len(values) > 1 {
+			x = float64(i) * w / float64(len(values)-1)
+		}
+		y := h - padding
+		if maxVal > minVal {
+			y -= (v - minVal) / (maxVal - minVal) * (h - 2*padding)
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+
+	// the svg only contains numbers formatted by us, so it is safe to not escape it
+	return template.HTML(fmt.Sprintf(`<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="%s"/></svg>`,
+		width, height, width, height, strings.Join(points, " ")))
+}
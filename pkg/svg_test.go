@@ -0,0 +1,46 @@
+package pkg
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		fromZero bool
+		want     string
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			want:   "",
+		},
+		{
+			name:   "all zero",
+			values: []float64{0, 0},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,19.0 120.0,19.0"/></svg>`,
+		},
+		{
+			name:   "scaled between min and max",
+			values: []float64{100, 110, 120},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,19.0 60.0,10.0 120.0,1.0"/></svg>`,
+		},
+		{
+			name:   "scaled to max",
+			values: []float64{0, 9, 18},
+			want:   `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,19.0 60.0,10.0 120.0,1.0"/></svg>`,
+		},
+		{
+			name:     "scaled from zero",
+			values:   []float64{9, 18, 18},
+			fromZero: true,
+			want:     `<svg class="sparkline" xmlns="http://www.w3.org/2000/svg" width="120" height="20" viewBox="0 0 120 20"><polyline fill="none" stroke="currentColor" stroke-width="1.5" points="0.0,10.0 60.0,1.0 120.0,1.0"/></svg>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(Sparkline(tt.values, 120, 20, tt.fromZero)); got != tt.want {
+				t.Errorf("Sparkline() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -88,21 +89,28 @@ func DurationToString(duration time.Duration) string {
 	months := (days % 365) / 30
 	remainingDays := (days % 365) % 30
 
-	var result string
+	var parts []string
 
 	if years > 0 {
-		result += fmt.Sprintf("%d years, ", years)
+		parts = append(parts, pluralize(years, "year"))
 	}
 
 	if months > 0 {
-		result += fmt.Sprintf("%d months, ", months)
+		parts = append(parts, pluralize(months, "month"))
 	}
 
 	if remainingDays > 0 || (years == 0 && months == 0) {
-		result += fmt.Sprintf("%d days", remainingDays)
+		parts = append(parts, pluralize(remainingDays, "day"))
 	}
 
-	return result
+	return strings.Join(parts, ", ")
+}
+
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
 }
 
 func IsWholeDay(start, end time.Time) bool {
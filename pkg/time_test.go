@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationToString(t *testing.T) {
+	const day = 24 * time.Hour
+
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 0, want: "0 days"},
+		{duration: 23 * time.Hour, want: "0 days"},
+		{duration: day, want: "1 day"},
+		{duration: 5 * day, want: "5 days"},
+		{duration: 30 * day, want: "1 month"},
+		{duration: 92 * day, want: "3 months, 2 days"},
+		{duration: 365 * day, want: "1 year"},
+		{duration: 365*day + 61*day, want: "1 year, 2 months, 1 day"},
+		{duration: 2*365*day + 3*day, want: "2 years, 3 days"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := DurationToString(tt.duration); got != tt.want {
+				t.Errorf("DurationToString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}